@@ -2,13 +2,16 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
 	// Server
-	Port string
+	Port    string
+	BaseURL string
 
 	// Database
 	DBHost     string
@@ -17,16 +20,83 @@ type Config struct {
 	DBPassword string
 	DBName     string
 
+	// Redis
+	RedisAddr     string
+	RedisPassword string
+	RedisDB       int
+
 	// JWT
 	JWTSecret string
 
 	// Web3
-	InfuraID        string
-	ContractAddress string
-	ChainID         string
+	InfuraID            string
+	ContractAddress     string
+	ChainID             string
+	ContractDeployments string
+
+	// Operator signer
+	OperatorSigner               string
+	OperatorKeystore             string
+	OperatorKeystorePasswordFile string
+	OperatorKeyFile              string
+	OperatorSignerURL            string
+	OperatorSignerMethod         string
+	OperatorAddress              string
+
+	// RPC providers
+	RPCEndpoints         string
+	RPCRequestsPerSecond float64
+	RPCHealthInterval    time.Duration
+	RPCMaxBlockLag       int
+
+	// Event indexer
+	IndexerConfirmations int
+	IndexerStartBlock    int
+	IndexerBlockRange    int
+	IndexerPollInterval  time.Duration
+
+	// Transaction manager
+	TxPollInterval   time.Duration
+	TxStuckAfter     time.Duration
+	TxFeeBumpPercent int
+	TxMaxFeeGwei     int
 
 	// Storage
 	UploadDir string
+
+	// Moderation
+	ImageHashMaxDistance int
+
+	// Checkout
+	ReservationTTL time.Duration
+
+	// Fulfilment
+	ShippingSLA        time.Duration
+	CarrierTrackingDir string
+
+	// Reconciliation
+	ReconcileInterval   time.Duration
+	ReconcileAutoRepair bool
+	ReconcileBlockRange int
+
+	// Reports
+	FiatRatesFile string
+
+	// Authentication
+	AuthPassScore          float64
+	AuthFailScore          float64
+	AuthenticatorEndpoints string
+	AuthenticatorTimeout   time.Duration
+	AuthReviewSLA          time.Duration
+	SecondOpinionPrice     float64
+	AuthValidity           time.Duration
+	AuthValidityByCategory string
+
+	// Certificates
+	CertificateSigningKey string
+
+	// Evidence
+	EvidenceRootInMetadata bool
 }
 
 var AppConfig Config
@@ -37,7 +107,8 @@ func LoadConfig() error {
 
 	AppConfig = Config{
 		// Server
-		Port: getEnvOrDefault("PORT", "8080"),
+		Port:    getEnvOrDefault("PORT", "8080"),
+		BaseURL: getEnvOrDefault("BASE_URL", "http://localhost:8080"),
 
 		// Database
 		DBHost:     getEnvOrDefault("DB_HOST", "localhost"),
@@ -46,16 +117,83 @@ func LoadConfig() error {
 		DBPassword: getEnvOrDefault("DB_PASSWORD", "postgres"),
 		DBName:     getEnvOrDefault("DB_NAME", "revibe"),
 
+		// Redis
+		RedisAddr:     getEnvOrDefault("REDIS_ADDR", "localhost:6379"),
+		RedisPassword: getEnvOrDefault("REDIS_PASSWORD", ""),
+		RedisDB:       getEnvAsIntOrDefault("REDIS_DB", 0),
+
 		// JWT
 		JWTSecret: getEnvOrDefault("JWT_SECRET", "your-secret-key"),
 
 		// Web3
-		InfuraID:        getEnvOrDefault("INFURA_ID", ""),
-		ContractAddress: getEnvOrDefault("CONTRACT_ADDRESS", ""),
-		ChainID:         getEnvOrDefault("CHAIN_ID", "1"),
+		InfuraID:            getEnvOrDefault("INFURA_ID", ""),
+		ContractAddress:     getEnvOrDefault("CONTRACT_ADDRESS", ""),
+		ChainID:             getEnvOrDefault("CHAIN_ID", "1"),
+		ContractDeployments: getEnvOrDefault("CONTRACT_DEPLOYMENTS", ""),
+
+		// Operator signer
+		OperatorSigner:               getEnvOrDefault("OPERATOR_SIGNER", ""),
+		OperatorKeystore:             getEnvOrDefault("OPERATOR_KEYSTORE", ""),
+		OperatorKeystorePasswordFile: getEnvOrDefault("OPERATOR_KEYSTORE_PASSWORD_FILE", ""),
+		OperatorKeyFile:              getEnvOrDefault("OPERATOR_KEY_FILE", ""),
+		OperatorSignerURL:            getEnvOrDefault("OPERATOR_SIGNER_URL", ""),
+		OperatorSignerMethod:         getEnvOrDefault("OPERATOR_SIGNER_METHOD", "eth_signTransaction"),
+		OperatorAddress:              getEnvOrDefault("OPERATOR_ADDRESS", ""),
+
+		// RPC providers
+		RPCEndpoints:         getEnvOrDefault("RPC_ENDPOINTS", ""),
+		RPCRequestsPerSecond: getEnvAsFloatOrDefault("RPC_REQUESTS_PER_SECOND", 10),
+		RPCHealthInterval:    getEnvAsDurationOrDefault("RPC_HEALTH_INTERVAL", 15*time.Second),
+		RPCMaxBlockLag:       getEnvAsIntOrDefault("RPC_MAX_BLOCK_LAG", 5),
+
+		// Event indexer
+		IndexerConfirmations: getEnvAsIntOrDefault("INDEXER_CONFIRMATIONS", 12),
+		IndexerStartBlock:    getEnvAsIntOrDefault("INDEXER_START_BLOCK", 0),
+		IndexerBlockRange:    getEnvAsIntOrDefault("INDEXER_BLOCK_RANGE", 2000),
+		IndexerPollInterval:  getEnvAsDurationOrDefault("INDEXER_POLL_INTERVAL", 15*time.Second),
+
+		// Transaction manager
+		TxPollInterval:   getEnvAsDurationOrDefault("TX_POLL_INTERVAL", 15*time.Second),
+		TxStuckAfter:     getEnvAsDurationOrDefault("TX_STUCK_AFTER", 3*time.Minute),
+		TxFeeBumpPercent: getEnvAsIntOrDefault("TX_FEE_BUMP_PERCENT", 15),
+		TxMaxFeeGwei:     getEnvAsIntOrDefault("TX_MAX_FEE_GWEI", 500),
 
 		// Storage
 		UploadDir: getEnvOrDefault("UPLOAD_DIR", "uploads"),
+
+		// Moderation
+		ImageHashMaxDistance: getEnvAsIntOrDefault("IMAGE_HASH_MAX_DISTANCE", 10),
+
+		// Checkout
+		ReservationTTL: getEnvAsDurationOrDefault("RESERVATION_TTL", 10*time.Minute),
+
+		// Fulfilment
+		ShippingSLA:        getEnvAsDurationOrDefault("SHIPPING_SLA", 72*time.Hour),
+		CarrierTrackingDir: getEnvOrDefault("CARRIER_TRACKING_DIR", ""),
+
+		// Reconciliation
+		ReconcileInterval:   getEnvAsDurationOrDefault("RECONCILE_INTERVAL", 6*time.Hour),
+		ReconcileAutoRepair: getEnvAsBoolOrDefault("RECONCILE_AUTO_REPAIR", false),
+		ReconcileBlockRange: getEnvAsIntOrDefault("RECONCILE_BLOCK_RANGE", 5000),
+
+		// Reports
+		FiatRatesFile: getEnvOrDefault("FIAT_RATES_FILE", ""),
+
+		// Authentication
+		AuthPassScore:          getEnvAsFloatOrDefault("AUTH_PASS_SCORE", 80),
+		AuthFailScore:          getEnvAsFloatOrDefault("AUTH_FAIL_SCORE", 40),
+		AuthenticatorEndpoints: getEnvOrDefault("AUTHENTICATOR_ENDPOINTS", ""),
+		AuthenticatorTimeout:   getEnvAsDurationOrDefault("AUTHENTICATOR_TIMEOUT", 30*time.Second),
+		AuthReviewSLA:          getEnvAsDurationOrDefault("AUTH_REVIEW_SLA", 48*time.Hour),
+		SecondOpinionPrice:     getEnvAsFloatOrDefault("SECOND_OPINION_PRICE", 1000),
+		AuthValidity:           getEnvAsDurationOrDefault("AUTH_VALIDITY", 365*24*time.Hour),
+		AuthValidityByCategory: getEnvOrDefault("AUTH_VALIDITY_BY_CATEGORY", ""),
+
+		// Certificates
+		CertificateSigningKey: getEnvOrDefault("CERTIFICATE_SIGNING_KEY", ""),
+
+		// Evidence
+		EvidenceRootInMetadata: getEnvAsBoolOrDefault("EVIDENCE_ROOT_IN_METADATA", false),
 	}
 
 	return nil
@@ -68,6 +206,34 @@ func getEnvOrDefault(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getEnvAsIntOrDefault(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvAsDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvAsFloatOrDefault(key string, defaultValue float64) float64 {
+	if value, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvAsBoolOrDefault(key string, defaultValue bool) bool {
+	if value, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
 // GetDSN returns the database connection string
 func (c *Config) GetDSN() string {
 	return "host=" + c.DBHost +
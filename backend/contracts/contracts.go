@@ -0,0 +1,13 @@
+// Package contracts holds the Go bindings for the ReVibe smart contract.
+//
+// revibe.go is generated by abigen from ReVibe.abi, the ABI of
+// contracts/ReVibe.sol as compiled by hardhat. After changing the contract,
+// run `npm run export-abi` in contracts/ and then `go generate ./contracts`
+// here; the tests fail while the ABI, the bindings and the Solidity source
+// disagree.
+//
+// export-abi also writes ReVibe.bin, the contract's bytecode, which the
+// services tests deploy on a simulated chain.
+package contracts
+
+//go:generate go run github.com/ethereum/go-ethereum/cmd/abigen --abi ReVibe.abi --pkg contracts --type ReVibeContract --out revibe.go
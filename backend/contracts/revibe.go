@@ -0,0 +1,2227 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+	_ = time.Tick
+	_ = context.Background
+)
+
+// ReVibeContractMetaData contains all meta data concerning the ReVibeContract contract.
+var ReVibeContractMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"approved\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"Approval\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"approved\",\"type\":\"bool\"}],\"name\":\"ApprovalForAll\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"newPrice\",\"type\":\"uint256\"}],\"name\":\"PriceUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"authenticated\",\"type\":\"bool\"}],\"name\":\"ProductAuthenticated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"seller\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"price\",\"type\":\"uint256\"}],\"name\":\"ProductListed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"seller\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"buyer\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"price\",\"type\":\"uint256\"}],\"name\":\"ProductSold\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"Transfer\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"authenticated\",\"type\":\"bool\"}],\"name\":\"authenticateProduct\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"buyProduct\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"getApproved\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"}],\"name\":\"getUserProducts\",\"outputs\":[{\"internalType\":\"uint256[]\",\"name\":\"\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"}],\"name\":\"getUserPurchases\",\"outputs\":[{\"internalType\":\"uint256[]\",\"name\":\"\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"isApprovedForAll\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"price\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"metadata\",\"type\":\"string\"}],\"name\":\"listProduct\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"ownerOf\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"platformFee\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"products\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"id\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"seller\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"price\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"isAuthenticated\",\"type\":\"bool\"},{\"internalType\":\"bool\",\"name\":\"isSold\",\"type\":\"bool\"},{\"internalType\":\"string\",\"name\":\"metadata\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"safeTransferFrom\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"data\",\"type\":\"bytes\"}],\"name\":\"safeTransferFrom\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"approved\",\"type\":\"bool\"}],\"name\":\"setApprovalForAll\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes4\",\"name\":\"interfaceId\",\"type\":\"bytes4\"}],\"name\":\"supportsInterface\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"symbol\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"tokenURI\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"transferFrom\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"newFee\",\"type\":\"uint256\"}],\"name\":\"updatePlatformFee\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"newPrice\",\"type\":\"uint256\"}],\"name\":\"updatePrice\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"userProducts\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"userPurchases\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"withdraw\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// ReVibeContractABI is the input ABI used to generate the binding from.
+// Deprecated: Use ReVibeContractMetaData.ABI instead.
+var ReVibeContractABI = ReVibeContractMetaData.ABI
+
+// ReVibeContract is an auto generated Go binding around an Ethereum contract.
+type ReVibeContract struct {
+	ReVibeContractCaller     // Read-only binding to the contract
+	ReVibeContractTransactor // Write-only binding to the contract
+	ReVibeContractFilterer   // Log filterer for contract events
+}
+
+// ReVibeContractCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ReVibeContractCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ReVibeContractTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ReVibeContractTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ReVibeContractFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ReVibeContractFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ReVibeContractSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ReVibeContractSession struct {
+	Contract     *ReVibeContract   // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// ReVibeContractCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ReVibeContractCallerSession struct {
+	Contract *ReVibeContractCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts         // Call options to use throughout this session
+}
+
+// ReVibeContractTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ReVibeContractTransactorSession struct {
+	Contract     *ReVibeContractTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts         // Transaction auth options to use throughout this session
+}
+
+// ReVibeContractRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ReVibeContractRaw struct {
+	Contract *ReVibeContract // Generic contract binding to access the raw methods on
+}
+
+// ReVibeContractCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ReVibeContractCallerRaw struct {
+	Contract *ReVibeContractCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ReVibeContractTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ReVibeContractTransactorRaw struct {
+	Contract *ReVibeContractTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewReVibeContract creates a new instance of ReVibeContract, bound to a specific deployed contract.
+func NewReVibeContract(address common.Address, backend bind.ContractBackend) (*ReVibeContract, error) {
+	contract, err := bindReVibeContract(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ReVibeContract{ReVibeContractCaller: ReVibeContractCaller{contract: contract}, ReVibeContractTransactor: ReVibeContractTransactor{contract: contract}, ReVibeContractFilterer: ReVibeContractFilterer{contract: contract}}, nil
+}
+
+// NewReVibeContractCaller creates a new read-only instance of ReVibeContract, bound to a specific deployed contract.
+func NewReVibeContractCaller(address common.Address, caller bind.ContractCaller) (*ReVibeContractCaller, error) {
+	contract, err := bindReVibeContract(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ReVibeContractCaller{contract: contract}, nil
+}
+
+// NewReVibeContractTransactor creates a new write-only instance of ReVibeContract, bound to a specific deployed contract.
+func NewReVibeContractTransactor(address common.Address, transactor bind.ContractTransactor) (*ReVibeContractTransactor, error) {
+	contract, err := bindReVibeContract(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ReVibeContractTransactor{contract: contract}, nil
+}
+
+// NewReVibeContractFilterer creates a new log filterer instance of ReVibeContract, bound to a specific deployed contract.
+func NewReVibeContractFilterer(address common.Address, filterer bind.ContractFilterer) (*ReVibeContractFilterer, error) {
+	contract, err := bindReVibeContract(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ReVibeContractFilterer{contract: contract}, nil
+}
+
+// bindReVibeContract binds a generic wrapper to an already deployed contract.
+func bindReVibeContract(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := ReVibeContractMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ReVibeContract *ReVibeContractRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ReVibeContract.Contract.ReVibeContractCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ReVibeContract *ReVibeContractRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.ReVibeContractTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ReVibeContract *ReVibeContractRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.ReVibeContractTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ReVibeContract *ReVibeContractCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ReVibeContract.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ReVibeContract *ReVibeContractTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ReVibeContract *ReVibeContractTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.contract.Transact(opts, method, params...)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address owner) view returns(uint256)
+func (_ReVibeContract *ReVibeContractCaller) BalanceOf(opts *bind.CallOpts, owner common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _ReVibeContract.contract.Call(opts, &out, "balanceOf", owner)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address owner) view returns(uint256)
+func (_ReVibeContract *ReVibeContractSession) BalanceOf(owner common.Address) (*big.Int, error) {
+	return _ReVibeContract.Contract.BalanceOf(&_ReVibeContract.CallOpts, owner)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address owner) view returns(uint256)
+func (_ReVibeContract *ReVibeContractCallerSession) BalanceOf(owner common.Address) (*big.Int, error) {
+	return _ReVibeContract.Contract.BalanceOf(&_ReVibeContract.CallOpts, owner)
+}
+
+// GetApproved is a free data retrieval call binding the contract method 0x081812fc.
+//
+// Solidity: function getApproved(uint256 tokenId) view returns(address)
+func (_ReVibeContract *ReVibeContractCaller) GetApproved(opts *bind.CallOpts, tokenId *big.Int) (common.Address, error) {
+	var out []interface{}
+	err := _ReVibeContract.contract.Call(opts, &out, "getApproved", tokenId)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// GetApproved is a free data retrieval call binding the contract method 0x081812fc.
+//
+// Solidity: function getApproved(uint256 tokenId) view returns(address)
+func (_ReVibeContract *ReVibeContractSession) GetApproved(tokenId *big.Int) (common.Address, error) {
+	return _ReVibeContract.Contract.GetApproved(&_ReVibeContract.CallOpts, tokenId)
+}
+
+// GetApproved is a free data retrieval call binding the contract method 0x081812fc.
+//
+// Solidity: function getApproved(uint256 tokenId) view returns(address)
+func (_ReVibeContract *ReVibeContractCallerSession) GetApproved(tokenId *big.Int) (common.Address, error) {
+	return _ReVibeContract.Contract.GetApproved(&_ReVibeContract.CallOpts, tokenId)
+}
+
+// GetUserProducts is a free data retrieval call binding the contract method 0xfee7f53b.
+//
+// Solidity: function getUserProducts(address user) view returns(uint256[])
+func (_ReVibeContract *ReVibeContractCaller) GetUserProducts(opts *bind.CallOpts, user common.Address) ([]*big.Int, error) {
+	var out []interface{}
+	err := _ReVibeContract.contract.Call(opts, &out, "getUserProducts", user)
+
+	if err != nil {
+		return *new([]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+
+	return out0, err
+
+}
+
+// GetUserProducts is a free data retrieval call binding the contract method 0xfee7f53b.
+//
+// Solidity: function getUserProducts(address user) view returns(uint256[])
+func (_ReVibeContract *ReVibeContractSession) GetUserProducts(user common.Address) ([]*big.Int, error) {
+	return _ReVibeContract.Contract.GetUserProducts(&_ReVibeContract.CallOpts, user)
+}
+
+// GetUserProducts is a free data retrieval call binding the contract method 0xfee7f53b.
+//
+// Solidity: function getUserProducts(address user) view returns(uint256[])
+func (_ReVibeContract *ReVibeContractCallerSession) GetUserProducts(user common.Address) ([]*big.Int, error) {
+	return _ReVibeContract.Contract.GetUserProducts(&_ReVibeContract.CallOpts, user)
+}
+
+// GetUserPurchases is a free data retrieval call binding the contract method 0xc6e5d8f6.
+//
+// Solidity: function getUserPurchases(address user) view returns(uint256[])
+func (_ReVibeContract *ReVibeContractCaller) GetUserPurchases(opts *bind.CallOpts, user common.Address) ([]*big.Int, error) {
+	var out []interface{}
+	err := _ReVibeContract.contract.Call(opts, &out, "getUserPurchases", user)
+
+	if err != nil {
+		return *new([]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+
+	return out0, err
+
+}
+
+// GetUserPurchases is a free data retrieval call binding the contract method 0xc6e5d8f6.
+//
+// Solidity: function getUserPurchases(address user) view returns(uint256[])
+func (_ReVibeContract *ReVibeContractSession) GetUserPurchases(user common.Address) ([]*big.Int, error) {
+	return _ReVibeContract.Contract.GetUserPurchases(&_ReVibeContract.CallOpts, user)
+}
+
+// GetUserPurchases is a free data retrieval call binding the contract method 0xc6e5d8f6.
+//
+// Solidity: function getUserPurchases(address user) view returns(uint256[])
+func (_ReVibeContract *ReVibeContractCallerSession) GetUserPurchases(user common.Address) ([]*big.Int, error) {
+	return _ReVibeContract.Contract.GetUserPurchases(&_ReVibeContract.CallOpts, user)
+}
+
+// IsApprovedForAll is a free data retrieval call binding the contract method 0xe985e9c5.
+//
+// Solidity: function isApprovedForAll(address owner, address operator) view returns(bool)
+func (_ReVibeContract *ReVibeContractCaller) IsApprovedForAll(opts *bind.CallOpts, owner common.Address, operator common.Address) (bool, error) {
+	var out []interface{}
+	err := _ReVibeContract.contract.Call(opts, &out, "isApprovedForAll", owner, operator)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsApprovedForAll is a free data retrieval call binding the contract method 0xe985e9c5.
+//
+// Solidity: function isApprovedForAll(address owner, address operator) view returns(bool)
+func (_ReVibeContract *ReVibeContractSession) IsApprovedForAll(owner common.Address, operator common.Address) (bool, error) {
+	return _ReVibeContract.Contract.IsApprovedForAll(&_ReVibeContract.CallOpts, owner, operator)
+}
+
+// IsApprovedForAll is a free data retrieval call binding the contract method 0xe985e9c5.
+//
+// Solidity: function isApprovedForAll(address owner, address operator) view returns(bool)
+func (_ReVibeContract *ReVibeContractCallerSession) IsApprovedForAll(owner common.Address, operator common.Address) (bool, error) {
+	return _ReVibeContract.Contract.IsApprovedForAll(&_ReVibeContract.CallOpts, owner, operator)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_ReVibeContract *ReVibeContractCaller) Name(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _ReVibeContract.contract.Call(opts, &out, "name")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_ReVibeContract *ReVibeContractSession) Name() (string, error) {
+	return _ReVibeContract.Contract.Name(&_ReVibeContract.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_ReVibeContract *ReVibeContractCallerSession) Name() (string, error) {
+	return _ReVibeContract.Contract.Name(&_ReVibeContract.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_ReVibeContract *ReVibeContractCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _ReVibeContract.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_ReVibeContract *ReVibeContractSession) Owner() (common.Address, error) {
+	return _ReVibeContract.Contract.Owner(&_ReVibeContract.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_ReVibeContract *ReVibeContractCallerSession) Owner() (common.Address, error) {
+	return _ReVibeContract.Contract.Owner(&_ReVibeContract.CallOpts)
+}
+
+// OwnerOf is a free data retrieval call binding the contract method 0x6352211e.
+//
+// Solidity: function ownerOf(uint256 tokenId) view returns(address)
+func (_ReVibeContract *ReVibeContractCaller) OwnerOf(opts *bind.CallOpts, tokenId *big.Int) (common.Address, error) {
+	var out []interface{}
+	err := _ReVibeContract.contract.Call(opts, &out, "ownerOf", tokenId)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// OwnerOf is a free data retrieval call binding the contract method 0x6352211e.
+//
+// Solidity: function ownerOf(uint256 tokenId) view returns(address)
+func (_ReVibeContract *ReVibeContractSession) OwnerOf(tokenId *big.Int) (common.Address, error) {
+	return _ReVibeContract.Contract.OwnerOf(&_ReVibeContract.CallOpts, tokenId)
+}
+
+// OwnerOf is a free data retrieval call binding the contract method 0x6352211e.
+//
+// Solidity: function ownerOf(uint256 tokenId) view returns(address)
+func (_ReVibeContract *ReVibeContractCallerSession) OwnerOf(tokenId *big.Int) (common.Address, error) {
+	return _ReVibeContract.Contract.OwnerOf(&_ReVibeContract.CallOpts, tokenId)
+}
+
+// PlatformFee is a free data retrieval call binding the contract method 0x26232a2e.
+//
+// Solidity: function platformFee() view returns(uint256)
+func (_ReVibeContract *ReVibeContractCaller) PlatformFee(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _ReVibeContract.contract.Call(opts, &out, "platformFee")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// PlatformFee is a free data retrieval call binding the contract method 0x26232a2e.
+//
+// Solidity: function platformFee() view returns(uint256)
+func (_ReVibeContract *ReVibeContractSession) PlatformFee() (*big.Int, error) {
+	return _ReVibeContract.Contract.PlatformFee(&_ReVibeContract.CallOpts)
+}
+
+// PlatformFee is a free data retrieval call binding the contract method 0x26232a2e.
+//
+// Solidity: function platformFee() view returns(uint256)
+func (_ReVibeContract *ReVibeContractCallerSession) PlatformFee() (*big.Int, error) {
+	return _ReVibeContract.Contract.PlatformFee(&_ReVibeContract.CallOpts)
+}
+
+// Products is a free data retrieval call binding the contract method 0x7acc0b20.
+//
+// Solidity: function products(uint256 ) view returns(uint256 id, address seller, uint256 price, bool isAuthenticated, bool isSold, string metadata)
+func (_ReVibeContract *ReVibeContractCaller) Products(opts *bind.CallOpts, arg0 *big.Int) (struct {
+	Id              *big.Int
+	Seller          common.Address
+	Price           *big.Int
+	IsAuthenticated bool
+	IsSold          bool
+	Metadata        string
+}, error) {
+	var out []interface{}
+	err := _ReVibeContract.contract.Call(opts, &out, "products", arg0)
+
+	outstruct := new(struct {
+		Id              *big.Int
+		Seller          common.Address
+		Price           *big.Int
+		IsAuthenticated bool
+		IsSold          bool
+		Metadata        string
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Id = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.Seller = *abi.ConvertType(out[1], new(common.Address)).(*common.Address)
+	outstruct.Price = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.IsAuthenticated = *abi.ConvertType(out[3], new(bool)).(*bool)
+	outstruct.IsSold = *abi.ConvertType(out[4], new(bool)).(*bool)
+	outstruct.Metadata = *abi.ConvertType(out[5], new(string)).(*string)
+
+	return *outstruct, err
+
+}
+
+// Products is a free data retrieval call binding the contract method 0x7acc0b20.
+//
+// Solidity: function products(uint256 ) view returns(uint256 id, address seller, uint256 price, bool isAuthenticated, bool isSold, string metadata)
+func (_ReVibeContract *ReVibeContractSession) Products(arg0 *big.Int) (struct {
+	Id              *big.Int
+	Seller          common.Address
+	Price           *big.Int
+	IsAuthenticated bool
+	IsSold          bool
+	Metadata        string
+}, error) {
+	return _ReVibeContract.Contract.Products(&_ReVibeContract.CallOpts, arg0)
+}
+
+// Products is a free data retrieval call binding the contract method 0x7acc0b20.
+//
+// Solidity: function products(uint256 ) view returns(uint256 id, address seller, uint256 price, bool isAuthenticated, bool isSold, string metadata)
+func (_ReVibeContract *ReVibeContractCallerSession) Products(arg0 *big.Int) (struct {
+	Id              *big.Int
+	Seller          common.Address
+	Price           *big.Int
+	IsAuthenticated bool
+	IsSold          bool
+	Metadata        string
+}, error) {
+	return _ReVibeContract.Contract.Products(&_ReVibeContract.CallOpts, arg0)
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_ReVibeContract *ReVibeContractCaller) SupportsInterface(opts *bind.CallOpts, interfaceId [4]byte) (bool, error) {
+	var out []interface{}
+	err := _ReVibeContract.contract.Call(opts, &out, "supportsInterface", interfaceId)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_ReVibeContract *ReVibeContractSession) SupportsInterface(interfaceId [4]byte) (bool, error) {
+	return _ReVibeContract.Contract.SupportsInterface(&_ReVibeContract.CallOpts, interfaceId)
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_ReVibeContract *ReVibeContractCallerSession) SupportsInterface(interfaceId [4]byte) (bool, error) {
+	return _ReVibeContract.Contract.SupportsInterface(&_ReVibeContract.CallOpts, interfaceId)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_ReVibeContract *ReVibeContractCaller) Symbol(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _ReVibeContract.contract.Call(opts, &out, "symbol")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_ReVibeContract *ReVibeContractSession) Symbol() (string, error) {
+	return _ReVibeContract.Contract.Symbol(&_ReVibeContract.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_ReVibeContract *ReVibeContractCallerSession) Symbol() (string, error) {
+	return _ReVibeContract.Contract.Symbol(&_ReVibeContract.CallOpts)
+}
+
+// TokenURI is a free data retrieval call binding the contract method 0xc87b56dd.
+//
+// Solidity: function tokenURI(uint256 tokenId) view returns(string)
+func (_ReVibeContract *ReVibeContractCaller) TokenURI(opts *bind.CallOpts, tokenId *big.Int) (string, error) {
+	var out []interface{}
+	err := _ReVibeContract.contract.Call(opts, &out, "tokenURI", tokenId)
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// TokenURI is a free data retrieval call binding the contract method 0xc87b56dd.
+//
+// Solidity: function tokenURI(uint256 tokenId) view returns(string)
+func (_ReVibeContract *ReVibeContractSession) TokenURI(tokenId *big.Int) (string, error) {
+	return _ReVibeContract.Contract.TokenURI(&_ReVibeContract.CallOpts, tokenId)
+}
+
+// TokenURI is a free data retrieval call binding the contract method 0xc87b56dd.
+//
+// Solidity: function tokenURI(uint256 tokenId) view returns(string)
+func (_ReVibeContract *ReVibeContractCallerSession) TokenURI(tokenId *big.Int) (string, error) {
+	return _ReVibeContract.Contract.TokenURI(&_ReVibeContract.CallOpts, tokenId)
+}
+
+// UserProducts is a free data retrieval call binding the contract method 0x3d527883.
+//
+// Solidity: function userProducts(address , uint256 ) view returns(uint256)
+func (_ReVibeContract *ReVibeContractCaller) UserProducts(opts *bind.CallOpts, arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _ReVibeContract.contract.Call(opts, &out, "userProducts", arg0, arg1)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// UserProducts is a free data retrieval call binding the contract method 0x3d527883.
+//
+// Solidity: function userProducts(address , uint256 ) view returns(uint256)
+func (_ReVibeContract *ReVibeContractSession) UserProducts(arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	return _ReVibeContract.Contract.UserProducts(&_ReVibeContract.CallOpts, arg0, arg1)
+}
+
+// UserProducts is a free data retrieval call binding the contract method 0x3d527883.
+//
+// Solidity: function userProducts(address , uint256 ) view returns(uint256)
+func (_ReVibeContract *ReVibeContractCallerSession) UserProducts(arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	return _ReVibeContract.Contract.UserProducts(&_ReVibeContract.CallOpts, arg0, arg1)
+}
+
+// UserPurchases is a free data retrieval call binding the contract method 0xcf2c0334.
+//
+// Solidity: function userPurchases(address , uint256 ) view returns(uint256)
+func (_ReVibeContract *ReVibeContractCaller) UserPurchases(opts *bind.CallOpts, arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _ReVibeContract.contract.Call(opts, &out, "userPurchases", arg0, arg1)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// UserPurchases is a free data retrieval call binding the contract method 0xcf2c0334.
+//
+// Solidity: function userPurchases(address , uint256 ) view returns(uint256)
+func (_ReVibeContract *ReVibeContractSession) UserPurchases(arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	return _ReVibeContract.Contract.UserPurchases(&_ReVibeContract.CallOpts, arg0, arg1)
+}
+
+// UserPurchases is a free data retrieval call binding the contract method 0xcf2c0334.
+//
+// Solidity: function userPurchases(address , uint256 ) view returns(uint256)
+func (_ReVibeContract *ReVibeContractCallerSession) UserPurchases(arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	return _ReVibeContract.Contract.UserPurchases(&_ReVibeContract.CallOpts, arg0, arg1)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address to, uint256 tokenId) returns()
+func (_ReVibeContract *ReVibeContractTransactor) Approve(opts *bind.TransactOpts, to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return _ReVibeContract.contract.Transact(opts, "approve", to, tokenId)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address to, uint256 tokenId) returns()
+func (_ReVibeContract *ReVibeContractSession) Approve(to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.Approve(&_ReVibeContract.TransactOpts, to, tokenId)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address to, uint256 tokenId) returns()
+func (_ReVibeContract *ReVibeContractTransactorSession) Approve(to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.Approve(&_ReVibeContract.TransactOpts, to, tokenId)
+}
+
+// AuthenticateProduct is a paid mutator transaction binding the contract method 0xc72e9e13.
+//
+// Solidity: function authenticateProduct(uint256 tokenId, bool authenticated) returns()
+func (_ReVibeContract *ReVibeContractTransactor) AuthenticateProduct(opts *bind.TransactOpts, tokenId *big.Int, authenticated bool) (*types.Transaction, error) {
+	return _ReVibeContract.contract.Transact(opts, "authenticateProduct", tokenId, authenticated)
+}
+
+// AuthenticateProduct is a paid mutator transaction binding the contract method 0xc72e9e13.
+//
+// Solidity: function authenticateProduct(uint256 tokenId, bool authenticated) returns()
+func (_ReVibeContract *ReVibeContractSession) AuthenticateProduct(tokenId *big.Int, authenticated bool) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.AuthenticateProduct(&_ReVibeContract.TransactOpts, tokenId, authenticated)
+}
+
+// AuthenticateProduct is a paid mutator transaction binding the contract method 0xc72e9e13.
+//
+// Solidity: function authenticateProduct(uint256 tokenId, bool authenticated) returns()
+func (_ReVibeContract *ReVibeContractTransactorSession) AuthenticateProduct(tokenId *big.Int, authenticated bool) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.AuthenticateProduct(&_ReVibeContract.TransactOpts, tokenId, authenticated)
+}
+
+// BuyProduct is a paid mutator transaction binding the contract method 0x8642269e.
+//
+// Solidity: function buyProduct(uint256 tokenId) payable returns()
+func (_ReVibeContract *ReVibeContractTransactor) BuyProduct(opts *bind.TransactOpts, tokenId *big.Int) (*types.Transaction, error) {
+	return _ReVibeContract.contract.Transact(opts, "buyProduct", tokenId)
+}
+
+// BuyProduct is a paid mutator transaction binding the contract method 0x8642269e.
+//
+// Solidity: function buyProduct(uint256 tokenId) payable returns()
+func (_ReVibeContract *ReVibeContractSession) BuyProduct(tokenId *big.Int) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.BuyProduct(&_ReVibeContract.TransactOpts, tokenId)
+}
+
+// BuyProduct is a paid mutator transaction binding the contract method 0x8642269e.
+//
+// Solidity: function buyProduct(uint256 tokenId) payable returns()
+func (_ReVibeContract *ReVibeContractTransactorSession) BuyProduct(tokenId *big.Int) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.BuyProduct(&_ReVibeContract.TransactOpts, tokenId)
+}
+
+// ListProduct is a paid mutator transaction binding the contract method 0x633aaf0c.
+//
+// Solidity: function listProduct(uint256 price, string metadata) returns(uint256)
+func (_ReVibeContract *ReVibeContractTransactor) ListProduct(opts *bind.TransactOpts, price *big.Int, metadata string) (*types.Transaction, error) {
+	return _ReVibeContract.contract.Transact(opts, "listProduct", price, metadata)
+}
+
+// ListProduct is a paid mutator transaction binding the contract method 0x633aaf0c.
+//
+// Solidity: function listProduct(uint256 price, string metadata) returns(uint256)
+func (_ReVibeContract *ReVibeContractSession) ListProduct(price *big.Int, metadata string) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.ListProduct(&_ReVibeContract.TransactOpts, price, metadata)
+}
+
+// ListProduct is a paid mutator transaction binding the contract method 0x633aaf0c.
+//
+// Solidity: function listProduct(uint256 price, string metadata) returns(uint256)
+func (_ReVibeContract *ReVibeContractTransactorSession) ListProduct(price *big.Int, metadata string) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.ListProduct(&_ReVibeContract.TransactOpts, price, metadata)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_ReVibeContract *ReVibeContractTransactor) RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ReVibeContract.contract.Transact(opts, "renounceOwnership")
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_ReVibeContract *ReVibeContractSession) RenounceOwnership() (*types.Transaction, error) {
+	return _ReVibeContract.Contract.RenounceOwnership(&_ReVibeContract.TransactOpts)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_ReVibeContract *ReVibeContractTransactorSession) RenounceOwnership() (*types.Transaction, error) {
+	return _ReVibeContract.Contract.RenounceOwnership(&_ReVibeContract.TransactOpts)
+}
+
+// SafeTransferFrom is a paid mutator transaction binding the contract method 0x42842e0e.
+//
+// Solidity: function safeTransferFrom(address from, address to, uint256 tokenId) returns()
+func (_ReVibeContract *ReVibeContractTransactor) SafeTransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return _ReVibeContract.contract.Transact(opts, "safeTransferFrom", from, to, tokenId)
+}
+
+// SafeTransferFrom is a paid mutator transaction binding the contract method 0x42842e0e.
+//
+// Solidity: function safeTransferFrom(address from, address to, uint256 tokenId) returns()
+func (_ReVibeContract *ReVibeContractSession) SafeTransferFrom(from common.Address, to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.SafeTransferFrom(&_ReVibeContract.TransactOpts, from, to, tokenId)
+}
+
+// SafeTransferFrom is a paid mutator transaction binding the contract method 0x42842e0e.
+//
+// Solidity: function safeTransferFrom(address from, address to, uint256 tokenId) returns()
+func (_ReVibeContract *ReVibeContractTransactorSession) SafeTransferFrom(from common.Address, to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.SafeTransferFrom(&_ReVibeContract.TransactOpts, from, to, tokenId)
+}
+
+// SafeTransferFrom0 is a paid mutator transaction binding the contract method 0xb88d4fde.
+//
+// Solidity: function safeTransferFrom(address from, address to, uint256 tokenId, bytes data) returns()
+func (_ReVibeContract *ReVibeContractTransactor) SafeTransferFrom0(opts *bind.TransactOpts, from common.Address, to common.Address, tokenId *big.Int, data []byte) (*types.Transaction, error) {
+	return _ReVibeContract.contract.Transact(opts, "safeTransferFrom0", from, to, tokenId, data)
+}
+
+// SafeTransferFrom0 is a paid mutator transaction binding the contract method 0xb88d4fde.
+//
+// Solidity: function safeTransferFrom(address from, address to, uint256 tokenId, bytes data) returns()
+func (_ReVibeContract *ReVibeContractSession) SafeTransferFrom0(from common.Address, to common.Address, tokenId *big.Int, data []byte) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.SafeTransferFrom0(&_ReVibeContract.TransactOpts, from, to, tokenId, data)
+}
+
+// SafeTransferFrom0 is a paid mutator transaction binding the contract method 0xb88d4fde.
+//
+// Solidity: function safeTransferFrom(address from, address to, uint256 tokenId, bytes data) returns()
+func (_ReVibeContract *ReVibeContractTransactorSession) SafeTransferFrom0(from common.Address, to common.Address, tokenId *big.Int, data []byte) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.SafeTransferFrom0(&_ReVibeContract.TransactOpts, from, to, tokenId, data)
+}
+
+// SetApprovalForAll is a paid mutator transaction binding the contract method 0xa22cb465.
+//
+// Solidity: function setApprovalForAll(address operator, bool approved) returns()
+func (_ReVibeContract *ReVibeContractTransactor) SetApprovalForAll(opts *bind.TransactOpts, operator common.Address, approved bool) (*types.Transaction, error) {
+	return _ReVibeContract.contract.Transact(opts, "setApprovalForAll", operator, approved)
+}
+
+// SetApprovalForAll is a paid mutator transaction binding the contract method 0xa22cb465.
+//
+// Solidity: function setApprovalForAll(address operator, bool approved) returns()
+func (_ReVibeContract *ReVibeContractSession) SetApprovalForAll(operator common.Address, approved bool) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.SetApprovalForAll(&_ReVibeContract.TransactOpts, operator, approved)
+}
+
+// SetApprovalForAll is a paid mutator transaction binding the contract method 0xa22cb465.
+//
+// Solidity: function setApprovalForAll(address operator, bool approved) returns()
+func (_ReVibeContract *ReVibeContractTransactorSession) SetApprovalForAll(operator common.Address, approved bool) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.SetApprovalForAll(&_ReVibeContract.TransactOpts, operator, approved)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 tokenId) returns()
+func (_ReVibeContract *ReVibeContractTransactor) TransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return _ReVibeContract.contract.Transact(opts, "transferFrom", from, to, tokenId)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 tokenId) returns()
+func (_ReVibeContract *ReVibeContractSession) TransferFrom(from common.Address, to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.TransferFrom(&_ReVibeContract.TransactOpts, from, to, tokenId)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 tokenId) returns()
+func (_ReVibeContract *ReVibeContractTransactorSession) TransferFrom(from common.Address, to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.TransferFrom(&_ReVibeContract.TransactOpts, from, to, tokenId)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_ReVibeContract *ReVibeContractTransactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _ReVibeContract.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_ReVibeContract *ReVibeContractSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.TransferOwnership(&_ReVibeContract.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_ReVibeContract *ReVibeContractTransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.TransferOwnership(&_ReVibeContract.TransactOpts, newOwner)
+}
+
+// UpdatePlatformFee is a paid mutator transaction binding the contract method 0xaa0b5988.
+//
+// Solidity: function updatePlatformFee(uint256 newFee) returns()
+func (_ReVibeContract *ReVibeContractTransactor) UpdatePlatformFee(opts *bind.TransactOpts, newFee *big.Int) (*types.Transaction, error) {
+	return _ReVibeContract.contract.Transact(opts, "updatePlatformFee", newFee)
+}
+
+// UpdatePlatformFee is a paid mutator transaction binding the contract method 0xaa0b5988.
+//
+// Solidity: function updatePlatformFee(uint256 newFee) returns()
+func (_ReVibeContract *ReVibeContractSession) UpdatePlatformFee(newFee *big.Int) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.UpdatePlatformFee(&_ReVibeContract.TransactOpts, newFee)
+}
+
+// UpdatePlatformFee is a paid mutator transaction binding the contract method 0xaa0b5988.
+//
+// Solidity: function updatePlatformFee(uint256 newFee) returns()
+func (_ReVibeContract *ReVibeContractTransactorSession) UpdatePlatformFee(newFee *big.Int) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.UpdatePlatformFee(&_ReVibeContract.TransactOpts, newFee)
+}
+
+// UpdatePrice is a paid mutator transaction binding the contract method 0x82367b2d.
+//
+// Solidity: function updatePrice(uint256 tokenId, uint256 newPrice) returns()
+func (_ReVibeContract *ReVibeContractTransactor) UpdatePrice(opts *bind.TransactOpts, tokenId *big.Int, newPrice *big.Int) (*types.Transaction, error) {
+	return _ReVibeContract.contract.Transact(opts, "updatePrice", tokenId, newPrice)
+}
+
+// UpdatePrice is a paid mutator transaction binding the contract method 0x82367b2d.
+//
+// Solidity: function updatePrice(uint256 tokenId, uint256 newPrice) returns()
+func (_ReVibeContract *ReVibeContractSession) UpdatePrice(tokenId *big.Int, newPrice *big.Int) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.UpdatePrice(&_ReVibeContract.TransactOpts, tokenId, newPrice)
+}
+
+// UpdatePrice is a paid mutator transaction binding the contract method 0x82367b2d.
+//
+// Solidity: function updatePrice(uint256 tokenId, uint256 newPrice) returns()
+func (_ReVibeContract *ReVibeContractTransactorSession) UpdatePrice(tokenId *big.Int, newPrice *big.Int) (*types.Transaction, error) {
+	return _ReVibeContract.Contract.UpdatePrice(&_ReVibeContract.TransactOpts, tokenId, newPrice)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x3ccfd60b.
+//
+// Solidity: function withdraw() returns()
+func (_ReVibeContract *ReVibeContractTransactor) Withdraw(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ReVibeContract.contract.Transact(opts, "withdraw")
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x3ccfd60b.
+//
+// Solidity: function withdraw() returns()
+func (_ReVibeContract *ReVibeContractSession) Withdraw() (*types.Transaction, error) {
+	return _ReVibeContract.Contract.Withdraw(&_ReVibeContract.TransactOpts)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x3ccfd60b.
+//
+// Solidity: function withdraw() returns()
+func (_ReVibeContract *ReVibeContractTransactorSession) Withdraw() (*types.Transaction, error) {
+	return _ReVibeContract.Contract.Withdraw(&_ReVibeContract.TransactOpts)
+}
+
+// ReVibeContractApprovalIterator is returned from FilterApproval and is used to iterate over the raw logs and unpacked data for Approval events raised by the ReVibeContract contract.
+type ReVibeContractApprovalIterator struct {
+	Event *ReVibeContractApproval // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ReVibeContractApprovalIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ReVibeContractApproval)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ReVibeContractApproval)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ReVibeContractApprovalIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ReVibeContractApprovalIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ReVibeContractApproval represents a Approval event raised by the ReVibeContract contract.
+type ReVibeContractApproval struct {
+	Owner    common.Address
+	Approved common.Address
+	TokenId  *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterApproval is a free log retrieval operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed approved, uint256 indexed tokenId)
+func (_ReVibeContract *ReVibeContractFilterer) FilterApproval(opts *bind.FilterOpts, owner []common.Address, approved []common.Address, tokenId []*big.Int) (*ReVibeContractApprovalIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var approvedRule []interface{}
+	for _, approvedItem := range approved {
+		approvedRule = append(approvedRule, approvedItem)
+	}
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+
+	logs, sub, err := _ReVibeContract.contract.FilterLogs(opts, "Approval", ownerRule, approvedRule, tokenIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ReVibeContractApprovalIterator{contract: _ReVibeContract.contract, event: "Approval", logs: logs, sub: sub}, nil
+}
+
+// WatchApproval is a free log subscription operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed approved, uint256 indexed tokenId)
+func (_ReVibeContract *ReVibeContractFilterer) WatchApproval(opts *bind.WatchOpts, sink chan<- *ReVibeContractApproval, owner []common.Address, approved []common.Address, tokenId []*big.Int) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var approvedRule []interface{}
+	for _, approvedItem := range approved {
+		approvedRule = append(approvedRule, approvedItem)
+	}
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+
+	logs, sub, err := _ReVibeContract.contract.WatchLogs(opts, "Approval", ownerRule, approvedRule, tokenIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ReVibeContractApproval)
+				if err := _ReVibeContract.contract.UnpackLog(event, "Approval", log); err != nil {
+					// If the signature doesn't match, skip this log.
+					if errors.Is(err, bind.ErrEventSignatureMismatch) {
+						continue
+					}
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseApproval is a log parse operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed approved, uint256 indexed tokenId)
+func (_ReVibeContract *ReVibeContractFilterer) ParseApproval(log types.Log) (*ReVibeContractApproval, error) {
+	event := new(ReVibeContractApproval)
+	if err := _ReVibeContract.contract.UnpackLog(event, "Approval", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ReVibeContractApprovalForAllIterator is returned from FilterApprovalForAll and is used to iterate over the raw logs and unpacked data for ApprovalForAll events raised by the ReVibeContract contract.
+type ReVibeContractApprovalForAllIterator struct {
+	Event *ReVibeContractApprovalForAll // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ReVibeContractApprovalForAllIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ReVibeContractApprovalForAll)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ReVibeContractApprovalForAll)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ReVibeContractApprovalForAllIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ReVibeContractApprovalForAllIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ReVibeContractApprovalForAll represents a ApprovalForAll event raised by the ReVibeContract contract.
+type ReVibeContractApprovalForAll struct {
+	Owner    common.Address
+	Operator common.Address
+	Approved bool
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterApprovalForAll is a free log retrieval operation binding the contract event 0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31.
+//
+// Solidity: event ApprovalForAll(address indexed owner, address indexed operator, bool approved)
+func (_ReVibeContract *ReVibeContractFilterer) FilterApprovalForAll(opts *bind.FilterOpts, owner []common.Address, operator []common.Address) (*ReVibeContractApprovalForAllIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _ReVibeContract.contract.FilterLogs(opts, "ApprovalForAll", ownerRule, operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ReVibeContractApprovalForAllIterator{contract: _ReVibeContract.contract, event: "ApprovalForAll", logs: logs, sub: sub}, nil
+}
+
+// WatchApprovalForAll is a free log subscription operation binding the contract event 0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31.
+//
+// Solidity: event ApprovalForAll(address indexed owner, address indexed operator, bool approved)
+func (_ReVibeContract *ReVibeContractFilterer) WatchApprovalForAll(opts *bind.WatchOpts, sink chan<- *ReVibeContractApprovalForAll, owner []common.Address, operator []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _ReVibeContract.contract.WatchLogs(opts, "ApprovalForAll", ownerRule, operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ReVibeContractApprovalForAll)
+				if err := _ReVibeContract.contract.UnpackLog(event, "ApprovalForAll", log); err != nil {
+					// If the signature doesn't match, skip this log.
+					if errors.Is(err, bind.ErrEventSignatureMismatch) {
+						continue
+					}
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseApprovalForAll is a log parse operation binding the contract event 0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31.
+//
+// Solidity: event ApprovalForAll(address indexed owner, address indexed operator, bool approved)
+func (_ReVibeContract *ReVibeContractFilterer) ParseApprovalForAll(log types.Log) (*ReVibeContractApprovalForAll, error) {
+	event := new(ReVibeContractApprovalForAll)
+	if err := _ReVibeContract.contract.UnpackLog(event, "ApprovalForAll", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ReVibeContractOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the ReVibeContract contract.
+type ReVibeContractOwnershipTransferredIterator struct {
+	Event *ReVibeContractOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ReVibeContractOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ReVibeContractOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ReVibeContractOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ReVibeContractOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ReVibeContractOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ReVibeContractOwnershipTransferred represents a OwnershipTransferred event raised by the ReVibeContract contract.
+type ReVibeContractOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_ReVibeContract *ReVibeContractFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*ReVibeContractOwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _ReVibeContract.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ReVibeContractOwnershipTransferredIterator{contract: _ReVibeContract.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_ReVibeContract *ReVibeContractFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *ReVibeContractOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _ReVibeContract.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ReVibeContractOwnershipTransferred)
+				if err := _ReVibeContract.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					// If the signature doesn't match, skip this log.
+					if errors.Is(err, bind.ErrEventSignatureMismatch) {
+						continue
+					}
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_ReVibeContract *ReVibeContractFilterer) ParseOwnershipTransferred(log types.Log) (*ReVibeContractOwnershipTransferred, error) {
+	event := new(ReVibeContractOwnershipTransferred)
+	if err := _ReVibeContract.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ReVibeContractPriceUpdatedIterator is returned from FilterPriceUpdated and is used to iterate over the raw logs and unpacked data for PriceUpdated events raised by the ReVibeContract contract.
+type ReVibeContractPriceUpdatedIterator struct {
+	Event *ReVibeContractPriceUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ReVibeContractPriceUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ReVibeContractPriceUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ReVibeContractPriceUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ReVibeContractPriceUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ReVibeContractPriceUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ReVibeContractPriceUpdated represents a PriceUpdated event raised by the ReVibeContract contract.
+type ReVibeContractPriceUpdated struct {
+	TokenId  *big.Int
+	NewPrice *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterPriceUpdated is a free log retrieval operation binding the contract event 0x945c1c4e99aa89f648fbfe3df471b916f719e16d960fcec0737d4d56bd696838.
+//
+// Solidity: event PriceUpdated(uint256 indexed tokenId, uint256 newPrice)
+func (_ReVibeContract *ReVibeContractFilterer) FilterPriceUpdated(opts *bind.FilterOpts, tokenId []*big.Int) (*ReVibeContractPriceUpdatedIterator, error) {
+
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+
+	logs, sub, err := _ReVibeContract.contract.FilterLogs(opts, "PriceUpdated", tokenIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ReVibeContractPriceUpdatedIterator{contract: _ReVibeContract.contract, event: "PriceUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchPriceUpdated is a free log subscription operation binding the contract event 0x945c1c4e99aa89f648fbfe3df471b916f719e16d960fcec0737d4d56bd696838.
+//
+// Solidity: event PriceUpdated(uint256 indexed tokenId, uint256 newPrice)
+func (_ReVibeContract *ReVibeContractFilterer) WatchPriceUpdated(opts *bind.WatchOpts, sink chan<- *ReVibeContractPriceUpdated, tokenId []*big.Int) (event.Subscription, error) {
+
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+
+	logs, sub, err := _ReVibeContract.contract.WatchLogs(opts, "PriceUpdated", tokenIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ReVibeContractPriceUpdated)
+				if err := _ReVibeContract.contract.UnpackLog(event, "PriceUpdated", log); err != nil {
+					// If the signature doesn't match, skip this log.
+					if errors.Is(err, bind.ErrEventSignatureMismatch) {
+						continue
+					}
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePriceUpdated is a log parse operation binding the contract event 0x945c1c4e99aa89f648fbfe3df471b916f719e16d960fcec0737d4d56bd696838.
+//
+// Solidity: event PriceUpdated(uint256 indexed tokenId, uint256 newPrice)
+func (_ReVibeContract *ReVibeContractFilterer) ParsePriceUpdated(log types.Log) (*ReVibeContractPriceUpdated, error) {
+	event := new(ReVibeContractPriceUpdated)
+	if err := _ReVibeContract.contract.UnpackLog(event, "PriceUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ReVibeContractProductAuthenticatedIterator is returned from FilterProductAuthenticated and is used to iterate over the raw logs and unpacked data for ProductAuthenticated events raised by the ReVibeContract contract.
+type ReVibeContractProductAuthenticatedIterator struct {
+	Event *ReVibeContractProductAuthenticated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ReVibeContractProductAuthenticatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ReVibeContractProductAuthenticated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ReVibeContractProductAuthenticated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ReVibeContractProductAuthenticatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ReVibeContractProductAuthenticatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ReVibeContractProductAuthenticated represents a ProductAuthenticated event raised by the ReVibeContract contract.
+type ReVibeContractProductAuthenticated struct {
+	TokenId       *big.Int
+	Authenticated bool
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterProductAuthenticated is a free log retrieval operation binding the contract event 0xbae6da0aa71ae09f883188ec6d71f8d4edc9341275284224177b9ce05f6174f3.
+//
+// Solidity: event ProductAuthenticated(uint256 indexed tokenId, bool authenticated)
+func (_ReVibeContract *ReVibeContractFilterer) FilterProductAuthenticated(opts *bind.FilterOpts, tokenId []*big.Int) (*ReVibeContractProductAuthenticatedIterator, error) {
+
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+
+	logs, sub, err := _ReVibeContract.contract.FilterLogs(opts, "ProductAuthenticated", tokenIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ReVibeContractProductAuthenticatedIterator{contract: _ReVibeContract.contract, event: "ProductAuthenticated", logs: logs, sub: sub}, nil
+}
+
+// WatchProductAuthenticated is a free log subscription operation binding the contract event 0xbae6da0aa71ae09f883188ec6d71f8d4edc9341275284224177b9ce05f6174f3.
+//
+// Solidity: event ProductAuthenticated(uint256 indexed tokenId, bool authenticated)
+func (_ReVibeContract *ReVibeContractFilterer) WatchProductAuthenticated(opts *bind.WatchOpts, sink chan<- *ReVibeContractProductAuthenticated, tokenId []*big.Int) (event.Subscription, error) {
+
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+
+	logs, sub, err := _ReVibeContract.contract.WatchLogs(opts, "ProductAuthenticated", tokenIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ReVibeContractProductAuthenticated)
+				if err := _ReVibeContract.contract.UnpackLog(event, "ProductAuthenticated", log); err != nil {
+					// If the signature doesn't match, skip this log.
+					if errors.Is(err, bind.ErrEventSignatureMismatch) {
+						continue
+					}
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseProductAuthenticated is a log parse operation binding the contract event 0xbae6da0aa71ae09f883188ec6d71f8d4edc9341275284224177b9ce05f6174f3.
+//
+// Solidity: event ProductAuthenticated(uint256 indexed tokenId, bool authenticated)
+func (_ReVibeContract *ReVibeContractFilterer) ParseProductAuthenticated(log types.Log) (*ReVibeContractProductAuthenticated, error) {
+	event := new(ReVibeContractProductAuthenticated)
+	if err := _ReVibeContract.contract.UnpackLog(event, "ProductAuthenticated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ReVibeContractProductListedIterator is returned from FilterProductListed and is used to iterate over the raw logs and unpacked data for ProductListed events raised by the ReVibeContract contract.
+type ReVibeContractProductListedIterator struct {
+	Event *ReVibeContractProductListed // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ReVibeContractProductListedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ReVibeContractProductListed)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ReVibeContractProductListed)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ReVibeContractProductListedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ReVibeContractProductListedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ReVibeContractProductListed represents a ProductListed event raised by the ReVibeContract contract.
+type ReVibeContractProductListed struct {
+	TokenId *big.Int
+	Seller  common.Address
+	Price   *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterProductListed is a free log retrieval operation binding the contract event 0xaae25b31116c34c03f0dc58472ff47d3ea0e38a572ec53c9e66319b994717ddb.
+//
+// Solidity: event ProductListed(uint256 indexed tokenId, address indexed seller, uint256 price)
+func (_ReVibeContract *ReVibeContractFilterer) FilterProductListed(opts *bind.FilterOpts, tokenId []*big.Int, seller []common.Address) (*ReVibeContractProductListedIterator, error) {
+
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+	var sellerRule []interface{}
+	for _, sellerItem := range seller {
+		sellerRule = append(sellerRule, sellerItem)
+	}
+
+	logs, sub, err := _ReVibeContract.contract.FilterLogs(opts, "ProductListed", tokenIdRule, sellerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ReVibeContractProductListedIterator{contract: _ReVibeContract.contract, event: "ProductListed", logs: logs, sub: sub}, nil
+}
+
+// WatchProductListed is a free log subscription operation binding the contract event 0xaae25b31116c34c03f0dc58472ff47d3ea0e38a572ec53c9e66319b994717ddb.
+//
+// Solidity: event ProductListed(uint256 indexed tokenId, address indexed seller, uint256 price)
+func (_ReVibeContract *ReVibeContractFilterer) WatchProductListed(opts *bind.WatchOpts, sink chan<- *ReVibeContractProductListed, tokenId []*big.Int, seller []common.Address) (event.Subscription, error) {
+
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+	var sellerRule []interface{}
+	for _, sellerItem := range seller {
+		sellerRule = append(sellerRule, sellerItem)
+	}
+
+	logs, sub, err := _ReVibeContract.contract.WatchLogs(opts, "ProductListed", tokenIdRule, sellerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ReVibeContractProductListed)
+				if err := _ReVibeContract.contract.UnpackLog(event, "ProductListed", log); err != nil {
+					// If the signature doesn't match, skip this log.
+					if errors.Is(err, bind.ErrEventSignatureMismatch) {
+						continue
+					}
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseProductListed is a log parse operation binding the contract event 0xaae25b31116c34c03f0dc58472ff47d3ea0e38a572ec53c9e66319b994717ddb.
+//
+// Solidity: event ProductListed(uint256 indexed tokenId, address indexed seller, uint256 price)
+func (_ReVibeContract *ReVibeContractFilterer) ParseProductListed(log types.Log) (*ReVibeContractProductListed, error) {
+	event := new(ReVibeContractProductListed)
+	if err := _ReVibeContract.contract.UnpackLog(event, "ProductListed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ReVibeContractProductSoldIterator is returned from FilterProductSold and is used to iterate over the raw logs and unpacked data for ProductSold events raised by the ReVibeContract contract.
+type ReVibeContractProductSoldIterator struct {
+	Event *ReVibeContractProductSold // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ReVibeContractProductSoldIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ReVibeContractProductSold)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ReVibeContractProductSold)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ReVibeContractProductSoldIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ReVibeContractProductSoldIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ReVibeContractProductSold represents a ProductSold event raised by the ReVibeContract contract.
+type ReVibeContractProductSold struct {
+	TokenId *big.Int
+	Seller  common.Address
+	Buyer   common.Address
+	Price   *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterProductSold is a free log retrieval operation binding the contract event 0xa02e46fc9bbd3e1138787bc1dc117ae0b9a5a3745ea30b0ab673d79d90739029.
+//
+// Solidity: event ProductSold(uint256 indexed tokenId, address indexed seller, address indexed buyer, uint256 price)
+func (_ReVibeContract *ReVibeContractFilterer) FilterProductSold(opts *bind.FilterOpts, tokenId []*big.Int, seller []common.Address, buyer []common.Address) (*ReVibeContractProductSoldIterator, error) {
+
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+	var sellerRule []interface{}
+	for _, sellerItem := range seller {
+		sellerRule = append(sellerRule, sellerItem)
+	}
+	var buyerRule []interface{}
+	for _, buyerItem := range buyer {
+		buyerRule = append(buyerRule, buyerItem)
+	}
+
+	logs, sub, err := _ReVibeContract.contract.FilterLogs(opts, "ProductSold", tokenIdRule, sellerRule, buyerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ReVibeContractProductSoldIterator{contract: _ReVibeContract.contract, event: "ProductSold", logs: logs, sub: sub}, nil
+}
+
+// WatchProductSold is a free log subscription operation binding the contract event 0xa02e46fc9bbd3e1138787bc1dc117ae0b9a5a3745ea30b0ab673d79d90739029.
+//
+// Solidity: event ProductSold(uint256 indexed tokenId, address indexed seller, address indexed buyer, uint256 price)
+func (_ReVibeContract *ReVibeContractFilterer) WatchProductSold(opts *bind.WatchOpts, sink chan<- *ReVibeContractProductSold, tokenId []*big.Int, seller []common.Address, buyer []common.Address) (event.Subscription, error) {
+
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+	var sellerRule []interface{}
+	for _, sellerItem := range seller {
+		sellerRule = append(sellerRule, sellerItem)
+	}
+	var buyerRule []interface{}
+	for _, buyerItem := range buyer {
+		buyerRule = append(buyerRule, buyerItem)
+	}
+
+	logs, sub, err := _ReVibeContract.contract.WatchLogs(opts, "ProductSold", tokenIdRule, sellerRule, buyerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ReVibeContractProductSold)
+				if err := _ReVibeContract.contract.UnpackLog(event, "ProductSold", log); err != nil {
+					// If the signature doesn't match, skip this log.
+					if errors.Is(err, bind.ErrEventSignatureMismatch) {
+						continue
+					}
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseProductSold is a log parse operation binding the contract event 0xa02e46fc9bbd3e1138787bc1dc117ae0b9a5a3745ea30b0ab673d79d90739029.
+//
+// Solidity: event ProductSold(uint256 indexed tokenId, address indexed seller, address indexed buyer, uint256 price)
+func (_ReVibeContract *ReVibeContractFilterer) ParseProductSold(log types.Log) (*ReVibeContractProductSold, error) {
+	event := new(ReVibeContractProductSold)
+	if err := _ReVibeContract.contract.UnpackLog(event, "ProductSold", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ReVibeContractTransferIterator is returned from FilterTransfer and is used to iterate over the raw logs and unpacked data for Transfer events raised by the ReVibeContract contract.
+type ReVibeContractTransferIterator struct {
+	Event *ReVibeContractTransfer // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ReVibeContractTransferIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ReVibeContractTransfer)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ReVibeContractTransfer)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ReVibeContractTransferIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ReVibeContractTransferIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ReVibeContractTransfer represents a Transfer event raised by the ReVibeContract contract.
+type ReVibeContractTransfer struct {
+	From    common.Address
+	To      common.Address
+	TokenId *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterTransfer is a free log retrieval operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 indexed tokenId)
+func (_ReVibeContract *ReVibeContractFilterer) FilterTransfer(opts *bind.FilterOpts, from []common.Address, to []common.Address, tokenId []*big.Int) (*ReVibeContractTransferIterator, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+
+	logs, sub, err := _ReVibeContract.contract.FilterLogs(opts, "Transfer", fromRule, toRule, tokenIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ReVibeContractTransferIterator{contract: _ReVibeContract.contract, event: "Transfer", logs: logs, sub: sub}, nil
+}
+
+// WatchTransfer is a free log subscription operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 indexed tokenId)
+func (_ReVibeContract *ReVibeContractFilterer) WatchTransfer(opts *bind.WatchOpts, sink chan<- *ReVibeContractTransfer, from []common.Address, to []common.Address, tokenId []*big.Int) (event.Subscription, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+
+	logs, sub, err := _ReVibeContract.contract.WatchLogs(opts, "Transfer", fromRule, toRule, tokenIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ReVibeContractTransfer)
+				if err := _ReVibeContract.contract.UnpackLog(event, "Transfer", log); err != nil {
+					// If the signature doesn't match, skip this log.
+					if errors.Is(err, bind.ErrEventSignatureMismatch) {
+						continue
+					}
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTransfer is a log parse operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 indexed tokenId)
+func (_ReVibeContract *ReVibeContractFilterer) ParseTransfer(log types.Log) (*ReVibeContractTransfer, error) {
+	event := new(ReVibeContractTransfer)
+	if err := _ReVibeContract.contract.UnpackLog(event, "Transfer", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,241 @@
+package contracts
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	abiPath      = "ReVibe.abi"
+	solidityPath = "../../contracts/ReVibe.sol"
+	artifactPath = "../../contracts/artifacts/contracts/ReVibe.sol/ReVibe.json"
+)
+
+// Members ReVibe inherits from OpenZeppelin's ERC721 and Ownable, which are
+// not declared in ReVibe.sol itself
+var (
+	inheritedMethods = map[string]bool{
+		"approve": true, "balanceOf": true, "getApproved": true, "isApprovedForAll": true,
+		"name": true, "owner": true, "ownerOf": true, "renounceOwnership": true,
+		"safeTransferFrom": true, "safeTransferFrom0": true, "setApprovalForAll": true,
+		"supportsInterface": true, "symbol": true, "tokenURI": true,
+		"transferFrom": true, "transferOwnership": true,
+	}
+	inheritedEvents = map[string]bool{
+		"Approval": true, "ApprovalForAll": true, "OwnershipTransferred": true, "Transfer": true,
+	}
+)
+
+// abiSummary reduces an ABI to what the bindings depend on: signatures,
+// outputs, mutability and which event arguments are indexed
+func abiSummary(parsed abi.ABI) map[string]string {
+	summary := make(map[string]string)
+	for _, method := range parsed.Methods {
+		var outputs []string
+		for _, output := range method.Outputs {
+			outputs = append(outputs, output.Type.String())
+		}
+		summary["function "+method.Sig] = method.StateMutability + " returns(" + strings.Join(outputs, ",") + ")"
+	}
+	for _, event := range parsed.Events {
+		summary["event "+event.Sig] = indexedFlags(event.Inputs)
+	}
+	return summary
+}
+
+func indexedFlags(args abi.Arguments) string {
+	var flags []string
+	for _, arg := range args {
+		if arg.Indexed {
+			flags = append(flags, "indexed")
+		} else {
+			flags = append(flags, "-")
+		}
+	}
+	return strings.Join(flags, ",")
+}
+
+func loadABI(t *testing.T, data []byte) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(string(data)))
+	require.NoError(t, err)
+	return parsed
+}
+
+func TestBindingsMatchABI(t *testing.T) {
+	data, err := os.ReadFile(abiPath)
+	require.NoError(t, err)
+
+	bound, err := ReVibeContractMetaData.GetAbi()
+	require.NoError(t, err)
+
+	assert.Equal(t, abiSummary(loadABI(t, data)), abiSummary(*bound),
+		"revibe.go is out of date with ReVibe.abi; run go generate ./contracts")
+}
+
+func TestABIMatchesArtifact(t *testing.T) {
+	data, err := os.ReadFile(artifactPath)
+	if os.IsNotExist(err) {
+		t.Skip("contract has not been compiled; run npm run compile in contracts/")
+	}
+	require.NoError(t, err)
+
+	var artifact struct {
+		ABI json.RawMessage `json:"abi"`
+	}
+	require.NoError(t, json.Unmarshal(data, &artifact))
+
+	checkedIn, err := os.ReadFile(abiPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, abiSummary(loadABI(t, artifact.ABI)), abiSummary(loadABI(t, checkedIn)),
+		"ReVibe.abi is out of date with the compiled contract; run npm run export-abi in contracts/")
+}
+
+var (
+	solComment  = regexp.MustCompile(`(?s)//[^\n]*|/\*.*?\*/`)
+	solFunction = regexp.MustCompile(`function\s+(\w+)\s*\(([^)]*)\)([^{;]*)`)
+	solEvent    = regexp.MustCompile(`event\s+(\w+)\s*\(([^)]*)\)\s*;`)
+	solMapping  = regexp.MustCompile(`mapping\s*\(\s*(\w+)\s*=>\s*([\w\[\]]+)\s*\)\s+public\s+(\w+)\s*;`)
+	solVariable = regexp.MustCompile(`(?m)^\s*([\w\[\]]+)\s+public\s+(\w+)\s*[=;]`)
+)
+
+// solidityDeclarations parses the externally visible functions, public state
+// variable getters and events declared in a Solidity source, keyed the same
+// way as abiSummary. Getters and functions map to their mutability; events
+// map to their indexed flags.
+func solidityDeclarations(source string) map[string]string {
+	source = solComment.ReplaceAllString(source, "")
+	declared := make(map[string]string)
+
+	for _, match := range solFunction.FindAllStringSubmatch(source, -1) {
+		modifiers := strings.Fields(match[3])
+		if !hasWord(modifiers, "external") && !hasWord(modifiers, "public") {
+			continue
+		}
+		mutability := "nonpayable"
+		for _, m := range []string{"payable", "view", "pure"} {
+			if hasWord(modifiers, m) {
+				mutability = m
+			}
+		}
+		types, _ := solidityParams(match[2])
+		declared["function "+match[1]+"("+strings.Join(types, ",")+")"] = mutability
+	}
+
+	for _, match := range solMapping.FindAllStringSubmatch(source, -1) {
+		inputs := []string{canonicalType(match[1])}
+		if strings.HasSuffix(match[2], "[]") {
+			inputs = append(inputs, "uint256")
+		}
+		declared["function "+match[3]+"("+strings.Join(inputs, ",")+")"] = "view"
+	}
+	for _, match := range solVariable.FindAllStringSubmatch(source, -1) {
+		declared["function "+match[2]+"()"] = "view"
+	}
+
+	for _, match := range solEvent.FindAllStringSubmatch(source, -1) {
+		types, flags := solidityParams(match[2])
+		declared["event "+match[1]+"("+strings.Join(types, ",")+")"] = strings.Join(flags, ",")
+	}
+
+	return declared
+}
+
+// solidityParams returns the canonical types of a parameter list and
+// whether each parameter is indexed
+func solidityParams(params string) (types, flags []string) {
+	for _, param := range strings.Split(params, ",") {
+		fields := strings.Fields(param)
+		if len(fields) == 0 {
+			continue
+		}
+		types = append(types, canonicalType(fields[0]))
+		if hasWord(fields, "indexed") {
+			flags = append(flags, "indexed")
+		} else {
+			flags = append(flags, "-")
+		}
+	}
+	return types, flags
+}
+
+func canonicalType(t string) string {
+	for _, alias := range []string{"uint", "int"} {
+		if t == alias || strings.HasPrefix(t, alias+"[") {
+			return alias + "256" + strings.TrimPrefix(t, alias)
+		}
+	}
+	return t
+}
+
+func hasWord(words []string, word string) bool {
+	for _, w := range words {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
+
+func TestABIMatchesSolidity(t *testing.T) {
+	source, err := os.ReadFile(solidityPath)
+	require.NoError(t, err)
+	data, err := os.ReadFile(abiPath)
+	require.NoError(t, err)
+
+	declared := solidityDeclarations(string(source))
+	parsed := loadABI(t, data)
+	summary := abiSummary(parsed)
+
+	// Everything ReVibe.sol declares must be in the ABI with the same shape
+	for key, want := range declared {
+		got, ok := summary[key]
+		if !assert.True(t, ok, "%s is declared in ReVibe.sol but missing from ReVibe.abi", key) {
+			continue
+		}
+		if strings.HasPrefix(key, "function ") {
+			got = strings.SplitN(got, " ", 2)[0]
+		}
+		assert.Equal(t, want, got, "%s differs between ReVibe.sol and ReVibe.abi", key)
+	}
+
+	// and everything in the ABI must be declared there or inherited
+	for name, method := range parsed.Methods {
+		if !inheritedMethods[name] {
+			assert.Contains(t, declared, "function "+method.Sig, "%s is in ReVibe.abi but not ReVibe.sol", method.Sig)
+		}
+	}
+	for name, event := range parsed.Events {
+		if !inheritedEvents[name] {
+			assert.Contains(t, declared, "event "+event.Sig, "%s is in ReVibe.abi but not ReVibe.sol", event.Sig)
+		}
+	}
+}
+
+func TestSolidityDeclarations(t *testing.T) {
+	declared := solidityDeclarations(`
+		mapping(address => uint256[]) public userProducts;
+		uint public fee = 25; // 2.5%
+		event Sold(uint indexed tokenId, address buyer);
+		function list(uint256 price, string memory metadata) external returns (uint256) {}
+		function buy(uint256 tokenId) external payable nonReentrant {}
+		function _mint(address to) internal {}
+		function items(address user) public view returns (uint256[] memory) {}
+	`)
+
+	assert.Equal(t, map[string]string{
+		"function userProducts(address,uint256)": "view",
+		"function fee()":                         "view",
+		"event Sold(uint256,address)":            "indexed,-",
+		"function list(uint256,string)":          "nonpayable",
+		"function buy(uint256)":                  "payable",
+		"function items(address)":                "view",
+	}, declared)
+}
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yourusername/revibe/backend/config"
+)
+
+var Redis *redis.Client
+
+// InitRedis initializes the Redis connection
+func InitRedis() error {
+	client := redis.NewClient(&redis.Options{
+		Addr:     config.AppConfig.RedisAddr,
+		Password: config.AppConfig.RedisPassword,
+		DB:       config.AppConfig.RedisDB,
+	})
+
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		return fmt.Errorf("failed to connect to redis: %v", err)
+	}
+
+	Redis = client
+	log.Println("Redis connection established successfully")
+	return nil
+}
+
+// CloseRedis closes the Redis connection
+func CloseRedis() error {
+	if Redis != nil {
+		return Redis.Close()
+	}
+	return nil
+}
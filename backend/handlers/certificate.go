@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/revibe/backend/services"
+	"gorm.io/gorm"
+)
+
+type VerifyCertificateRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type RevokeCertificateRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+func certificateErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Certificate not found"})
+	case errors.Is(err, services.ErrCertificateRevoked):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process certificate"})
+	}
+}
+
+// HandleGetCertificate returns a certificate and its signed token
+func HandleGetCertificate(certificateService *services.CertificateService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cert, err := certificateService.GetCertificate(c.Param("id"))
+		if err != nil {
+			certificateErrorResponse(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, cert)
+	}
+}
+
+// HandleVerifyCertificate checks a certificate token's signature and revocation status
+func HandleVerifyCertificate(certificateService *services.CertificateService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req VerifyCertificateRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		result, err := certificateService.Verify(req.Token)
+		if err != nil {
+			certificateErrorResponse(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// HandleGetCertificateKeys publishes the keys certificates are signed with as a JWK set
+func HandleGetCertificateKeys(certificateService *services.CertificateService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"keys": certificateService.PublicKeys()})
+	}
+}
+
+// HandleRevokeCertificate revokes a certificate
+func HandleRevokeCertificate(certificateService *services.CertificateService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req RevokeCertificateRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, _ := c.Get("userID")
+		cert, err := certificateService.Revoke(c.Param("id"), userID.(string), req.Reason)
+		if err != nil {
+			certificateErrorResponse(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, cert)
+	}
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/revibe/backend/models"
+	"github.com/yourusername/revibe/backend/services"
+	"gorm.io/gorm"
+)
+
+// HandleGetRPCStatus reports the health of each chain's RPC providers
+func HandleGetRPCStatus(registry *services.DeploymentRegistry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"chains": registry.RPCStatus(),
+		})
+	}
+}
+
+// DeploymentStatus is a contract deployment with its indexer's progress
+type DeploymentStatus struct {
+	models.Deployment
+	Indexer *services.IndexerStatus `json:"indexer"`
+}
+
+// HandleListDeployments lists the contract deployments and how far each
+// one's indexer has got. Indexers that have not started report null.
+func HandleListDeployments(indexers []*services.IndexerService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		deployments := make([]DeploymentStatus, 0, len(indexers))
+		for _, indexer := range indexers {
+			status, err := indexer.GetStatus(c.Request.Context())
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch indexer status"})
+				return
+			}
+			deployments = append(deployments, DeploymentStatus{
+				Deployment: indexer.Deployment(),
+				Indexer:    status,
+			})
+		}
+
+		c.JSON(http.StatusOK, gin.H{"deployments": deployments})
+	}
+}
+
+// HandleGetIndexerStatus reports how far the contract event indexer has got
+func HandleGetIndexerStatus(indexerService *services.IndexerService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		status, err := indexerService.GetStatus(c.Request.Context())
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Indexer has not started"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch indexer status"})
+			return
+		}
+
+		c.JSON(http.StatusOK, status)
+	}
+}
+
+// HandleListManagedTransactions lists transactions sent by the transaction
+// manager, optionally filtered by status
+func HandleListManagedTransactions(txManager *services.TransactionManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, limit := getPagination(c)
+		txs, total, err := txManager.ListTransactions(c.Query("status"), page, limit)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch transactions"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"transactions": txs,
+			"total":        total,
+			"page":         page,
+			"limit":        limit,
+		})
+	}
+}
+
+// HandleGetManagedTransaction returns a managed transaction with its attempts
+func HandleGetManagedTransaction(txManager *services.TransactionManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		mtx, err := txManager.GetTransaction(c.Param("id"))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch transaction"})
+			return
+		}
+
+		c.JSON(http.StatusOK, mtx)
+	}
+}
+
+// HandleGetTransactionStatus reports a transaction's status, confirmations,
+// gas and decoded ReVibe events, with the revert reason if it failed. The
+// chainId query parameter picks the chain; it defaults to the active
+// deployment's.
+func HandleGetTransactionStatus(txStatusService *services.TransactionStatusService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var chainID int64
+		if value := c.Query("chainId"); value != "" {
+			var err error
+			chainID, err = strconv.ParseInt(value, 10, 64)
+			if err != nil || chainID <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid chain ID"})
+				return
+			}
+		}
+
+		status, err := txStatusService.GetTransactionStatus(c.Request.Context(), chainID, c.Param("hash"))
+		switch {
+		case errors.Is(err, services.ErrInvalidTxHash):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, services.ErrUnknownDeployment):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Chain not supported"})
+			return
+		case errors.Is(err, services.ErrTransactionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found"})
+			return
+		case err != nil:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch transaction status"})
+			return
+		}
+
+		c.JSON(http.StatusOK, status)
+	}
+}
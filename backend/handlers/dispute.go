@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/revibe/backend/models"
+	"github.com/yourusername/revibe/backend/services"
+	"gorm.io/gorm"
+)
+
+type OpenDisputeRequest struct {
+	Reason      string `json:"reason" binding:"required,oneof=damaged counterfeit not_received not_as_described other"`
+	Description string `json:"description" binding:"required"`
+}
+
+type DisputeNoteRequest struct {
+	Note string `json:"note"`
+}
+
+type ResolveDisputeRequest struct {
+	Resolution      string `json:"resolution" binding:"required,oneof=refund partial_refund rejected"`
+	RefundAmountWei string `json:"refundAmountWei"`
+	Note            string `json:"note"`
+}
+
+func disputeErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, services.ErrNotOrderBuyer), errors.Is(err, services.ErrNotDisputeParty):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrDisputeExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrOrderNotDisputable),
+		errors.Is(err, services.ErrInvalidDisputeState),
+		errors.Is(err, services.ErrInvalidRefundAmount),
+		errors.Is(err, services.ErrInvalidDisputeOutcome):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process dispute"})
+	}
+}
+
+// HandleOpenDispute lets the buyer of a completed order open a dispute
+func HandleOpenDispute(disputeService *services.DisputeService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req OpenDisputeRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, _ := c.Get("userID")
+		dispute, err := disputeService.OpenDispute(c.Param("id"), userID.(string), req.Reason, req.Description)
+		if err != nil {
+			disputeErrorResponse(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, dispute)
+	}
+}
+
+// HandleGetDispute returns a dispute to the buyer or seller of its order
+func HandleGetDispute(disputeService *services.DisputeService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		dispute, err := disputeService.GetDispute(c.Param("id"))
+		if err != nil {
+			disputeErrorResponse(c, err)
+			return
+		}
+
+		userID, _ := c.Get("userID")
+		if !disputeService.IsParty(dispute, userID.(string)) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to view this dispute"})
+			return
+		}
+
+		c.JSON(http.StatusOK, dispute)
+	}
+}
+
+// HandleSubmitDisputeEvidence accepts an evidence file and/or note from a party to a dispute
+func HandleSubmitDisputeEvidence(disputeService *services.DisputeService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// The file is optional; a note on its own is valid evidence
+		file, _ := c.FormFile("file")
+		note := c.PostForm("note")
+		if file == nil && note == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "A file or note is required"})
+			return
+		}
+
+		userID, _ := c.Get("userID")
+		evidence, err := disputeService.SubmitEvidence(c.Param("id"), userID.(string), file, note)
+		if err != nil {
+			disputeErrorResponse(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, evidence)
+	}
+}
+
+// HandleListDisputes lists disputes for staff, optionally filtered by status
+func HandleListDisputes(disputeService *services.DisputeService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, limit := getPagination(c)
+		disputes, total, err := disputeService.ListDisputes(c.Query("status"), page, limit)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch disputes"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"disputes": disputes,
+			"total":    total,
+			"page":     page,
+			"limit":    limit,
+		})
+	}
+}
+
+// HandleGetDisputeAdmin returns any dispute to staff
+func HandleGetDisputeAdmin(disputeService *services.DisputeService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		dispute, err := disputeService.GetDispute(c.Param("id"))
+		if err != nil {
+			disputeErrorResponse(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, dispute)
+	}
+}
+
+// HandleTransitionDispute moves a dispute to the given status on behalf of staff
+func HandleTransitionDispute(disputeService *services.DisputeService, status string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req DisputeNoteRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, _ := c.Get("userID")
+		dispute, err := disputeService.Transition(c.Param("id"), userID.(string), status, req.Note)
+		if err != nil {
+			disputeErrorResponse(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, dispute)
+	}
+}
+
+// HandleResolveDispute records a staff decision on a dispute
+func HandleResolveDispute(disputeService *services.DisputeService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req ResolveDisputeRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if req.Resolution == models.DisputeResolutionPartialRefund && req.RefundAmountWei == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "refundAmountWei is required for a partial refund"})
+			return
+		}
+
+		userID, _ := c.Get("userID")
+		dispute, err := disputeService.Resolve(c.Param("id"), userID.(string), services.ResolveDisputeInput{
+			Resolution:      req.Resolution,
+			RefundAmountWei: req.RefundAmountWei,
+			Note:            req.Note,
+		})
+		if err != nil {
+			disputeErrorResponse(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, dispute)
+	}
+}
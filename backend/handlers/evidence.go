@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/revibe/backend/services"
+	"github.com/yourusername/revibe/backend/utils"
+	"gorm.io/gorm"
+)
+
+func evidenceErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Evidence bundle not found"})
+	case errors.Is(err, services.ErrEvidenceFileMissing):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process evidence bundle"})
+	}
+}
+
+// HandleGetEvidence returns an authentication's evidence bundle
+func HandleGetEvidence(evidenceService *services.EvidenceService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		bundle, err := evidenceService.GetBundle(c.Param("id"))
+		if err != nil {
+			evidenceErrorResponse(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, bundle)
+	}
+}
+
+// HandleVerifyEvidence re-hashes an evidence bundle to detect tampering
+func HandleVerifyEvidence(evidenceService *services.EvidenceService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		result, err := evidenceService.VerifyBundle(c.Param("id"))
+		if err != nil {
+			evidenceErrorResponse(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// HandleDownloadEvidence streams an evidence bundle as a zip archive
+func HandleDownloadEvidence(evidenceService *services.EvidenceService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		bundle, err := evidenceService.GetBundle(c.Param("id"))
+		if err != nil {
+			evidenceErrorResponse(c, err)
+			return
+		}
+		if err := evidenceService.CheckBundleFiles(bundle); err != nil {
+			evidenceErrorResponse(c, err)
+			return
+		}
+
+		filename := fmt.Sprintf("evidence-%s.zip", bundle.AuthenticationID)
+		c.Header("Content-Type", "application/zip")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		c.Status(http.StatusOK)
+		// Headers are already sent, so a write error can only be logged
+		if err := evidenceService.WriteArchive(c.Writer, bundle); err != nil {
+			utils.LogError(err, map[string]interface{}{
+				"component":        "evidence_archive",
+				"authenticationId": bundle.AuthenticationID,
+			})
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/revibe/backend/services"
+	"gorm.io/gorm"
+)
+
+const dateLayout = "2006-01-02"
+
+// parseTimeParam parses an RFC3339 timestamp or a YYYY-MM-DD date. An empty
+// value returns the zero time.
+func parseTimeParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(dateLayout, value)
+}
+
+// getDateRange reads the from and to query parameters
+func getDateRange(c *gin.Context) (time.Time, time.Time, error) {
+	from, err := parseTimeParam(c.Query("from"))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid from date")
+	}
+	to, err := parseTimeParam(c.Query("to"))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid to date")
+	}
+	return from, to, nil
+}
+
+// getOwnProfile loads the user for the walletAddress parameter and checks it
+// is the authenticated user. It writes the error response and returns false
+// otherwise.
+func getOwnProfile(c *gin.Context, db *gorm.DB) (*UserProfile, bool) {
+	var user UserProfile
+	if err := db.First(&user, "wallet_address = ?", c.Param("walletAddress")).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch user"})
+		return nil, false
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists || userID.(string) != user.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to view this account"})
+		return nil, false
+	}
+
+	return &user, true
+}
+
+// HandleGetSellerBalance returns a seller's sale proceeds and fees paid
+func HandleGetSellerBalance(db *gorm.DB, ledgerService *services.LedgerService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := getOwnProfile(c, db)
+		if !ok {
+			return
+		}
+
+		balance, err := ledgerService.GetSellerBalance(user.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch balance"})
+			return
+		}
+
+		c.JSON(http.StatusOK, balance)
+	}
+}
+
+// HandleGetSellerStatement returns a page of ledger postings to a seller's proceeds
+func HandleGetSellerStatement(db *gorm.DB, ledgerService *services.LedgerService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := getOwnProfile(c, db)
+		if !ok {
+			return
+		}
+
+		from, to, err := getDateRange(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		page, limit := getPagination(c)
+		entries, total, err := ledgerService.GetSellerStatement(user.ID, from, to, page, limit)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch statement"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"entries": entries,
+			"total":   total,
+			"page":    page,
+			"limit":   limit,
+		})
+	}
+}
+
+// HandleGetRevenueSummary returns platform fee revenue grouped by period
+func HandleGetRevenueSummary(ledgerService *services.LedgerService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		from, to, err := getDateRange(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		period := c.DefaultQuery("period", services.RevenuePeriodMonth)
+		periods, err := ledgerService.GetRevenueSummary(period, from, to)
+		if err != nil {
+			if err == services.ErrInvalidRevenuePeriod {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch revenue"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"period":  period,
+			"periods": periods,
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/revibe/backend/services"
+	"gorm.io/gorm"
+)
+
+// HandleGetTokenMetadata serves the ERC-721 metadata JSON for a token
+func HandleGetTokenMetadata(metadataService *services.MetadataService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		productID, err := metadataService.ResolveProductID(c.Param("tokenId"))
+		if err != nil {
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				c.JSON(http.StatusNotFound, gin.H{"error": "Token not found"})
+			case errors.Is(err, services.ErrInvalidTokenID):
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			case errors.Is(err, services.ErrChainUnavailable):
+				c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to read token from chain"})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve token"})
+			}
+			return
+		}
+
+		metadata, err := metadataService.BuildMetadata(productID)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Token not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build token metadata"})
+			return
+		}
+
+		// Keep caches short so authentication changes show up quickly
+		c.Header("Cache-Control", "public, max-age=60")
+		c.JSON(http.StatusOK, metadata)
+	}
+}
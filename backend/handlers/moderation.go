@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/revibe/backend/services"
+	"gorm.io/gorm"
+)
+
+type ResolveImageMatchRequest struct {
+	Action string `json:"action" binding:"required,oneof=confirm dismiss"`
+}
+
+// HandleGetImageMatches lists open duplicate-image matches with both listings
+func HandleGetImageMatches(imageHashService *services.ImageHashService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		matches, err := imageHashService.GetOpenMatches()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch image matches"})
+			return
+		}
+
+		c.JSON(http.StatusOK, matches)
+	}
+}
+
+// HandleResolveImageMatch confirms or dismisses a duplicate-image match
+func HandleResolveImageMatch(imageHashService *services.ImageHashService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req ResolveImageMatchRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, _ := c.Get("userID")
+		match, err := imageHashService.ResolveMatch(c.Param("id"), userID.(string), req.Action == "confirm")
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Image match not found"})
+				return
+			}
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, match)
+	}
+}
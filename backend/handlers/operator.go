@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/revibe/backend/services"
+	"gorm.io/gorm"
+)
+
+type UpdatePlatformFeeRequest struct {
+	PlatformFee *int64 `json:"platformFee" binding:"required"`
+}
+
+func operatorErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Operator transaction not found"})
+	case errors.Is(err, services.ErrNoOperator):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidPlatformFee):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process operator transaction"})
+	}
+}
+
+// HandleUpdatePlatformFee queues an operator transaction setting the
+// contract's platform fee
+func HandleUpdatePlatformFee(operatorService *services.OperatorService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req UpdatePlatformFeeRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		op, err := operatorService.QueuePlatformFeeUpdate(*req.PlatformFee)
+		if err != nil {
+			operatorErrorResponse(c, err)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, op)
+	}
+}
+
+// HandleWithdraw queues an operator transaction withdrawing the contract's
+// balance to its owner
+func HandleWithdraw(operatorService *services.OperatorService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		op, err := operatorService.QueueWithdrawal()
+		if err != nil {
+			operatorErrorResponse(c, err)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, op)
+	}
+}
+
+// HandleGetOperatorTransaction returns a queued operator transaction and its status
+func HandleGetOperatorTransaction(operatorService *services.OperatorService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		op, err := operatorService.GetOperatorTransaction(c.Param("id"))
+		if err != nil {
+			operatorErrorResponse(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, op)
+	}
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/revibe/backend/services"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPageLimit = 10
+	maxPageLimit     = 100
+)
+
+type CheckoutRequest struct {
+	TxHash string `json:"txHash" binding:"required"`
+}
+
+// getPagination reads the page and limit query parameters
+func getPagination(c *gin.Context) (int, int) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultPageLimit)))
+	if err != nil || limit < 1 {
+		limit = defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+
+	return page, limit
+}
+
+// HandleCheckout creates a pending order for a buyProduct transaction
+func HandleCheckout(orderService *services.OrderService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req CheckoutRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, _ := c.Get("userID")
+		order, err := orderService.CreateOrder(c.Request.Context(), c.Param("id"), userID.(string), req.TxHash)
+		if err != nil {
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+			case errors.Is(err, services.ErrOrderExists):
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			case errors.Is(err, services.ErrProductNotMinted),
+				errors.Is(err, services.ErrOwnProduct),
+				errors.Is(err, services.ErrDeploymentReadOnly),
+				errors.Is(err, services.ErrInvalidTxHash):
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create order"})
+			}
+			return
+		}
+
+		c.JSON(http.StatusCreated, order)
+	}
+}
+
+// HandleGetOrder returns a single order to its buyer or seller
+func HandleGetOrder(orderService *services.OrderService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		order, err := orderService.GetOrder(c.Param("id"))
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch order"})
+			return
+		}
+
+		userID, _ := c.Get("userID")
+		if userID.(string) != order.BuyerID && userID.(string) != order.SellerID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to view this order"})
+			return
+		}
+
+		c.JSON(http.StatusOK, order)
+	}
+}
@@ -1,31 +1,43 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/yourusername/revibe/backend/models"
+	"github.com/yourusername/revibe/backend/services"
+	"github.com/yourusername/revibe/backend/utils"
 	"gorm.io/gorm"
 )
 
 type Product struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name" binding:"required"`
-	Description string    `json:"description" binding:"required"`
-	Price       float64   `json:"price" binding:"required"`
-	Images      []string  `json:"images" binding:"required"`
-	Category    string    `json:"category" binding:"required"`
-	Condition   string    `json:"condition" binding:"required"`
-	SellerID    string    `json:"sellerId"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID               string     `json:"id"`
+	Name             string     `json:"name" binding:"required"`
+	Description      string     `json:"description" binding:"required"`
+	Price            float64    `json:"price" binding:"required"`
+	Images           []string   `gorm:"-" json:"images" binding:"required"`
+	Category         string     `json:"category" binding:"required"`
+	Condition        string     `json:"condition" binding:"required"`
+	SellerID         string     `json:"sellerId"`
+	ModerationStatus string     `json:"moderationStatus"`
+	MetadataURI      string     `gorm:"-" json:"metadataUri,omitempty"`
+	TokenID          *string    `json:"tokenId"`
+	ChainID          int64      `json:"chainId,omitempty"`
+	ContractAddress  string     `json:"contractAddress,omitempty"`
+	Status           string     `json:"status"`
+	Reserved         bool       `gorm:"-" json:"reserved"`
+	ReservedUntil    *time.Time `gorm:"-" json:"reservedUntil,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	UpdatedAt        time.Time  `json:"updatedAt"`
 }
 
-func HandleGetProducts(db *gorm.DB) gin.HandlerFunc {
+func HandleGetProducts(db *gorm.DB, reservationService *services.ReservationService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var products []Product
-		query := db.Model(&Product{})
+		query := db.Model(&Product{}).Where("moderation_status = ?", models.ModerationStatusApproved)
 
 		// Apply filters
 		if category := c.Query("category"); category != "" {
@@ -46,6 +58,24 @@ func HandleGetProducts(db *gorm.DB) gin.HandlerFunc {
 			return
 		}
 
+		// Mark products held by a buyer at checkout
+		ids := make([]string, len(products))
+		for i := range products {
+			ids[i] = products[i].ID
+		}
+		reservations, err := reservationService.GetMany(c.Request.Context(), ids)
+		if err != nil {
+			utils.LogError(err, map[string]interface{}{
+				"component": "reservations",
+			})
+		}
+		for i := range products {
+			if reservation, ok := reservations[products[i].ID]; ok {
+				products[i].Reserved = true
+				products[i].ReservedUntil = &reservation.ExpiresAt
+			}
+		}
+
 		c.JSON(http.StatusOK, products)
 	}
 }
@@ -68,7 +98,7 @@ func HandleGetProduct(db *gorm.DB) gin.HandlerFunc {
 	}
 }
 
-func HandleCreateProduct(db *gorm.DB) gin.HandlerFunc {
+func HandleCreateProduct(db *gorm.DB, imageHashService *services.ImageHashService, metadataService *services.MetadataService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var product Product
 		if err := c.ShouldBindJSON(&product); err != nil {
@@ -85,19 +115,47 @@ func HandleCreateProduct(db *gorm.DB) gin.HandlerFunc {
 
 		product.ID = uuid.New().String()
 		product.SellerID = userID.(string)
+		product.Status = models.ProductStatusActive
+		product.TokenID = nil
+		product.ChainID = 0
+		product.ContractAddress = ""
 		product.CreatedAt = time.Now()
 		product.UpdatedAt = time.Now()
 
-		if err := db.Create(&product).Error; err != nil {
+		// The listing stays hidden until its images have been checked against
+		// other sellers' listings; re-used photos send it to moderation
+		product.ModerationStatus = models.ModerationStatusPendingReview
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&product).Error; err != nil {
+				return err
+			}
+			matches, err := imageHashService.SetProductImages(tx, product.ID, product.SellerID, product.Images)
+			if err != nil {
+				return err
+			}
+			if len(matches) == 0 {
+				product.ModerationStatus = models.ModerationStatusApproved
+				return tx.Model(&product).Update("moderation_status", product.ModerationStatus).Error
+			}
+			return nil
+		})
+		if err != nil {
+			utils.LogError(err, map[string]interface{}{
+				"component":  "image_hash",
+				"product_id": product.ID,
+			})
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create product"})
 			return
 		}
 
+		// Metadata URI to pass to listProduct
+		product.MetadataURI = metadataService.MetadataURI(product.ID)
+
 		c.JSON(http.StatusCreated, product)
 	}
 }
 
-func HandleUpdateProduct(db *gorm.DB) gin.HandlerFunc {
+func HandleUpdateProduct(db *gorm.DB, imageHashService *services.ImageHashService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id := c.Param("id")
 		var product Product
@@ -133,7 +191,21 @@ func HandleUpdateProduct(db *gorm.DB) gin.HandlerFunc {
 		product.Condition = updateData.Condition
 		product.UpdatedAt = time.Now()
 
-		if err := db.Save(&product).Error; err != nil {
+		// New photos are checked like a new listing's
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Save(&product).Error; err != nil {
+				return err
+			}
+			if _, err := imageHashService.SetProductImages(tx, product.ID, product.SellerID, product.Images); err != nil {
+				return err
+			}
+			return tx.Model(&Product{}).Select("moderation_status").Where("id = ?", product.ID).Scan(&product.ModerationStatus).Error
+		})
+		if err != nil {
+			utils.LogError(err, map[string]interface{}{
+				"component":  "image_hash",
+				"product_id": product.ID,
+			})
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update product"})
 			return
 		}
@@ -172,7 +244,99 @@ func HandleDeleteProduct(db *gorm.DB) gin.HandlerFunc {
 	}
 }
 
-func HandleAuthenticateProduct(db *gorm.DB) gin.HandlerFunc {
+// HandleAuthenticateProduct runs the automated authenticators for a product
+// on behalf of its seller and returns the recorded result
+func HandleAuthenticateProduct(authService *services.AuthenticationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := c.Get("userID")
+		auth, err := authService.Authenticate(c.Request.Context(), c.Param("id"), userID.(string))
+		if err != nil {
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+			case errors.Is(err, services.ErrNotProductSeller):
+				c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			case errors.Is(err, services.ErrReauthenticationNeedsReview),
+				errors.Is(err, services.ErrEvidenceFileMissing):
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to authenticate product"})
+			}
+			return
+		}
+
+		c.JSON(http.StatusCreated, auth)
+	}
+}
+
+// HandleGetAuthentications returns a product's authentication history
+func HandleGetAuthentications(authService *services.AuthenticationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auths, err := authService.GetAuthentications(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch authentications"})
+			return
+		}
+
+		c.JSON(http.StatusOK, auths)
+	}
+}
+
+// HandleGetProvenance returns a product's chain of custody
+func HandleGetProvenance(provenanceService *services.ProvenanceService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provenance, err := provenanceService.GetProvenance(c.Param("id"))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch provenance"})
+			return
+		}
+
+		c.JSON(http.StatusOK, provenance)
+	}
+}
+
+type RevokeAuthenticationRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// HandleRevokeAuthentication withdraws a passing authentication
+func HandleRevokeAuthentication(expiryService *services.AuthExpiryService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req RevokeAuthenticationRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, _ := c.Get("userID")
+		auth, err := expiryService.Revoke(c.Param("id"), userID.(string), req.Reason)
+		if err != nil {
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				c.JSON(http.StatusNotFound, gin.H{"error": "Authentication not found"})
+			case errors.Is(err, services.ErrAuthenticationNotActive):
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke authentication"})
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, auth)
+	}
+}
+
+type TrackListingRequest struct {
+	TxHash string `json:"txHash" binding:"required"`
+}
+
+// HandleTrackListing records the listProduct transaction for a product so its
+// token ID can be linked once the transaction is mined
+func HandleTrackListing(db *gorm.DB, listingService *services.ListingService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id := c.Param("id")
 		var product Product
@@ -186,9 +350,47 @@ func HandleAuthenticateProduct(db *gorm.DB) gin.HandlerFunc {
 			return
 		}
 
-		// TODO: Implement product authentication logic
-		// This should interact with the smart contract to verify the product's authenticity
+		// Check if user is the seller
+		userID, exists := c.Get("userID")
+		if !exists || userID.(string) != product.SellerID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to list this product"})
+			return
+		}
 
-		c.JSON(http.StatusOK, gin.H{"message": "Product authentication initiated"})
+		if product.TokenID != nil {
+			c.JSON(http.StatusConflict, gin.H{"error": "Product is already linked to a token"})
+			return
+		}
+
+		var req TrackListingRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		listing, err := listingService.TrackListing(product.ID, req.TxHash)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusAccepted, listing)
+	}
+}
+
+// HandleGetProductByToken looks up a product by its on-chain token ID
+func HandleGetProductByToken(listingService *services.ListingService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		product, err := listingService.GetProductByToken(c.Param("tokenId"))
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch product"})
+			return
+		}
+
+		c.JSON(http.StatusOK, product)
 	}
-} 
\ No newline at end of file
+}
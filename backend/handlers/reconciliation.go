@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/revibe/backend/services"
+	"gorm.io/gorm"
+)
+
+type RunReconciliationRequest struct {
+	FromBlock *uint64 `json:"fromBlock"`
+	ToBlock   *uint64 `json:"toBlock"`
+	Repair    bool    `json:"repair"`
+}
+
+// HandleRunReconciliation runs a reconciliation immediately and returns its report
+func HandleRunReconciliation(reconciliationService *services.ReconciliationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req RunReconciliationRequest
+		if err := c.ShouldBindJSON(&req); err != nil && c.Request.ContentLength > 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, _ := c.Get("userID")
+		staffID := userID.(string)
+		run, err := reconciliationService.Run(c.Request.Context(), services.ReconcileOptions{
+			FromBlock:     req.FromBlock,
+			ToBlock:       req.ToBlock,
+			Repair:        req.Repair,
+			TriggeredByID: &staffID,
+		})
+		if err != nil {
+			switch err {
+			case services.ErrReconciliationRunning:
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			case services.ErrInvalidBlockRange:
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to run reconciliation"})
+			}
+			return
+		}
+
+		run, err = reconciliationService.GetRun(run.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch reconciliation report"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, run)
+	}
+}
+
+// HandleListReconciliationRuns lists past reconciliation runs
+func HandleListReconciliationRuns(reconciliationService *services.ReconciliationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, limit := getPagination(c)
+		runs, total, err := reconciliationService.ListRuns(page, limit)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch reconciliation runs"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"runs":  runs,
+			"total": total,
+			"page":  page,
+			"limit": limit,
+		})
+	}
+}
+
+// HandleGetReconciliationRun returns a reconciliation run with its discrepancies
+func HandleGetReconciliationRun(reconciliationService *services.ReconciliationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		run, err := reconciliationService.GetRun(c.Param("id"))
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Reconciliation run not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch reconciliation run"})
+			return
+		}
+
+		c.JSON(http.StatusOK, run)
+	}
+}
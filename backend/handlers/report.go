@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/revibe/backend/services"
+	"github.com/yourusername/revibe/backend/utils"
+	"gorm.io/gorm"
+)
+
+// HandleGetSalesReport returns a seller's sales report as JSON or CSV. The
+// period defaults to the current calendar year.
+func HandleGetSalesReport(db *gorm.DB, reportService *services.ReportService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := getOwnProfile(c, db)
+		if !ok {
+			return
+		}
+
+		from, to, err := getDateRange(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		now := time.Now().UTC()
+		if from.IsZero() {
+			from = time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		}
+		if to.IsZero() {
+			to = now
+		}
+		if !from.Before(to) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from must be before to"})
+			return
+		}
+
+		format := c.DefaultQuery("format", "json")
+		if format != "json" && format != "csv" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "format must be csv or json"})
+			return
+		}
+
+		report, err := reportService.GetSalesReport(user.ID, from, to)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build sales report"})
+			return
+		}
+
+		if format == "csv" {
+			filename := fmt.Sprintf("sales-%s-%s.csv", from.Format(dateLayout), to.Format(dateLayout))
+			c.Header("Content-Type", "text/csv")
+			c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+			c.Status(http.StatusOK)
+			// Headers are already sent, so a write error can only be logged
+			if err := report.WriteCSV(c.Writer); err != nil {
+				utils.LogError(err, map[string]interface{}{
+					"component": "sales_report",
+					"user_id":   user.ID,
+				})
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, report)
+	}
+}
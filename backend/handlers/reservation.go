@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/revibe/backend/services"
+	"gorm.io/gorm"
+)
+
+// HandleReserveProduct places a time-limited checkout hold on a product
+func HandleReserveProduct(reservationService *services.ReservationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := c.Get("userID")
+		reservation, err := reservationService.Reserve(c.Request.Context(), c.Param("id"), userID.(string))
+		if err != nil {
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+			case errors.Is(err, services.ErrProductReserved):
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			case errors.Is(err, services.ErrProductNotAvailable),
+				errors.Is(err, services.ErrProductNotMinted),
+				errors.Is(err, services.ErrOwnProduct),
+				errors.Is(err, services.ErrReauthenticationRequired):
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reserve product"})
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, reservation)
+	}
+}
+
+// HandleGetReservation returns the active hold on a product with its remaining time
+func HandleGetReservation(reservationService *services.ReservationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reservation, err := reservationService.Get(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			if err == services.ErrReservationNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Product is not reserved"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch reservation"})
+			return
+		}
+
+		c.JSON(http.StatusOK, reservation)
+	}
+}
+
+// HandleCancelReservation releases the caller's hold on a product
+func HandleCancelReservation(reservationService *services.ReservationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := c.Get("userID")
+		if err := reservationService.Cancel(c.Request.Context(), c.Param("id"), userID.(string)); err != nil {
+			if err == services.ErrReservationNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "No reservation to cancel"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel reservation"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Reservation cancelled successfully"})
+	}
+}
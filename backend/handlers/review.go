@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/revibe/backend/models"
+	"github.com/yourusername/revibe/backend/services"
+	"gorm.io/gorm"
+)
+
+type SubmitReviewRequest struct {
+	Findings     string               `json:"findings" binding:"required"`
+	Score        *float64             `json:"score" binding:"required"`
+	Verdict      string               `json:"verdict" binding:"required,oneof=pass fail"`
+	Photos       []string             `json:"photos"`
+	Measurements []models.Measurement `json:"measurements"`
+}
+
+type ReassignReviewRequest struct {
+	ReviewerID string `json:"reviewerId"`
+}
+
+func reviewErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
+	case errors.Is(err, services.ErrNotProductSeller),
+		errors.Is(err, services.ErrNotAssignedReviewer),
+		errors.Is(err, services.ErrOwnProductReview):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrReviewRequestExists), errors.Is(err, services.ErrRequestNotClaimable):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrProductNotMinted),
+		errors.Is(err, services.ErrAlreadyReviewed),
+		errors.Is(err, services.ErrNotReviewer),
+		errors.Is(err, services.ErrInvalidReview),
+		errors.Is(err, services.ErrRequestClosed),
+		errors.Is(err, services.ErrEvidenceFileMissing):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process authentication request"})
+	}
+}
+
+// HandleRequestReview lets a seller queue a listed product for expert authentication
+func HandleRequestReview(reviewService *services.ReviewService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := c.Get("userID")
+		request, err := reviewService.RequestReview(c.Param("id"), userID.(string))
+		if err != nil {
+			reviewErrorResponse(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, request)
+	}
+}
+
+// HandleGetReviewRequest returns an authentication request to its seller or to reviewers
+func HandleGetReviewRequest(db *gorm.DB, reviewService *services.ReviewService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request, err := reviewService.GetRequest(c.Param("id"))
+		if err != nil {
+			reviewErrorResponse(c, err)
+			return
+		}
+
+		userID, _ := c.Get("userID")
+		if request.SellerID != userID.(string) {
+			var user models.User
+			if err := db.First(&user, "id = ?", userID.(string)).Error; err != nil ||
+				(user.Role != models.RoleAuthenticator && user.Role != models.RoleAdmin) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to view this request"})
+				return
+			}
+		}
+
+		c.JSON(http.StatusOK, request)
+	}
+}
+
+// HandleGetReviewQueue lists the requests the calling reviewer can work on
+func HandleGetReviewQueue(reviewService *services.ReviewService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := c.Get("userID")
+		page, limit := getPagination(c)
+		requests, total, err := reviewService.ListQueue(userID.(string), c.Query("category"), page, limit)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch review queue"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"requests": requests,
+			"total":    total,
+			"page":     page,
+			"limit":    limit,
+		})
+	}
+}
+
+// HandleClaimReview assigns a queued request to the calling reviewer
+func HandleClaimReview(reviewService *services.ReviewService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := c.Get("userID")
+		request, err := reviewService.Claim(c.Param("id"), userID.(string))
+		if err != nil {
+			reviewErrorResponse(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, request)
+	}
+}
+
+// HandleReleaseReview returns a claimed request to the queue
+func HandleReleaseReview(reviewService *services.ReviewService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := c.Get("userID")
+		request, err := reviewService.Release(c.Param("id"), userID.(string))
+		if err != nil {
+			reviewErrorResponse(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, request)
+	}
+}
+
+// HandleSubmitReview records the assigned reviewer's findings and verdict
+func HandleSubmitReview(reviewService *services.ReviewService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req SubmitReviewRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, _ := c.Get("userID")
+		request, err := reviewService.SubmitReview(c.Param("id"), userID.(string), services.ReviewSubmission{
+			Findings:     req.Findings,
+			Score:        *req.Score,
+			Verdict:      req.Verdict,
+			Photos:       req.Photos,
+			Measurements: req.Measurements,
+		})
+		if err != nil {
+			reviewErrorResponse(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, request)
+	}
+}
+
+// HandleListReviewRequests lists authentication requests for staff
+func HandleListReviewRequests(reviewService *services.ReviewService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, limit := getPagination(c)
+		requests, total, err := reviewService.ListRequests(c.Query("status"), c.Query("overdue") == "true", page, limit)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch authentication requests"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"requests": requests,
+			"total":    total,
+			"page":     page,
+			"limit":    limit,
+		})
+	}
+}
+
+// HandleReassignReview hands a request to another reviewer, or back to the queue
+func HandleReassignReview(reviewService *services.ReviewService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req ReassignReviewRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		request, err := reviewService.Reassign(c.Param("id"), req.ReviewerID)
+		if err != nil {
+			reviewErrorResponse(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, request)
+	}
+}
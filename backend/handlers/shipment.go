@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/revibe/backend/services"
+	"gorm.io/gorm"
+)
+
+type AddTrackingRequest struct {
+	Carrier        string `json:"carrier" binding:"required"`
+	TrackingNumber string `json:"trackingNumber" binding:"required"`
+}
+
+func shipmentErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+	case errors.Is(err, services.ErrNotOrderSeller), errors.Is(err, services.ErrNotOrderBuyer):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrOrderNotShippable), errors.Is(err, services.ErrNoShipment):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update shipment"})
+	}
+}
+
+// HandleAddTracking lets the seller attach carrier tracking to an order
+func HandleAddTracking(shipmentService *services.ShipmentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req AddTrackingRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID, _ := c.Get("userID")
+		shipment, err := shipmentService.AddTracking(c.Param("id"), userID.(string), req.Carrier, req.TrackingNumber)
+		if err != nil {
+			shipmentErrorResponse(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, shipment)
+	}
+}
+
+// HandleGetShipments returns an order's shipments to its buyer or seller
+func HandleGetShipments(orderService *services.OrderService, shipmentService *services.ShipmentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		order, err := orderService.GetOrder(c.Param("id"))
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch order"})
+			return
+		}
+
+		userID, _ := c.Get("userID")
+		if userID.(string) != order.BuyerID && userID.(string) != order.SellerID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to view this order"})
+			return
+		}
+
+		shipments, err := shipmentService.GetShipments(order.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch shipments"})
+			return
+		}
+
+		c.JSON(http.StatusOK, shipments)
+	}
+}
+
+// HandleConfirmReceipt lets the buyer confirm that an order was delivered
+func HandleConfirmReceipt(shipmentService *services.ShipmentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := c.Get("userID")
+		shipment, err := shipmentService.ConfirmReceipt(c.Param("id"), userID.(string))
+		if err != nil {
+			shipmentErrorResponse(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, shipment)
+	}
+}
+
+// HandleGetOverdueShipments lists orders not shipped within the shipping SLA
+func HandleGetOverdueShipments(shipmentService *services.ShipmentService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		orders, err := shipmentService.GetOverdueOrders()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch overdue orders"})
+			return
+		}
+
+		c.JSON(http.StatusOK, orders)
+	}
+}
@@ -6,10 +6,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/yourusername/revibe/backend/services"
+	"github.com/yourusername/revibe/backend/utils"
 )
 
 // HandleUpload handles file upload requests
-func HandleUpload(uploadService *services.UploadService) gin.HandlerFunc {
+func HandleUpload(uploadService *services.UploadService, imageHashService *services.ImageHashService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get file from request
 		file, err := c.FormFile("file")
@@ -28,6 +29,18 @@ func HandleUpload(uploadService *services.UploadService) gin.HandlerFunc {
 			return
 		}
 
+		// Index product images so re-listed photos can be detected
+		if subDir == "products" {
+			userID, _ := c.Get("userID")
+			uploaderID, _ := userID.(string)
+			if _, err := imageHashService.IndexFile(url, uploaderID); err != nil {
+				utils.LogWarning("Failed to index image hash", map[string]interface{}{
+					"path":  url,
+					"error": err.Error(),
+				})
+			}
+		}
+
 		// Return file URL
 		c.JSON(http.StatusOK, gin.H{
 			"url": uploadService.GetFileURL(url),
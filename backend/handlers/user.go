@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/yourusername/revibe/backend/services"
 	"gorm.io/gorm"
 )
 
@@ -105,7 +106,7 @@ func HandleGetUserProducts(db *gorm.DB) gin.HandlerFunc {
 	}
 }
 
-func HandleGetUserOrders(db *gorm.DB) gin.HandlerFunc {
+func HandleGetUserOrders(db *gorm.DB, orderService *services.OrderService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		walletAddress := c.Param("walletAddress")
 		var user UserProfile
@@ -119,9 +120,25 @@ func HandleGetUserOrders(db *gorm.DB) gin.HandlerFunc {
 			return
 		}
 
-		// TODO: Implement order fetching logic
-		// This should fetch orders from the smart contract and combine with product data
+		// Orders are only visible to the user they belong to
+		userID, exists := c.Get("userID")
+		if !exists || userID.(string) != user.ID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not authorized to view these orders"})
+			return
+		}
+
+		page, limit := getPagination(c)
+		orders, total, err := orderService.ListOrders(user.ID, c.Query("role"), c.Query("status"), page, limit)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch user orders"})
+			return
+		}
 
-		c.JSON(http.StatusOK, gin.H{"message": "Orders endpoint not implemented yet"})
+		c.JSON(http.StatusOK, gin.H{
+			"orders": orders,
+			"total":  total,
+			"page":   page,
+			"limit":  limit,
+		})
 	}
 } 
\ No newline at end of file
@@ -14,10 +14,40 @@ import (
 	"github.com/yourusername/revibe/backend/database"
 	"github.com/yourusername/revibe/backend/handlers"
 	"github.com/yourusername/revibe/backend/middleware"
+	"github.com/yourusername/revibe/backend/models"
 	"github.com/yourusername/revibe/backend/services"
 	"github.com/yourusername/revibe/backend/utils"
 )
 
+// appServices groups the services shared by route handlers
+type appServices struct {
+	deployments *services.DeploymentRegistry
+	web3        *services.Web3Service
+	upload      *services.UploadService
+	imageHash   *services.ImageHashService
+	metadata    *services.MetadataService
+	listing     *services.ListingService
+	reservation *services.ReservationService
+	order       *services.OrderService
+	ledger      *services.LedgerService
+	shipment    *services.ShipmentService
+	dispute     *services.DisputeService
+	indexer     *services.IndexerService
+	indexers    []*services.IndexerService
+	txManager   *services.TransactionManager
+	txStatus    *services.TransactionStatusService
+	operator    *services.OperatorService
+	reconcile   *services.ReconciliationService
+	report      *services.ReportService
+	auth        *services.AuthenticationService
+	review      *services.ReviewService
+	certificate *services.CertificateService
+	provenance  *services.ProvenanceService
+	evidence    *services.EvidenceService
+	authExpiry  *services.AuthExpiryService
+	metrics     *services.MetricsService
+}
+
 func main() {
 	// Load environment variables
 	if err := godotenv.Load(); err != nil {
@@ -42,12 +72,20 @@ func main() {
 	}
 	defer database.CloseDB()
 
-	// Initialize Web3 service
-	web3Service, err := services.NewWeb3Service()
+	// Initialize Redis
+	if err := database.InitRedis(); err != nil {
+		utils.LogFatal(err, nil)
+	}
+	defer database.CloseRedis()
+
+	// Initialize contract deployments. Services that work with a single
+	// contract use the active deployment.
+	deploymentRegistry, err := services.NewDeploymentRegistry(database.DB)
 	if err != nil {
 		utils.LogFatal(err, nil)
 	}
-	defer web3Service.Close()
+	defer deploymentRegistry.Close()
+	web3Service := deploymentRegistry.Active()
 
 	// Initialize upload service
 	uploadService, err := services.NewUploadService()
@@ -55,6 +93,108 @@ func main() {
 		utils.LogFatal(err, nil)
 	}
 
+	// Initialize image hash service
+	imageHashService := services.NewImageHashService(database.DB)
+	if err := imageHashService.IndexBands(); err != nil {
+		utils.LogError(err, map[string]interface{}{
+			"component": "image_hash",
+		})
+	}
+
+	// Initialize metadata service
+	metadataService := services.NewMetadataService(database.DB, web3Service, uploadService)
+
+	// Initialize listing service
+	listingService := services.NewListingService(database.DB, deploymentRegistry)
+
+	// Initialize reservation service
+	reservationService := services.NewReservationService(database.DB, database.Redis)
+
+	// Initialize ledger service
+	ledgerService := services.NewLedgerService(database.DB, deploymentRegistry)
+
+	// Initialize order service
+	orderService := services.NewOrderService(database.DB, deploymentRegistry, reservationService, ledgerService)
+
+	// Initialize shipment service
+	var carrierTracker services.CarrierTracker
+	if config.AppConfig.CarrierTrackingDir != "" {
+		carrierTracker = services.NewFileCarrierTracker(config.AppConfig.CarrierTrackingDir)
+	}
+	shipmentService := services.NewShipmentService(database.DB, carrierTracker)
+
+	// Initialize transaction manager
+	txManager := services.NewTransactionManager(database.DB, deploymentRegistry)
+
+	// Initialize operator signer and service
+	operatorSigner, err := services.NewOperatorSigner()
+	if err != nil {
+		utils.LogFatal(err, nil)
+	}
+	operatorService := services.NewOperatorService(database.DB, deploymentRegistry, txManager, operatorSigner)
+
+	// Initialize reconciliation service
+	reconciliationService := services.NewReconciliationService(database.DB, web3Service, listingService, orderService)
+
+	// Initialize certificate service
+	certificateService, err := services.NewCertificateService(database.DB)
+	if err != nil {
+		utils.LogFatal(err, nil)
+	}
+
+	// Initialize evidence service
+	evidenceService := services.NewEvidenceService(database.DB)
+
+	// Initialize authentication expiry
+	authExpiryService, err := services.NewAuthExpiryService(database.DB, operatorService, certificateService)
+	if err != nil {
+		utils.LogFatal(err, nil)
+	}
+
+	// Initialize a contract event indexer per deployment, sharing the database projection
+	projectionService := services.NewProjectionService(database.DB, orderService, ledgerService, certificateService, authExpiryService)
+	var indexerService *services.IndexerService
+	var indexers []*services.IndexerService
+	for _, deployment := range deploymentRegistry.Deployments() {
+		indexer := services.NewIndexerService(database.DB, deployment)
+		if err := indexer.CheckStartBlock(); err != nil {
+			utils.LogFatal(err, map[string]interface{}{
+				"chain_id": deployment.ChainID().Int64(),
+				"contract": deployment.ContractAddress().Hex(),
+			})
+		}
+		indexer.AddHandler(projectionService)
+		indexers = append(indexers, indexer)
+		if deployment == web3Service {
+			indexerService = indexer
+		}
+	}
+
+	// Initialize ownership history, built from the indexers' token transfers
+	provenanceService := services.NewProvenanceService(database.DB)
+
+	// Initialize transaction status lookups, backed by the indexers' receipt cache
+	txStatusService := services.NewTransactionStatusService(database.DB, deploymentRegistry)
+
+	// Initialize product authentication
+	authenticatorRegistry, err := services.NewDefaultAuthenticatorRegistry(database.DB)
+	if err != nil {
+		utils.LogFatal(err, nil)
+	}
+	authService := services.NewAuthenticationService(database.DB, authenticatorRegistry, uploadService, certificateService, evidenceService, authExpiryService)
+
+	// Initialize expert review queue
+	reviewService := services.NewReviewService(database.DB, operatorService, certificateService, evidenceService, authExpiryService)
+
+	// Initialize report service
+	reportService, err := services.NewReportService(database.DB)
+	if err != nil {
+		utils.LogFatal(err, nil)
+	}
+
+	// Initialize dispute service
+	disputeService := services.NewDisputeService(database.DB, uploadService, operatorService)
+
 	// Initialize metrics service
 	metricsService, err := services.NewMetricsService()
 	if err != nil {
@@ -65,13 +205,38 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Start event listeners
-	if err := web3Service.StartEventListeners(ctx); err != nil {
-		utils.LogError(err, map[string]interface{}{
-			"component": "event_listeners",
-		})
+	// Start RPC provider health checks
+	go deploymentRegistry.StartRPCHealthChecks(ctx)
+
+	// Start contract event indexers
+	for _, indexer := range indexers {
+		go indexer.StartIndexer(ctx)
 	}
 
+	// Start listing transaction tracker
+	go listingService.StartListingTracker(ctx)
+
+	// Start order tracker
+	go orderService.StartOrderTracker(ctx)
+
+	// Start shipment monitor
+	go shipmentService.StartShipmentMonitor(ctx)
+
+	// Start transaction manager
+	go txManager.StartTransactionManager(ctx)
+
+	// Start operator transaction queue
+	go operatorService.StartOperatorQueue(ctx)
+
+	// Start authentication review SLA monitor
+	go reviewService.StartReviewMonitor(ctx)
+
+	// Start authentication expiry monitor
+	go authExpiryService.StartExpiryMonitor(ctx)
+
+	// Start chain reconciliation
+	go reconciliationService.StartReconciler(ctx)
+
 	// Start metrics collector
 	go metricsService.StartMetricsCollector(ctx)
 
@@ -86,7 +251,33 @@ func main() {
 	router.Use(middleware.Metrics(metricsService))
 
 	// Setup routes
-	setupRoutes(router, web3Service, uploadService, metricsService)
+	setupRoutes(router, &appServices{
+		deployments: deploymentRegistry,
+		web3:        web3Service,
+		upload:      uploadService,
+		imageHash:   imageHashService,
+		metadata:    metadataService,
+		listing:     listingService,
+		reservation: reservationService,
+		order:       orderService,
+		ledger:      ledgerService,
+		shipment:    shipmentService,
+		dispute:     disputeService,
+		indexer:     indexerService,
+		indexers:    indexers,
+		txManager:   txManager,
+		txStatus:    txStatusService,
+		operator:    operatorService,
+		reconcile:   reconciliationService,
+		report:      reportService,
+		auth:        authService,
+		review:      reviewService,
+		certificate: certificateService,
+		provenance:  provenanceService,
+		evidence:    evidenceService,
+		authExpiry:  authExpiryService,
+		metrics:     metricsService,
+	})
 
 	// Start server
 	server := &http.Server{
@@ -155,7 +346,7 @@ func initDB() (*gorm.DB, error) {
 	return db, nil
 }
 
-func setupRoutes(router *gin.Engine, web3Service *services.Web3Service, uploadService *services.UploadService, metricsService *services.MetricsService) {
+func setupRoutes(router *gin.Engine, svc *appServices) {
 	// Health check and metrics routes
 	router.GET("/health", handlers.HandleHealthCheck())
 	router.GET("/metrics", handlers.HandleMetrics())
@@ -163,8 +354,8 @@ func setupRoutes(router *gin.Engine, web3Service *services.Web3Service, uploadSe
 	// Auth routes
 	auth := router.Group("/auth")
 	{
-		auth.POST("/login", handlers.HandleLogin(database.DB, web3Service))
-		auth.POST("/verify", handlers.HandleVerify(database.DB, web3Service))
+		auth.POST("/login", handlers.HandleLogin(database.DB, svc.web3))
+		auth.POST("/verify", handlers.HandleVerify(database.DB, svc.web3))
 	}
 
 	// Protected routes
@@ -174,34 +365,128 @@ func setupRoutes(router *gin.Engine, web3Service *services.Web3Service, uploadSe
 		// Product routes
 		products := protected.Group("/products")
 		{
-			products.GET("", handlers.HandleGetProducts(database.DB, web3Service))
-			products.GET("/:id", handlers.HandleGetProduct(database.DB, web3Service))
-			products.POST("", handlers.HandleCreateProduct(database.DB, web3Service))
-			products.PUT("/:id", handlers.HandleUpdateProduct(database.DB, web3Service))
-			products.DELETE("/:id", handlers.HandleDeleteProduct(database.DB, web3Service))
-			products.POST("/:id/authenticate", handlers.HandleAuthenticateProduct(database.DB, web3Service))
+			products.GET("", handlers.HandleGetProducts(database.DB, svc.reservation))
+			products.GET("/by-token/:tokenId", handlers.HandleGetProductByToken(svc.listing))
+			products.GET("/:id", handlers.HandleGetProduct(database.DB, svc.web3))
+			products.POST("", handlers.HandleCreateProduct(database.DB, svc.imageHash, svc.metadata))
+			products.PUT("/:id", handlers.HandleUpdateProduct(database.DB, svc.imageHash))
+			products.DELETE("/:id", handlers.HandleDeleteProduct(database.DB, svc.web3))
+			products.POST("/:id/authenticate", handlers.HandleAuthenticateProduct(svc.auth))
+			products.GET("/:id/authentications", handlers.HandleGetAuthentications(svc.auth))
+			products.GET("/:id/provenance", handlers.HandleGetProvenance(svc.provenance))
+			products.POST("/:id/authentication-requests", handlers.HandleRequestReview(svc.review))
+			products.POST("/:id/listing", handlers.HandleTrackListing(database.DB, svc.listing))
+			products.POST("/:id/reserve", handlers.HandleReserveProduct(svc.reservation))
+			products.GET("/:id/reserve", handlers.HandleGetReservation(svc.reservation))
+			products.DELETE("/:id/reserve", handlers.HandleCancelReservation(svc.reservation))
+			products.POST("/:id/checkout", handlers.HandleCheckout(svc.order))
+		}
+
+		// Order routes
+		orders := protected.Group("/orders")
+		{
+			orders.GET("/:id", handlers.HandleGetOrder(svc.order))
+			orders.GET("/:id/shipments", handlers.HandleGetShipments(svc.order, svc.shipment))
+			orders.POST("/:id/shipments", handlers.HandleAddTracking(svc.shipment))
+			orders.POST("/:id/confirm-receipt", handlers.HandleConfirmReceipt(svc.shipment))
+			orders.POST("/:id/disputes", handlers.HandleOpenDispute(svc.dispute))
+		}
+
+		// Dispute routes
+		disputes := protected.Group("/disputes")
+		{
+			disputes.GET("/:id", handlers.HandleGetDispute(svc.dispute))
+			disputes.POST("/:id/evidence", handlers.HandleSubmitDisputeEvidence(svc.dispute))
+		}
+
+		// Authentication evidence routes
+		authentications := protected.Group("/authentications")
+		{
+			authentications.GET("/:id/evidence", handlers.HandleGetEvidence(svc.evidence))
+			authentications.GET("/:id/evidence/verify", handlers.HandleVerifyEvidence(svc.evidence))
+			authentications.GET("/:id/evidence/archive", handlers.HandleDownloadEvidence(svc.evidence))
+		}
+
+		// Transaction status routes
+		protected.GET("/transactions/:hash", handlers.HandleGetTransactionStatus(svc.txStatus))
+
+		// Authentication request routes
+		protected.GET("/authentication-requests/:id", handlers.HandleGetReviewRequest(database.DB, svc.review))
+
+		// Expert review routes
+		reviews := protected.Group("/reviews")
+		reviews.Use(middleware.RequireRole(database.DB, models.RoleAuthenticator, models.RoleAdmin))
+		{
+			reviews.GET("/queue", handlers.HandleGetReviewQueue(svc.review))
+			reviews.POST("/requests/:id/claim", handlers.HandleClaimReview(svc.review))
+			reviews.POST("/requests/:id/release", handlers.HandleReleaseReview(svc.review))
+			reviews.POST("/requests/:id/reviews", handlers.HandleSubmitReview(svc.review))
 		}
 
 		// User routes
 		users := protected.Group("/users")
 		{
-			users.GET("/:walletAddress", handlers.HandleGetUser(database.DB, web3Service))
-			users.PUT("/:walletAddress", handlers.HandleUpdateUser(database.DB, web3Service))
-			users.GET("/:walletAddress/products", handlers.HandleGetUserProducts(database.DB, web3Service))
-			users.GET("/:walletAddress/orders", handlers.HandleGetUserOrders(database.DB, web3Service))
+			users.GET("/:walletAddress", handlers.HandleGetUser(database.DB, svc.web3))
+			users.PUT("/:walletAddress", handlers.HandleUpdateUser(database.DB, svc.web3))
+			users.GET("/:walletAddress/products", handlers.HandleGetUserProducts(database.DB, svc.web3))
+			users.GET("/:walletAddress/orders", handlers.HandleGetUserOrders(database.DB, svc.order))
+			users.GET("/:walletAddress/balance", handlers.HandleGetSellerBalance(database.DB, svc.ledger))
+			users.GET("/:walletAddress/statement", handlers.HandleGetSellerStatement(database.DB, svc.ledger))
+			users.GET("/:walletAddress/reports/sales", handlers.HandleGetSalesReport(database.DB, svc.report))
 		}
 
 		// Upload routes
 		uploads := protected.Group("/uploads")
 		{
-			uploads.POST("", handlers.HandleUpload(uploadService))
-			uploads.DELETE("", handlers.HandleDeleteFile(uploadService))
-			uploads.POST("/cleanup", handlers.HandleCleanupUnusedFiles(uploadService, database.DB))
+			uploads.POST("", handlers.HandleUpload(svc.upload, svc.imageHash))
+			uploads.DELETE("", handlers.HandleDeleteFile(svc.upload))
+			uploads.POST("/cleanup", handlers.HandleCleanupUnusedFiles(svc.upload, database.DB))
+		}
+
+		// Admin routes
+		admin := protected.Group("/admin")
+		admin.Use(middleware.RequireRole(database.DB, models.RoleAdmin))
+		{
+			admin.GET("/image-matches", handlers.HandleGetImageMatches(svc.imageHash))
+			admin.POST("/image-matches/:id/resolve", handlers.HandleResolveImageMatch(svc.imageHash))
+			admin.GET("/orders/overdue-shipments", handlers.HandleGetOverdueShipments(svc.shipment))
+			admin.GET("/disputes", handlers.HandleListDisputes(svc.dispute))
+			admin.GET("/disputes/:id", handlers.HandleGetDisputeAdmin(svc.dispute))
+			admin.POST("/disputes/:id/request-evidence", handlers.HandleTransitionDispute(svc.dispute, models.DisputeStatusEvidenceRequested))
+			admin.POST("/disputes/:id/review", handlers.HandleTransitionDispute(svc.dispute, models.DisputeStatusUnderReview))
+			admin.POST("/disputes/:id/resolve", handlers.HandleResolveDispute(svc.dispute))
+			admin.GET("/revenue", handlers.HandleGetRevenueSummary(svc.ledger))
+			admin.GET("/authentication-requests", handlers.HandleListReviewRequests(svc.review))
+			admin.POST("/authentication-requests/:id/reassign", handlers.HandleReassignReview(svc.review))
+			admin.POST("/authentications/:id/revoke", handlers.HandleRevokeAuthentication(svc.authExpiry))
+			admin.POST("/certificates/:id/revoke", handlers.HandleRevokeCertificate(svc.certificate))
+			admin.GET("/rpc/providers", handlers.HandleGetRPCStatus(svc.deployments))
+			admin.GET("/deployments", handlers.HandleListDeployments(svc.indexers))
+			admin.GET("/indexer", handlers.HandleGetIndexerStatus(svc.indexer))
+			admin.GET("/transactions", handlers.HandleListManagedTransactions(svc.txManager))
+			admin.GET("/transactions/:id", handlers.HandleGetManagedTransaction(svc.txManager))
+			admin.POST("/platform-fee", handlers.HandleUpdatePlatformFee(svc.operator))
+			admin.POST("/withdraw", handlers.HandleWithdraw(svc.operator))
+			admin.GET("/operator-transactions/:id", handlers.HandleGetOperatorTransaction(svc.operator))
+			admin.GET("/reconciliation/runs", handlers.HandleListReconciliationRuns(svc.reconcile))
+			admin.POST("/reconciliation/runs", handlers.HandleRunReconciliation(svc.reconcile))
+			admin.GET("/reconciliation/runs/:id", handlers.HandleGetReconciliationRun(svc.reconcile))
 		}
 	}
 
 	// Public file routes
-	router.GET("/uploads/*path", handlers.HandleGetFile(uploadService))
+	router.GET("/uploads/*path", handlers.HandleGetFile(svc.upload))
+
+	// Public token metadata routes
+	router.GET("/metadata/:tokenId", handlers.HandleGetTokenMetadata(svc.metadata))
+
+	// Public certificate routes
+	certificates := router.Group("/certificates")
+	{
+		certificates.GET("/keys", handlers.HandleGetCertificateKeys(svc.certificate))
+		certificates.POST("/verify", handlers.HandleVerifyCertificate(svc.certificate))
+		certificates.GET("/:id", handlers.HandleGetCertificate(svc.certificate))
+	}
 }
 
 func corsMiddleware() gin.HandlerFunc {
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// Certificate is a signed certificate of authenticity issued for a passing
+// authentication. Token is the compact JWS holding the credential, which can
+// be verified offline with the platform's public key.
+type Certificate struct {
+	ID               string     `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	AuthenticationID string     `gorm:"type:uuid;uniqueIndex;not null" json:"authenticationId"`
+	ProductID        string     `gorm:"type:uuid;index;not null" json:"productId"`
+	TokenID          *string    `gorm:"size:78" json:"tokenId,omitempty"`
+	KeyID            string     `gorm:"size:64;not null" json:"keyId"`
+	Token            string     `gorm:"type:text;not null" json:"token"`
+	IssuedAt         time.Time  `gorm:"not null" json:"issuedAt"`
+	RevokedAt        *time.Time `json:"revokedAt,omitempty"`
+	RevokedByID      *string    `gorm:"type:uuid" json:"revokedById,omitempty"`
+	RevocationReason string     `gorm:"type:text" json:"revocationReason,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	UpdatedAt        time.Time  `json:"updatedAt"`
+}
+
+// Revoked reports whether the certificate has been revoked
+func (c *Certificate) Revoked() bool {
+	return c.RevokedAt != nil
+}
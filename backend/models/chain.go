@@ -0,0 +1,194 @@
+package models
+
+import (
+	"time"
+)
+
+// Deployment statuses
+const (
+	DeploymentStatusActive = "active"
+	DeploymentStatusLegacy = "legacy"
+)
+
+// Deployment is a ReVibe contract deployed on a chain. New listings go to
+// the active deployment of the default chain; legacy deployments are still
+// indexed and read but the backend sends them no new transactions.
+// ABIVersion names the contract version the deployment runs.
+type Deployment struct {
+	ID              string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	ChainID         int64     `gorm:"uniqueIndex:idx_deployment;not null" json:"chainId"`
+	ContractAddress string    `gorm:"size:42;uniqueIndex:idx_deployment;not null" json:"contractAddress"`
+	ABIVersion      string    `gorm:"size:20;not null" json:"abiVersion"`
+	Status          string    `gorm:"size:50;not null;default:'active'" json:"status"`
+	StartBlock      uint64    `json:"startBlock"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+// Listing transaction statuses
+const (
+	ListingStatusPending   = "pending"
+	ListingStatusConfirmed = "confirmed"
+	ListingStatusFailed    = "failed"
+	ListingStatusExpired   = "expired"
+)
+
+// ListingTransaction tracks the listProduct transaction that mints a product's token
+type ListingTransaction struct {
+	ID              string     `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	ProductID       string     `gorm:"type:uuid;index;not null" json:"productId"`
+	TxHash          string     `gorm:"size:66;uniqueIndex;not null" json:"txHash"`
+	ChainID         int64      `gorm:"not null" json:"chainId"`
+	ContractAddress string     `gorm:"size:42;not null" json:"contractAddress"`
+	Status          string     `gorm:"size:50;not null;default:'pending'" json:"status"`
+	TokenID         *string    `gorm:"size:78" json:"tokenId"`
+	BlockNumber     uint64     `json:"blockNumber,omitempty"`
+	Error           string     `gorm:"type:text" json:"error,omitempty"`
+	ConfirmedAt     *time.Time `json:"confirmedAt,omitempty"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
+
+// IndexerCheckpoint records the last block of a contract the event indexer
+// has processed, and that block's hash for reorg detection
+type IndexerCheckpoint struct {
+	ID              string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	ChainID         int64     `gorm:"uniqueIndex:idx_indexer_checkpoint;not null" json:"chainId"`
+	ContractAddress string    `gorm:"size:42;uniqueIndex:idx_indexer_checkpoint;not null" json:"contractAddress"`
+	BlockNumber     uint64    `json:"blockNumber"`
+	BlockHash       string    `gorm:"size:66" json:"blockHash"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+// IndexedBlock is the hash of a block the indexer processed, kept for
+// recent blocks so a reorg can be traced back to the common ancestor
+type IndexedBlock struct {
+	ID              string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	ChainID         int64     `gorm:"uniqueIndex:idx_indexed_block;not null" json:"chainId"`
+	ContractAddress string    `gorm:"size:42;uniqueIndex:idx_indexed_block;not null" json:"contractAddress"`
+	Number          uint64    `gorm:"uniqueIndex:idx_indexed_block;not null" json:"number"`
+	Hash            string    `gorm:"size:66;not null" json:"hash"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+// ChainEvent is a contract log processed by the indexer. A log is only
+// processed once, keyed by its transaction hash and log index. Projection
+// records what the event changed in the database so a reorg can undo it.
+type ChainEvent struct {
+	ID              string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	ChainID         int64     `gorm:"index:idx_chain_event_block;not null" json:"chainId"`
+	ContractAddress string    `gorm:"size:42;index:idx_chain_event_block;not null" json:"contractAddress"`
+	BlockNumber     uint64    `gorm:"index:idx_chain_event_block;not null" json:"blockNumber"`
+	BlockHash       string    `gorm:"size:66;not null" json:"blockHash"`
+	BlockTime       time.Time `json:"blockTime"`
+	TxHash          string    `gorm:"size:66;uniqueIndex:idx_chain_event_log;not null" json:"txHash"`
+	LogIndex        uint      `gorm:"uniqueIndex:idx_chain_event_log;not null" json:"logIndex"`
+	DeploymentID    *string   `gorm:"type:uuid;index" json:"deploymentId,omitempty"`
+	Name            string    `gorm:"size:50;not null" json:"name"`
+	TokenID         string    `gorm:"size:78;index" json:"tokenId,omitempty"`
+	ProductID       *string   `gorm:"type:uuid;index" json:"productId,omitempty"`
+	Projection      string    `gorm:"type:text" json:"-"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+// TokenTransfer is an ERC-721 Transfer of a ReVibe token, recorded whether
+// or not it went through the marketplace. A mint is a transfer from the zero
+// address. PriceWei and OrderID are set when the transaction's ProductSold
+// shows the transfer was a sale.
+type TokenTransfer struct {
+	ID              string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	ChainEventID    string    `gorm:"type:uuid;uniqueIndex;not null" json:"-"`
+	ChainID         int64     `gorm:"index:idx_token_transfer_token;not null" json:"chainId"`
+	ContractAddress string    `gorm:"size:42;index:idx_token_transfer_token;not null" json:"contractAddress"`
+	TokenID         string    `gorm:"size:78;index:idx_token_transfer_token;not null" json:"tokenId"`
+	FromAddress     string    `gorm:"size:42;not null" json:"fromAddress"`
+	ToAddress       string    `gorm:"size:42;not null" json:"toAddress"`
+	PriceWei        string    `gorm:"size:78" json:"priceWei,omitempty"`
+	OrderID         *string   `gorm:"type:uuid" json:"orderId,omitempty"`
+	BlockNumber     uint64    `gorm:"not null" json:"blockNumber"`
+	BlockTime       time.Time `json:"blockTime"`
+	TxHash          string    `gorm:"size:66;not null" json:"txHash"`
+	LogIndex        uint      `gorm:"not null" json:"logIndex"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+// CachedReceipt is the receipt of a mined transaction with enough
+// confirmations. The indexer caches the receipts of the transactions behind
+// the logs it processes and drops them when a reorg removes their block.
+// Logs holds the receipt's logs as JSON.
+type CachedReceipt struct {
+	ID                string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	ChainID           int64     `gorm:"uniqueIndex:idx_cached_receipt;index:idx_cached_receipt_block;not null" json:"chainId"`
+	TxHash            string    `gorm:"size:66;uniqueIndex:idx_cached_receipt;not null" json:"txHash"`
+	BlockNumber       uint64    `gorm:"index:idx_cached_receipt_block;not null" json:"blockNumber"`
+	BlockHash         string    `gorm:"size:66;not null" json:"blockHash"`
+	FromAddress       string    `gorm:"size:42;not null" json:"fromAddress"`
+	ToAddress         string    `gorm:"size:42" json:"toAddress,omitempty"`
+	Status            uint64    `gorm:"not null" json:"status"`
+	GasUsed           uint64    `gorm:"not null" json:"gasUsed"`
+	EffectiveGasPrice string    `gorm:"size:78" json:"effectiveGasPrice"`
+	Logs              string    `gorm:"type:text" json:"-"`
+	RevertReason      string    `gorm:"type:text" json:"revertReason,omitempty"`
+	CreatedAt         time.Time `json:"createdAt"`
+}
+
+// Managed transaction statuses
+const (
+	TxStatusPending   = "pending"
+	TxStatusConfirmed = "confirmed"
+	TxStatusFailed    = "failed"
+)
+
+// SenderNonce is the next nonce the transaction manager will use for an
+// address. The row is locked while a nonce is allocated.
+type SenderNonce struct {
+	ID        string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	ChainID   int64     `gorm:"uniqueIndex:idx_sender_nonce;not null" json:"chainId"`
+	Address   string    `gorm:"size:42;uniqueIndex:idx_sender_nonce;not null" json:"address"`
+	NextNonce uint64    `gorm:"not null" json:"nextNonce"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ManagedTransaction is a transaction the backend signs and sends itself.
+// It owns one nonce of its sender; fee bumps are further attempts at the
+// same nonce, so at most one of them can be mined. Reference is the
+// caller's idempotency key.
+type ManagedTransaction struct {
+	ID          string               `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	ChainID     int64                `gorm:"uniqueIndex:idx_managed_tx_nonce;not null" json:"chainId"`
+	FromAddress string               `gorm:"size:42;uniqueIndex:idx_managed_tx_nonce;not null" json:"fromAddress"`
+	Nonce       uint64               `gorm:"uniqueIndex:idx_managed_tx_nonce;not null" json:"nonce"`
+	Reference   *string              `gorm:"size:100;uniqueIndex" json:"reference,omitempty"`
+	ToAddress   string               `gorm:"size:42" json:"toAddress,omitempty"`
+	ValueWei    string               `gorm:"size:78;not null;default:'0'" json:"valueWei"`
+	Data        string               `gorm:"type:text" json:"data,omitempty"`
+	GasLimit    uint64               `gorm:"not null" json:"gasLimit"`
+	Status      string               `gorm:"size:50;not null;default:'pending';index" json:"status"`
+	TxHash      string               `gorm:"size:66;index" json:"txHash"`
+	BlockNumber uint64               `json:"blockNumber,omitempty"`
+	GasUsed     uint64               `json:"gasUsed,omitempty"`
+	Error       string               `gorm:"type:text" json:"error,omitempty"`
+	Attempts    []TransactionAttempt `gorm:"foreignKey:TransactionID" json:"attempts,omitempty"`
+	ConfirmedAt *time.Time           `json:"confirmedAt,omitempty"`
+	CreatedAt   time.Time            `json:"createdAt"`
+	UpdatedAt   time.Time            `json:"updatedAt"`
+}
+
+// TransactionAttempt is one signed version of a managed transaction. The
+// signed bytes are stored before broadcast so the exact transaction can be
+// sent again after a failure or restart. A cancellation is an attempt that
+// transfers nothing to the sender, to use up the nonce of a transaction
+// nodes rejected.
+type TransactionAttempt struct {
+	ID                   string     `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	TransactionID        string     `gorm:"type:uuid;index;not null" json:"transactionId"`
+	TxHash               string     `gorm:"size:66;uniqueIndex;not null" json:"txHash"`
+	GasPrice             string     `gorm:"size:78" json:"gasPrice,omitempty"`
+	MaxFeePerGas         string     `gorm:"size:78" json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas string     `gorm:"size:78" json:"maxPriorityFeePerGas,omitempty"`
+	RawTx                string     `gorm:"type:text;not null" json:"-"`
+	Cancel               bool       `gorm:"not null;default:false" json:"cancel,omitempty"`
+	SentAt               *time.Time `json:"sentAt,omitempty"`
+	CreatedAt            time.Time  `json:"createdAt"`
+}
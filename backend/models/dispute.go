@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+)
+
+// Dispute statuses
+const (
+	DisputeStatusOpened            = "opened"
+	DisputeStatusEvidenceRequested = "evidence_requested"
+	DisputeStatusUnderReview       = "under_review"
+	DisputeStatusResolved          = "resolved"
+)
+
+// Dispute resolutions
+const (
+	DisputeResolutionRefund        = "refund"
+	DisputeResolutionPartialRefund = "partial_refund"
+	DisputeResolutionRejected      = "rejected"
+)
+
+// Dispute represents a buyer's claim against an order
+type Dispute struct {
+	ID              string               `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	OrderID         string               `gorm:"type:uuid;index;not null" json:"orderId"`
+	Order           Order                `gorm:"foreignKey:OrderID" json:"order"`
+	OpenedByID      string               `gorm:"type:uuid;not null" json:"openedById"`
+	Reason          string               `gorm:"size:50;not null" json:"reason"`
+	Description     string               `gorm:"type:text;not null" json:"description"`
+	Status          string               `gorm:"size:50;not null;default:'opened';index" json:"status"`
+	Resolution      string               `gorm:"size:50" json:"resolution,omitempty"`
+	RefundAmountWei string               `gorm:"size:78" json:"refundAmountWei,omitempty"`
+	RefundTxID      *string              `gorm:"type:uuid" json:"refundTxId,omitempty"`
+	RefundTx        *OperatorTransaction `gorm:"foreignKey:RefundTxID" json:"refundTx,omitempty"`
+	ResolvedByID    *string              `gorm:"type:uuid" json:"resolvedById,omitempty"`
+	ResolvedAt      *time.Time           `json:"resolvedAt,omitempty"`
+	Evidence        []DisputeEvidence    `gorm:"foreignKey:DisputeID" json:"evidence,omitempty"`
+	Actions         []DisputeAction      `gorm:"foreignKey:DisputeID" json:"actions,omitempty"`
+	CreatedAt       time.Time            `json:"createdAt"`
+	UpdatedAt       time.Time            `json:"updatedAt"`
+}
+
+// DisputeEvidence is a file or note submitted by a party to a dispute
+type DisputeEvidence struct {
+	ID            string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	DisputeID     string    `gorm:"type:uuid;index;not null" json:"disputeId"`
+	SubmittedByID string    `gorm:"type:uuid;not null" json:"submittedById"`
+	URL           string    `gorm:"size:255" json:"url,omitempty"`
+	Note          string    `gorm:"type:text" json:"note,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// DisputeAction records every status change and staff decision on a dispute
+type DisputeAction struct {
+	ID         string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	DisputeID  string    `gorm:"type:uuid;index;not null" json:"disputeId"`
+	ActorID    string    `gorm:"type:uuid;not null" json:"actorId"`
+	Action     string    `gorm:"size:50;not null" json:"action"`
+	FromStatus string    `gorm:"size:50" json:"fromStatus,omitempty"`
+	ToStatus   string    `gorm:"size:50" json:"toStatus,omitempty"`
+	Note       string    `gorm:"type:text" json:"note,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
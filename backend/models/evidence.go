@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+)
+
+// Evidence item kinds
+const (
+	EvidenceKindPhoto       = "photo"
+	EvidenceKindMeasurement = "measurement"
+	EvidenceKindNote        = "note"
+)
+
+// Measurement is a reviewer's measurement of a product, such as a sole length
+type Measurement struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+// EvidenceBundle is the evidence an authentication was based on. MerkleRoot
+// commits to the SHA-256 of every item in order, so any later change to an
+// item is detectable.
+type EvidenceBundle struct {
+	ID               string         `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	AuthenticationID string         `gorm:"type:uuid;uniqueIndex;not null" json:"authenticationId"`
+	ProductID        string         `gorm:"type:uuid;index;not null" json:"productId"`
+	MerkleRoot       string         `gorm:"size:64;not null" json:"merkleRoot"`
+	Items            []EvidenceItem `gorm:"foreignKey:BundleID" json:"items"`
+	CreatedAt        time.Time      `json:"createdAt"`
+}
+
+// EvidenceItem is one photo, measurement or note in a bundle. Photos are
+// stored as uploads; measurements and notes are kept in Content.
+type EvidenceItem struct {
+	ID            string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	BundleID      string    `gorm:"type:uuid;index;not null" json:"bundleId"`
+	Position      int       `gorm:"not null" json:"position"`
+	Kind          string    `gorm:"size:20;not null" json:"kind"`
+	Name          string    `gorm:"size:255;not null" json:"name"`
+	URL           string    `gorm:"size:255" json:"url,omitempty"`
+	Content       string    `gorm:"type:text" json:"content,omitempty"`
+	ContentType   string    `gorm:"size:100" json:"contentType"`
+	Size          int64     `gorm:"not null" json:"size"`
+	SHA256        string    `gorm:"size:64;not null" json:"sha256"`
+	SubmittedByID *string   `gorm:"type:uuid" json:"submittedById,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+)
+
+// Shipment statuses
+const (
+	ShipmentStatusShipped   = "shipped"
+	ShipmentStatusInTransit = "in_transit"
+	ShipmentStatusDelivered = "delivered"
+)
+
+// Shipment event sources
+const (
+	ShipmentSourceSeller  = "seller"
+	ShipmentSourceCarrier = "carrier"
+	ShipmentSourceBuyer   = "buyer"
+)
+
+// Shipment represents the physical delivery of an order
+type Shipment struct {
+	ID             string          `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	OrderID        string          `gorm:"type:uuid;index;not null" json:"orderId"`
+	Carrier        string          `gorm:"size:50;not null" json:"carrier"`
+	TrackingNumber string          `gorm:"size:100;not null" json:"trackingNumber"`
+	Status         string          `gorm:"size:50;not null;default:'shipped'" json:"status"`
+	Events         []ShipmentEvent `gorm:"foreignKey:ShipmentID" json:"events,omitempty"`
+	ShippedAt      time.Time       `json:"shippedAt"`
+	DeliveredAt    *time.Time      `json:"deliveredAt,omitempty"`
+	ConfirmedAt    *time.Time      `json:"confirmedAt,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	UpdatedAt      time.Time       `json:"updatedAt"`
+}
+
+// ShipmentEvent is a status update in a shipment's history
+type ShipmentEvent struct {
+	ID          string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	ShipmentID  string    `gorm:"type:uuid;index;not null" json:"shipmentId"`
+	Status      string    `gorm:"size:50;not null" json:"status"`
+	Location    string    `gorm:"size:255" json:"location,omitempty"`
+	Description string    `gorm:"type:text" json:"description,omitempty"`
+	Source      string    `gorm:"size:50;not null" json:"source"`
+	OccurredAt  time.Time `gorm:"not null" json:"occurredAt"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
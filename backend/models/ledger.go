@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+)
+
+// Ledger journal types
+const (
+	JournalTypeSale = "sale"
+)
+
+// Ledger accounts. Sales are debited with what the buyer paid and credited
+// to the seller's proceeds and the platform's fee revenue.
+const (
+	AccountSales          = "sales"
+	AccountSellerProceeds = "seller_proceeds"
+	AccountPlatformFees   = "platform_fees"
+)
+
+// Ledger entry directions
+const (
+	EntryDebit  = "debit"
+	EntryCredit = "credit"
+)
+
+// LedgerJournal groups the balanced entries posted for one business event
+type LedgerJournal struct {
+	ID              string        `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	Type            string        `gorm:"size:50;not null;uniqueIndex:idx_ledger_journal_order" json:"type"`
+	OrderID         string        `gorm:"type:uuid;not null;uniqueIndex:idx_ledger_journal_order" json:"orderId"`
+	SellerID        string        `gorm:"type:uuid;not null;index" json:"sellerId"`
+	TxHash          string        `gorm:"size:66" json:"txHash"`
+	GrossWei        string        `gorm:"type:numeric(78,0);not null" json:"grossWei"`
+	FeeWei          string        `gorm:"type:numeric(78,0);not null" json:"feeWei"`
+	NetWei          string        `gorm:"type:numeric(78,0);not null" json:"netWei"`
+	FeeRatePerMille int64         `gorm:"not null" json:"feeRatePerMille"`
+	Entries         []LedgerEntry `gorm:"foreignKey:JournalID" json:"entries,omitempty"`
+	PostedAt        time.Time     `gorm:"not null;index" json:"postedAt"`
+	CreatedAt       time.Time     `json:"createdAt"`
+}
+
+// LedgerEntry is one side of a double-entry posting
+type LedgerEntry struct {
+	ID        string         `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	JournalID string         `gorm:"type:uuid;index;not null" json:"journalId"`
+	Journal   *LedgerJournal `gorm:"foreignKey:JournalID" json:"journal,omitempty"`
+	Account   string         `gorm:"size:50;not null;index:idx_ledger_entry_account" json:"account"`
+	OwnerID   *string        `gorm:"type:uuid;index:idx_ledger_entry_account" json:"ownerId,omitempty"`
+	Direction string         `gorm:"size:10;not null" json:"direction"`
+	AmountWei string         `gorm:"type:numeric(78,0);not null" json:"amountWei"`
+	PostedAt  time.Time      `gorm:"not null;index" json:"postedAt"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
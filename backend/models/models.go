@@ -6,27 +6,57 @@ import (
 	"gorm.io/gorm"
 )
 
+// User roles
+const (
+	RoleUser          = "user"
+	RoleAdmin         = "admin"
+	RoleAuthenticator = "authenticator"
+)
+
 // User represents a user in the system
 type User struct {
 	ID            string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
 	WalletAddress string    `gorm:"uniqueIndex;not null" json:"walletAddress"`
 	Name          string    `gorm:"size:255" json:"name"`
 	Avatar        string    `gorm:"size:255" json:"avatar"`
+	Role          string    `gorm:"size:50;not null;default:'user'" json:"role"`
 	CreatedAt     time.Time `json:"createdAt"`
 	UpdatedAt     time.Time `json:"updatedAt"`
 	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
+// Product moderation statuses
+const (
+	ModerationStatusApproved      = "approved"
+	ModerationStatusPendingReview = "pending_review"
+	ModerationStatusRejected      = "rejected"
+)
+
+// Product sale statuses
+const (
+	ProductStatusActive = "active"
+	ProductStatusSold   = "sold"
+)
+
 // Product represents a product listing
 type Product struct {
 	ID          string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
 	Name        string    `gorm:"size:255;not null" json:"name"`
 	Description string    `gorm:"type:text;not null" json:"description"`
 	Price       float64   `gorm:"type:decimal(10,2);not null" json:"price"`
+	PriceWei    string    `gorm:"size:78" json:"priceWei,omitempty"`
 	Category    string    `gorm:"size:50;not null" json:"category"`
 	Condition   string    `gorm:"size:50;not null" json:"condition"`
 	SellerID    string    `gorm:"type:uuid;not null" json:"sellerId"`
 	Seller      User      `gorm:"foreignKey:SellerID" json:"seller"`
+	Images      []ProductImage `gorm:"foreignKey:ProductID" json:"images,omitempty"`
+	ModerationStatus string `gorm:"size:50;not null;default:'approved'" json:"moderationStatus"`
+	TokenID     *string   `gorm:"size:78;uniqueIndex:idx_products_token" json:"tokenId"`
+	ChainID     int64     `gorm:"uniqueIndex:idx_products_token" json:"chainId,omitempty"`
+	ContractAddress string `gorm:"size:42;uniqueIndex:idx_products_token" json:"contractAddress,omitempty"`
+	DeploymentID *string  `gorm:"type:uuid;index" json:"deploymentId,omitempty"`
+	OwnerAddress string   `gorm:"size:42;index" json:"ownerAddress,omitempty"`
+	Status      string    `gorm:"size:50;not null;default:'active';index" json:"status"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
@@ -42,33 +72,100 @@ type ProductImage struct {
 	UpdatedAt time.Time `json:"updatedAt"`
 }
 
+// Order statuses
+const (
+	OrderStatusPending   = "pending"
+	OrderStatusCompleted = "completed"
+	OrderStatusFailed    = "failed"
+	OrderStatusExpired   = "expired"
+)
+
 // Order represents a product purchase order
 type Order struct {
 	ID          string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
-	ProductID   string    `gorm:"type:uuid;not null" json:"productId"`
+	ProductID   string    `gorm:"type:uuid;not null;index" json:"productId"`
 	Product     Product   `gorm:"foreignKey:ProductID" json:"product"`
-	BuyerID     string    `gorm:"type:uuid;not null" json:"buyerId"`
+	BuyerID     string    `gorm:"type:uuid;not null;index" json:"buyerId"`
 	Buyer       User      `gorm:"foreignKey:BuyerID" json:"buyer"`
+	SellerID    string    `gorm:"type:uuid;not null;index" json:"sellerId"`
+	Seller      User      `gorm:"foreignKey:SellerID" json:"seller"`
 	Price       float64   `gorm:"type:decimal(10,2);not null" json:"price"`
-	Status      string    `gorm:"size:50;not null;default:'pending'" json:"status"`
-	TxHash      string    `gorm:"size:66" json:"txHash"`
-	CompletedAt time.Time `json:"completedAt"`
+	PriceWei    string    `gorm:"size:78" json:"priceWei,omitempty"`
+	TokenID     string    `gorm:"size:78" json:"tokenId"`
+	Status      string    `gorm:"size:50;not null;default:'pending';index" json:"status"`
+	TxHash      string    `gorm:"size:66;uniqueIndex" json:"txHash"`
+	BlockNumber uint64    `json:"blockNumber,omitempty"`
+	FailureReason string  `gorm:"type:text" json:"failureReason,omitempty"`
+	// Contested orders were submitted while the product was held by another
+	// buyer or no longer on sale; the chain decides whether they complete
+	Contested   bool      `gorm:"not null;default:false;index" json:"contested"`
+	ContestedReason string `gorm:"type:text" json:"contestedReason,omitempty"`
+	CompletedAt *time.Time `json:"completedAt"`
+	Shipments   []Shipment `gorm:"foreignKey:OrderID" json:"shipments,omitempty"`
+	ShippingOverdue bool   `gorm:"not null;default:false" json:"shippingOverdue"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
+// Authentication verdicts
+const (
+	AuthVerdictPass         = "pass"
+	AuthVerdictFail         = "fail"
+	AuthVerdictInconclusive = "inconclusive"
+)
+
+// Authentication methods
+const (
+	AuthMethodAutomated = "automated"
+	AuthMethodReview    = "review"
+	AuthMethodOnChain   = "on_chain"
+)
+
+// Authentication statuses. Failed and inconclusive authentications report
+// their verdict as their status.
+const (
+	AuthStatusActive  = "active"
+	AuthStatusExpired = "expired"
+	AuthStatusRevoked = "revoked"
+)
+
 // Authentication represents a product authentication record
 type Authentication struct {
-	ID        string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
-	ProductID string    `gorm:"type:uuid;not null" json:"productId"`
-	Product   Product   `gorm:"foreignKey:ProductID" json:"product"`
-	Result    bool      `gorm:"not null" json:"result"`
-	Score     float64   `gorm:"type:decimal(5,2)" json:"score"`
-	Details   string    `gorm:"type:text" json:"details"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID               string          `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	ProductID        string          `gorm:"type:uuid;not null;index" json:"productId"`
+	Product          Product         `gorm:"foreignKey:ProductID" json:"product"`
+	Result           bool            `gorm:"not null" json:"result"`
+	Verdict          string          `gorm:"size:20;not null;default:'inconclusive'" json:"verdict"`
+	Method           string          `gorm:"size:20;not null;default:'automated'" json:"method"`
+	Score            float64         `gorm:"type:decimal(5,2)" json:"score"`
+	Details          string          `gorm:"type:text" json:"details"`
+	Certificate      *Certificate    `gorm:"foreignKey:AuthenticationID" json:"certificate,omitempty"`
+	Evidence         *EvidenceBundle `gorm:"foreignKey:AuthenticationID" json:"evidence,omitempty"`
+	Status           string          `gorm:"-" json:"status"`
+	ExpiresAt        *time.Time      `gorm:"index" json:"expiresAt,omitempty"`
+	ExpiredAt        *time.Time      `json:"expiredAt,omitempty"`
+	RevokedAt        *time.Time      `json:"revokedAt,omitempty"`
+	RevokedByID      *string         `gorm:"type:uuid" json:"revokedById,omitempty"`
+	RevocationReason string          `gorm:"type:text" json:"revocationReason,omitempty"`
+	DeauthTxID       *string         `gorm:"type:uuid" json:"deauthTxId,omitempty"`
+	CreatedAt        time.Time       `json:"createdAt"`
+	UpdatedAt        time.Time       `json:"updatedAt"`
+	DeletedAt        gorm.DeletedAt  `gorm:"index" json:"-"`
+}
+
+// CurrentStatus reports whether a passing authentication is still in force
+func (a *Authentication) CurrentStatus(now time.Time) string {
+	switch {
+	case a.Verdict != AuthVerdictPass:
+		return a.Verdict
+	case a.RevokedAt != nil:
+		return AuthStatusRevoked
+	case a.ExpiredAt != nil || (a.ExpiresAt != nil && !now.Before(*a.ExpiresAt)):
+		return AuthStatusExpired
+	default:
+		return AuthStatusActive
+	}
 }
 
 // AutoMigrate performs database migrations
@@ -79,5 +176,32 @@ func AutoMigrate(db *gorm.DB) error {
 		&ProductImage{},
 		&Order{},
 		&Authentication{},
+		&ImageHash{},
+		&ImageMatch{},
+		&ListingTransaction{},
+		&Shipment{},
+		&ShipmentEvent{},
+		&OperatorTransaction{},
+		&Dispute{},
+		&DisputeEvidence{},
+		&DisputeAction{},
+		&LedgerJournal{},
+		&LedgerEntry{},
+		&ReconciliationRun{},
+		&ReconciliationDiscrepancy{},
+		&AuthenticationRequest{},
+		&AuthenticationReview{},
+		&Certificate{},
+		&EvidenceBundle{},
+		&EvidenceItem{},
+		&Deployment{},
+		&IndexerCheckpoint{},
+		&IndexedBlock{},
+		&ChainEvent{},
+		&TokenTransfer{},
+		&CachedReceipt{},
+		&SenderNonce{},
+		&ManagedTransaction{},
+		&TransactionAttempt{},
 	)
 } 
\ No newline at end of file
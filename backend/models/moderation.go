@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+)
+
+// Image match statuses
+const (
+	ImageMatchStatusOpen      = "open"
+	ImageMatchStatusDismissed = "dismissed"
+	ImageMatchStatusConfirmed = "confirmed"
+)
+
+// ImageHash stores the perceptual hash of an uploaded image
+type ImageHash struct {
+	ID         string  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	URL        string  `gorm:"size:255;uniqueIndex;not null" json:"url"`
+	Hash       string  `gorm:"size:16;index;not null" json:"hash"`
+	UploaderID *string `gorm:"type:uuid" json:"uploaderId"`
+	// The hash split into 16-bit bands, indexed so near-duplicates can be
+	// looked up without comparing every stored hash
+	Band0     *int      `gorm:"index" json:"-"`
+	Band1     *int      `gorm:"index" json:"-"`
+	Band2     *int      `gorm:"index" json:"-"`
+	Band3     *int      `gorm:"index" json:"-"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ImageMatch records a near-duplicate image found on another seller's listing
+type ImageMatch struct {
+	ID               string     `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	ProductID        string     `gorm:"type:uuid;index;not null" json:"productId"`
+	Product          Product    `gorm:"foreignKey:ProductID" json:"product"`
+	ImageURL         string     `gorm:"size:255;not null" json:"imageUrl"`
+	MatchedProductID string     `gorm:"type:uuid;index;not null" json:"matchedProductId"`
+	MatchedProduct   Product    `gorm:"foreignKey:MatchedProductID" json:"matchedProduct"`
+	MatchedImageURL  string     `gorm:"size:255;not null" json:"matchedImageUrl"`
+	Distance         int        `gorm:"not null" json:"distance"`
+	Status           string     `gorm:"size:50;not null;default:'open'" json:"status"`
+	ResolvedBy       *string    `gorm:"type:uuid" json:"resolvedBy,omitempty"`
+	ResolvedAt       *time.Time `json:"resolvedAt,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	UpdatedAt        time.Time  `json:"updatedAt"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+)
+
+// Operator transaction kinds
+const (
+	OperatorTxKindRefund            = "refund"
+	OperatorTxKindAuthenticate      = "authenticate"
+	OperatorTxKindUpdatePlatformFee = "update_platform_fee"
+	OperatorTxKindWithdraw          = "withdraw"
+)
+
+// Operator transaction statuses
+const (
+	OperatorTxStatusQueued    = "queued"
+	OperatorTxStatusSent      = "sent"
+	OperatorTxStatusConfirmed = "confirmed"
+	OperatorTxStatusFailed    = "failed"
+)
+
+// OperatorTransaction is a transaction the platform sends from its operator
+// wallet, queued so it can be sent and followed outside the request.
+// ChainID and ContractAddress pick the deployment it goes to; when they are
+// unset it goes to the active deployment. A queued entry that could not be
+// submitted for a reason that may pass, such as a provider outage, keeps its
+// status and is tried again at NextAttemptAt, with the reason in Error.
+type OperatorTransaction struct {
+	ID              string     `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	Kind            string     `gorm:"size:50;not null;index" json:"kind"`
+	Reference       string     `gorm:"size:100;index" json:"reference"`
+	ChainID         int64      `gorm:"not null;default:0" json:"chainId,omitempty"`
+	ContractAddress string     `gorm:"size:42" json:"contractAddress,omitempty"`
+	ToAddress       string     `gorm:"size:42" json:"toAddress,omitempty"`
+	ValueWei        string     `gorm:"size:78" json:"valueWei,omitempty"`
+	TokenID         string     `gorm:"size:78" json:"tokenId,omitempty"`
+	Authenticated   bool       `gorm:"not null;default:false" json:"authenticated,omitempty"`
+	PlatformFee     int64      `gorm:"not null;default:0" json:"platformFee,omitempty"`
+	Status          string     `gorm:"size:50;not null;default:'queued';index" json:"status"`
+	TransactionID   *string    `gorm:"type:uuid;index" json:"transactionId,omitempty"`
+	TxHash          string     `gorm:"size:66" json:"txHash,omitempty"`
+	Error           string     `gorm:"type:text" json:"error,omitempty"`
+	Attempts        int        `gorm:"not null;default:0" json:"attempts,omitempty"`
+	NextAttemptAt   *time.Time `json:"nextAttemptAt,omitempty"`
+	SentAt          *time.Time `json:"sentAt,omitempty"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
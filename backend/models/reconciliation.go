@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+)
+
+// Reconciliation run statuses
+const (
+	ReconciliationStatusRunning   = "running"
+	ReconciliationStatusCompleted = "completed"
+	ReconciliationStatusFailed    = "failed"
+)
+
+// Discrepancy kinds found by reconciliation
+const (
+	// A ProductSold event has no order in the database
+	DiscrepancyMissingOrder = "missing_order"
+	// A ProductSold event's order is not marked completed
+	DiscrepancyOrderNotCompleted = "order_not_completed"
+	// A token sold on chain belongs to a product still marked active
+	DiscrepancyProductNotSold = "product_not_sold"
+	// A ProductSold event refers to a token no product is linked to
+	DiscrepancyUnknownToken = "unknown_token"
+	// getUserPurchases lists a token the user has no completed order for
+	DiscrepancyPurchaseNotRecorded = "purchase_not_recorded"
+	// A completed order's token is missing from the buyer's getUserPurchases
+	DiscrepancyOrderNotOnChain = "order_not_on_chain"
+	// getUserProducts lists a token no product of the seller is linked to
+	DiscrepancyListingNotRecorded = "listing_not_recorded"
+	// A product's token is missing from the seller's getUserProducts
+	DiscrepancyProductNotOnChain = "product_not_on_chain"
+)
+
+// ReconciliationRun is one comparison of on-chain state against the database
+type ReconciliationRun struct {
+	ID            string                      `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	FromBlock     uint64                      `json:"fromBlock"`
+	ToBlock       uint64                      `json:"toBlock"`
+	Repair        bool                        `gorm:"not null;default:false" json:"repair"`
+	Status        string                      `gorm:"size:50;not null;index" json:"status"`
+	TriggeredByID *string                     `gorm:"type:uuid" json:"triggeredById,omitempty"`
+	Error         string                      `gorm:"type:text" json:"error,omitempty"`
+	Found         int                         `json:"found"`
+	Repaired      int                         `json:"repaired"`
+	Discrepancies []ReconciliationDiscrepancy `gorm:"foreignKey:RunID" json:"discrepancies,omitempty"`
+	StartedAt     time.Time                   `json:"startedAt"`
+	FinishedAt    *time.Time                  `json:"finishedAt,omitempty"`
+	CreatedAt     time.Time                   `json:"createdAt"`
+}
+
+// ReconciliationDiscrepancy is a difference between the chain and the database
+type ReconciliationDiscrepancy struct {
+	ID        string    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RunID     string    `gorm:"type:uuid;index;not null" json:"runId"`
+	Kind      string    `gorm:"size:50;not null;index" json:"kind"`
+	TokenID   string    `gorm:"size:78" json:"tokenId,omitempty"`
+	TxHash    string    `gorm:"size:66" json:"txHash,omitempty"`
+	Address   string    `gorm:"size:42" json:"address,omitempty"`
+	ProductID *string   `gorm:"type:uuid" json:"productId,omitempty"`
+	OrderID   *string   `gorm:"type:uuid" json:"orderId,omitempty"`
+	Detail    string    `gorm:"type:text" json:"detail"`
+	Repaired  bool      `gorm:"not null;default:false" json:"repaired"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+)
+
+// Authentication request statuses
+const (
+	AuthRequestStatusQueued                = "queued"
+	AuthRequestStatusInReview              = "in_review"
+	AuthRequestStatusAwaitingSecondOpinion = "awaiting_second_opinion"
+	AuthRequestStatusCompleted             = "completed"
+	AuthRequestStatusCancelled             = "cancelled"
+)
+
+// AuthenticationRequest is a seller's request for expert review of a product
+type AuthenticationRequest struct {
+	ID                    string                 `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	ProductID             string                 `gorm:"type:uuid;index;not null" json:"productId"`
+	Product               Product                `gorm:"foreignKey:ProductID" json:"product"`
+	SellerID              string                 `gorm:"type:uuid;index;not null" json:"sellerId"`
+	Category              string                 `gorm:"size:50;not null;index" json:"category"`
+	Status                string                 `gorm:"size:50;not null;default:'queued';index" json:"status"`
+	RequiresSecondOpinion bool                   `gorm:"not null;default:false" json:"requiresSecondOpinion"`
+	AssigneeID            *string                `gorm:"type:uuid;index" json:"assigneeId,omitempty"`
+	ClaimedAt             *time.Time             `json:"claimedAt,omitempty"`
+	DueAt                 time.Time              `gorm:"not null;index" json:"dueAt"`
+	Overdue               bool                   `gorm:"not null;default:false;index" json:"overdue"`
+	Verdict               string                 `gorm:"size:20" json:"verdict,omitempty"`
+	Score                 float64                `gorm:"type:decimal(5,2)" json:"score,omitempty"`
+	AuthenticationID      *string                `gorm:"type:uuid" json:"authenticationId,omitempty"`
+	OnChainTxID           *string                `gorm:"type:uuid" json:"onChainTxId,omitempty"`
+	OnChainTx             *OperatorTransaction   `gorm:"foreignKey:OnChainTxID" json:"onChainTx,omitempty"`
+	Reviews               []AuthenticationReview `gorm:"foreignKey:RequestID" json:"reviews,omitempty"`
+	CompletedAt           *time.Time             `json:"completedAt,omitempty"`
+	CreatedAt             time.Time              `json:"createdAt"`
+	UpdatedAt             time.Time              `json:"updatedAt"`
+}
+
+// AuthenticationReview is one reviewer's findings on an authentication request
+type AuthenticationReview struct {
+	ID           string        `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	RequestID    string        `gorm:"type:uuid;index;not null" json:"requestId"`
+	ReviewerID   string        `gorm:"type:uuid;not null" json:"reviewerId"`
+	Findings     string        `gorm:"type:text;not null" json:"findings"`
+	Score        float64       `gorm:"type:decimal(5,2);not null" json:"score"`
+	Verdict      string        `gorm:"size:20;not null" json:"verdict"`
+	Photos       []string      `gorm:"type:text;serializer:json" json:"photos,omitempty"`
+	Measurements []Measurement `gorm:"type:text;serializer:json" json:"measurements,omitempty"`
+	CreatedAt    time.Time     `json:"createdAt"`
+}
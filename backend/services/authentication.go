@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/yourusername/revibe/backend/config"
+	"github.com/yourusername/revibe/backend/models"
+	"gorm.io/gorm"
+)
+
+var ErrNotProductSeller = errors.New("only the seller can request authentication")
+
+// AuthenticationDetails is stored as JSON in models.Authentication.Details
+type AuthenticationDetails struct {
+	Verdict        string                `json:"verdict"`
+	Authenticators []AuthenticatorResult `json:"authenticators"`
+}
+
+// AuthenticationService runs the registered authenticators for a product and
+// records the combined verdict
+type AuthenticationService struct {
+	db                 *gorm.DB
+	registry           *AuthenticatorRegistry
+	uploadService      *UploadService
+	certificateService *CertificateService
+	evidenceService    *EvidenceService
+	expiryService      *AuthExpiryService
+	passScore          float64
+	failScore          float64
+	timeout            time.Duration
+}
+
+// NewAuthenticationService creates a new AuthenticationService instance
+func NewAuthenticationService(db *gorm.DB, registry *AuthenticatorRegistry, uploadService *UploadService, certificateService *CertificateService, evidenceService *EvidenceService, expiryService *AuthExpiryService) *AuthenticationService {
+	return &AuthenticationService{
+		db:                 db,
+		registry:           registry,
+		uploadService:      uploadService,
+		certificateService: certificateService,
+		evidenceService:    evidenceService,
+		expiryService:      expiryService,
+		passScore:          config.AppConfig.AuthPassScore,
+		failScore:          config.AppConfig.AuthFailScore,
+		timeout:            config.AppConfig.AuthenticatorTimeout,
+	}
+}
+
+// NewDefaultAuthenticatorRegistry registers the built-in authenticators and
+// any remote authenticators configured in AUTHENTICATOR_ENDPOINTS
+func NewDefaultAuthenticatorRegistry(db *gorm.DB) (*AuthenticatorRegistry, error) {
+	registry := NewAuthenticatorRegistry()
+	registry.Register(AnyCategory, NewImageEvidenceAuthenticator(db), 1)
+
+	endpoints, err := ParseAuthenticatorEndpoints(config.AppConfig.AuthenticatorEndpoints)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{Timeout: config.AppConfig.AuthenticatorTimeout}
+	for category, urls := range endpoints {
+		for _, endpoint := range urls {
+			// Remote models outweigh the image checks
+			registry.Register(category, NewHTTPAuthenticator(endpoint, client), 3)
+		}
+	}
+
+	return registry, nil
+}
+
+// Authenticate runs every authenticator registered for the product's
+// category on behalf of its seller and stores the combined result with an
+// evidence bundle of the product's photos and each authenticator's result,
+// and a certificate if the product passed
+func (s *AuthenticationService) Authenticate(ctx context.Context, productID, requesterID string) (*models.Authentication, error) {
+	var product models.Product
+	if err := s.db.Preload("Images").First(&product, "id = ?", productID).Error; err != nil {
+		return nil, err
+	}
+	if product.SellerID != requesterID {
+		return nil, ErrNotProductSeller
+	}
+
+	// Lapsed authentications are renewed by review so the on-chain flag is restored
+	required, err := ReauthenticationRequired(s.db, product.ID)
+	if err != nil {
+		return nil, err
+	}
+	if required {
+		return nil, ErrReauthenticationNeedsReview
+	}
+
+	input := &AuthenticationInput{
+		Product: &product,
+		Attributes: map[string]string{
+			"category":  product.Category,
+			"condition": product.Condition,
+		},
+	}
+	for _, image := range product.Images {
+		input.Images = append(input.Images, s.uploadService.GetFileURL(image.URL))
+	}
+
+	results := s.run(ctx, s.registry.ForCategory(product.Category), input)
+	score, verdict := CombineResults(results, s.passScore, s.failScore)
+
+	details, err := json.Marshal(AuthenticationDetails{
+		Verdict:        verdict,
+		Authenticators: results,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode authentication details: %v", err)
+	}
+
+	evidence := ProductPhotoEvidence(&product)
+	for _, result := range results {
+		content, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode authenticator result: %v", err)
+		}
+		evidence = append(evidence, EvidenceInput{
+			Kind:    models.EvidenceKindNote,
+			Name:    result.Authenticator,
+			Content: string(content),
+		})
+	}
+	bundle, err := s.evidenceService.BuildBundle(product.ID, evidence)
+	if err != nil {
+		return nil, err
+	}
+
+	auth := models.Authentication{
+		ProductID: product.ID,
+		Result:    verdict == models.AuthVerdictPass,
+		Verdict:   verdict,
+		Method:    models.AuthMethodAutomated,
+		Score:     score,
+		Details:   string(details),
+	}
+	s.expiryService.SetExpiry(&auth, product.Category, time.Now())
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Product").Create(&auth).Error; err != nil {
+			return fmt.Errorf("failed to save authentication: %v", err)
+		}
+		if err := s.evidenceService.SaveBundle(tx, &auth, bundle); err != nil {
+			return err
+		}
+		return s.certificateService.RecordAuthentication(tx, &auth)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	auth.Status = auth.CurrentStatus(time.Now())
+	return &auth, nil
+}
+
+// run calls the authenticators concurrently, each with its own timeout.
+// Failures are recorded on the result rather than aborting the others.
+func (s *AuthenticationService) run(ctx context.Context, authenticators []registeredAuthenticator, input *AuthenticationInput) []AuthenticatorResult {
+	results := make([]AuthenticatorResult, len(authenticators))
+
+	var wg sync.WaitGroup
+	for i, entry := range authenticators {
+		wg.Add(1)
+		go func(i int, entry registeredAuthenticator) {
+			defer wg.Done()
+
+			runCtx, cancel := context.WithTimeout(ctx, s.timeout)
+			defer cancel()
+
+			result, err := entry.authenticator.Authenticate(runCtx, input)
+			if err != nil {
+				result = &AuthenticatorResult{
+					Verdict: models.AuthVerdictInconclusive,
+					Error:   err.Error(),
+				}
+			}
+			result.Authenticator = entry.authenticator.Name()
+			result.Weight = entry.weight
+			results[i] = *result
+		}(i, entry)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// GetAuthentications returns a product's authentication history, newest
+// first, with the current status of each
+func (s *AuthenticationService) GetAuthentications(productID string) ([]models.Authentication, error) {
+	var auths []models.Authentication
+	if err := s.db.Preload("Certificate").
+		Preload("Evidence").
+		Where("product_id = ?", productID).
+		Order("created_at desc").
+		Find(&auths).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch authentications: %v", err)
+	}
+
+	return WithStatus(auths), nil
+}
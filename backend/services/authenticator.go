@@ -0,0 +1,284 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/yourusername/revibe/backend/models"
+	"gorm.io/gorm"
+)
+
+// AnyCategory registers an authenticator for every product category
+const AnyCategory = "*"
+
+// AuthenticationInput is what an authenticator inspects
+type AuthenticationInput struct {
+	Product    *models.Product   `json:"-"`
+	Images     []string          `json:"images"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+// AuthEvidence is a structured finding supporting an authenticator's score
+type AuthEvidence struct {
+	Kind        string                 `json:"kind"`
+	Description string                 `json:"description"`
+	Data        map[string]interface{} `json:"data,omitempty"`
+}
+
+// AuthenticatorResult is one authenticator's assessment. Score is 0-100.
+type AuthenticatorResult struct {
+	Authenticator string         `json:"authenticator"`
+	Weight        float64        `json:"weight"`
+	Score         float64        `json:"score"`
+	Verdict       string         `json:"verdict"`
+	Evidence      []AuthEvidence `json:"evidence,omitempty"`
+	Error         string         `json:"error,omitempty"`
+}
+
+// Authenticator assesses whether a product is genuine. Implementations
+// return models.AuthVerdictInconclusive when they cannot decide on their own.
+type Authenticator interface {
+	Name() string
+	Authenticate(ctx context.Context, input *AuthenticationInput) (*AuthenticatorResult, error)
+}
+
+type registeredAuthenticator struct {
+	authenticator Authenticator
+	weight        float64
+}
+
+// AuthenticatorRegistry maps product categories to the authenticators that run for them
+type AuthenticatorRegistry struct {
+	mu      sync.RWMutex
+	entries map[string][]registeredAuthenticator
+}
+
+// NewAuthenticatorRegistry creates an empty AuthenticatorRegistry
+func NewAuthenticatorRegistry() *AuthenticatorRegistry {
+	return &AuthenticatorRegistry{
+		entries: make(map[string][]registeredAuthenticator),
+	}
+}
+
+// Register adds an authenticator for a category, or for every category with
+// AnyCategory. weight sets its share of the combined score.
+func (r *AuthenticatorRegistry) Register(category string, authenticator Authenticator, weight float64) {
+	if weight <= 0 {
+		weight = 1
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := strings.ToLower(category)
+	r.entries[key] = append(r.entries[key], registeredAuthenticator{authenticator: authenticator, weight: weight})
+}
+
+// ForCategory returns the authenticators registered for every category
+// followed by those for the given category
+func (r *AuthenticatorRegistry) ForCategory(category string) []registeredAuthenticator {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []registeredAuthenticator
+	result = append(result, r.entries[AnyCategory]...)
+	if key := strings.ToLower(category); key != AnyCategory {
+		result = append(result, r.entries[key]...)
+	}
+	return result
+}
+
+// CombineResults merges authenticator results into a final score and
+// verdict. The score is the weighted mean of results without errors. Any
+// fail verdict or a score below failScore fails the product; passing needs a
+// score of at least passScore and at least one authenticator that passed it.
+// Everything else is inconclusive.
+func CombineResults(results []AuthenticatorResult, passScore, failScore float64) (float64, string) {
+	var total, weights float64
+	passed := false
+	for _, result := range results {
+		if result.Error != "" {
+			continue
+		}
+		if result.Verdict == models.AuthVerdictFail {
+			return result.Score, models.AuthVerdictFail
+		}
+		if result.Verdict == models.AuthVerdictPass {
+			passed = true
+		}
+		total += result.Score * result.Weight
+		weights += result.Weight
+	}
+	if weights == 0 {
+		return 0, models.AuthVerdictInconclusive
+	}
+
+	score := total / weights
+	switch {
+	case score < failScore:
+		return score, models.AuthVerdictFail
+	case score >= passScore && passed:
+		return score, models.AuthVerdictPass
+	default:
+		return score, models.AuthVerdictInconclusive
+	}
+}
+
+// ImageEvidenceAuthenticator scores a listing's photos. It cannot prove a
+// product genuine, but photos reused from other listings are a strong
+// counterfeit signal and too few photos make remote checks unreliable.
+type ImageEvidenceAuthenticator struct {
+	db        *gorm.DB
+	minImages int
+}
+
+// NewImageEvidenceAuthenticator creates an ImageEvidenceAuthenticator
+func NewImageEvidenceAuthenticator(db *gorm.DB) *ImageEvidenceAuthenticator {
+	return &ImageEvidenceAuthenticator{db: db, minImages: 3}
+}
+
+// Name identifies the authenticator in results
+func (a *ImageEvidenceAuthenticator) Name() string {
+	return "image_evidence"
+}
+
+// Authenticate checks the number of photos and any duplicate photo matches
+func (a *ImageEvidenceAuthenticator) Authenticate(ctx context.Context, input *AuthenticationInput) (*AuthenticatorResult, error) {
+	result := &AuthenticatorResult{Score: 100, Verdict: models.AuthVerdictInconclusive}
+
+	if missing := a.minImages - len(input.Images); missing > 0 {
+		result.Score -= float64(20 * missing)
+		result.Evidence = append(result.Evidence, AuthEvidence{
+			Kind:        "too_few_images",
+			Description: fmt.Sprintf("listing has %d of %d recommended photos", len(input.Images), a.minImages),
+			Data:        map[string]interface{}{"images": len(input.Images)},
+		})
+	}
+
+	var matches []models.ImageMatch
+	if err := a.db.WithContext(ctx).
+		Where("product_id = ? AND status <> ?", input.Product.ID, models.ImageMatchStatusDismissed).
+		Find(&matches).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch image matches: %v", err)
+	}
+	for _, match := range matches {
+		result.Evidence = append(result.Evidence, AuthEvidence{
+			Kind:        "duplicate_image",
+			Description: fmt.Sprintf("photo matches a photo of another listing (%s)", match.Status),
+			Data: map[string]interface{}{
+				"imageUrl":         match.ImageURL,
+				"matchedProductId": match.MatchedProductID,
+				"distance":         match.Distance,
+			},
+		})
+		if match.Status == models.ImageMatchStatusConfirmed {
+			result.Score = 0
+			result.Verdict = models.AuthVerdictFail
+		} else {
+			result.Score -= 30
+		}
+	}
+
+	if result.Score < 0 {
+		result.Score = 0
+	}
+	return result, nil
+}
+
+// HTTPAuthenticator delegates to a remote authentication service, such as an
+// image classification model. It posts the product details and photo URLs as
+// JSON and expects {"score": 0-100, "verdict": "...", "evidence": [...]}.
+type HTTPAuthenticator struct {
+	name     string
+	endpoint string
+	client   *http.Client
+}
+
+// NewHTTPAuthenticator creates an HTTPAuthenticator for an endpoint
+func NewHTTPAuthenticator(endpoint string, client *http.Client) *HTTPAuthenticator {
+	name := "remote"
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		name = "remote:" + u.Host
+	}
+	return &HTTPAuthenticator{name: name, endpoint: endpoint, client: client}
+}
+
+// Name identifies the authenticator in results
+func (a *HTTPAuthenticator) Name() string {
+	return a.name
+}
+
+// Authenticate sends the product to the remote service
+func (a *HTTPAuthenticator) Authenticate(ctx context.Context, input *AuthenticationInput) (*AuthenticatorResult, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"productId":   input.Product.ID,
+		"name":        input.Product.Name,
+		"description": input.Product.Description,
+		"category":    input.Product.Category,
+		"condition":   input.Product.Condition,
+		"images":      input.Images,
+		"attributes":  input.Attributes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("authenticator request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("authenticator returned status %d", resp.StatusCode)
+	}
+
+	var result AuthenticatorResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("invalid authenticator response: %v", err)
+	}
+	if result.Score < 0 || result.Score > 100 {
+		return nil, fmt.Errorf("authenticator score out of range: %v", result.Score)
+	}
+	switch result.Verdict {
+	case models.AuthVerdictPass, models.AuthVerdictFail, models.AuthVerdictInconclusive:
+	default:
+		return nil, fmt.Errorf("invalid authenticator verdict: %q", result.Verdict)
+	}
+
+	return &result, nil
+}
+
+// ParseAuthenticatorEndpoints parses a comma-separated list of
+// category=url pairs, where the category may be AnyCategory
+func ParseAuthenticatorEndpoints(spec string) (map[string][]string, error) {
+	endpoints := make(map[string][]string)
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		category, endpoint, ok := strings.Cut(pair, "=")
+		if !ok || category == "" {
+			return nil, fmt.Errorf("invalid authenticator endpoint %q", pair)
+		}
+		u, err := url.Parse(endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid authenticator URL %q", endpoint)
+		}
+		key := strings.ToLower(strings.TrimSpace(category))
+		endpoints[key] = append(endpoints[key], endpoint)
+	}
+	return endpoints, nil
+}
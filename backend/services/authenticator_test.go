@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/revibe/backend/models"
+)
+
+func TestCombineResults(t *testing.T) {
+	t.Run("Weighted Pass", func(t *testing.T) {
+		score, verdict := CombineResults([]AuthenticatorResult{
+			{Score: 60, Weight: 1, Verdict: models.AuthVerdictInconclusive},
+			{Score: 90, Weight: 3, Verdict: models.AuthVerdictPass},
+		}, 80, 40)
+		assert.Equal(t, 82.5, score)
+		assert.Equal(t, models.AuthVerdictPass, verdict)
+	})
+
+	t.Run("High Score Without Pass Is Inconclusive", func(t *testing.T) {
+		_, verdict := CombineResults([]AuthenticatorResult{
+			{Score: 100, Weight: 1, Verdict: models.AuthVerdictInconclusive},
+		}, 80, 40)
+		assert.Equal(t, models.AuthVerdictInconclusive, verdict)
+	})
+
+	t.Run("Any Fail Fails", func(t *testing.T) {
+		score, verdict := CombineResults([]AuthenticatorResult{
+			{Score: 95, Weight: 3, Verdict: models.AuthVerdictPass},
+			{Score: 0, Weight: 1, Verdict: models.AuthVerdictFail},
+		}, 80, 40)
+		assert.Equal(t, 0.0, score)
+		assert.Equal(t, models.AuthVerdictFail, verdict)
+	})
+
+	t.Run("Low Score Fails", func(t *testing.T) {
+		_, verdict := CombineResults([]AuthenticatorResult{
+			{Score: 30, Weight: 1, Verdict: models.AuthVerdictInconclusive},
+		}, 80, 40)
+		assert.Equal(t, models.AuthVerdictFail, verdict)
+	})
+
+	t.Run("Errors Are Ignored", func(t *testing.T) {
+		score, verdict := CombineResults([]AuthenticatorResult{
+			{Weight: 3, Verdict: models.AuthVerdictInconclusive, Error: "timeout"},
+		}, 80, 40)
+		assert.Equal(t, 0.0, score)
+		assert.Equal(t, models.AuthVerdictInconclusive, verdict)
+	})
+}
+
+type stubAuthenticator struct {
+	name string
+}
+
+func (a *stubAuthenticator) Name() string { return a.name }
+
+func (a *stubAuthenticator) Authenticate(ctx context.Context, input *AuthenticationInput) (*AuthenticatorResult, error) {
+	return &AuthenticatorResult{Score: 50, Verdict: models.AuthVerdictInconclusive}, nil
+}
+
+func TestAuthenticatorRegistry(t *testing.T) {
+	registry := NewAuthenticatorRegistry()
+	registry.Register(AnyCategory, &stubAuthenticator{name: "all"}, 1)
+	registry.Register("Sneakers", &stubAuthenticator{name: "sneakers"}, 2)
+
+	entries := registry.ForCategory("sneakers")
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "all", entries[0].authenticator.Name())
+	assert.Equal(t, "sneakers", entries[1].authenticator.Name())
+	assert.Equal(t, 2.0, entries[1].weight)
+
+	assert.Len(t, registry.ForCategory("bags"), 1)
+}
+
+func TestParseAuthenticatorEndpoints(t *testing.T) {
+	endpoints, err := ParseAuthenticatorEndpoints("sneakers=https://a.example/check, *=http://b.example/v1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://a.example/check"}, endpoints["sneakers"])
+	assert.Equal(t, []string{"http://b.example/v1"}, endpoints[AnyCategory])
+
+	endpoints, err = ParseAuthenticatorEndpoints("")
+	assert.NoError(t, err)
+	assert.Empty(t, endpoints)
+
+	_, err = ParseAuthenticatorEndpoints("sneakers")
+	assert.Error(t, err)
+
+	_, err = ParseAuthenticatorEndpoints("sneakers=ftp://a.example")
+	assert.Error(t, err)
+}
+
+func TestHTTPAuthenticator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["category"] == "bags" {
+			w.Write([]byte(`{"score": 150, "verdict": "pass"}`))
+			return
+		}
+		w.Write([]byte(`{"score": 91.5, "verdict": "pass", "evidence": [{"kind": "logo", "description": "stitching matches"}]}`))
+	}))
+	defer server.Close()
+
+	authenticator := NewHTTPAuthenticator(server.URL, server.Client())
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := authenticator.Authenticate(context.Background(), &AuthenticationInput{
+			Product: &models.Product{ID: "1", Category: "sneakers"},
+			Images:  []string{"http://localhost:8080/uploads/products/a.jpg"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 91.5, result.Score)
+		assert.Equal(t, models.AuthVerdictPass, result.Verdict)
+		assert.Len(t, result.Evidence, 1)
+	})
+
+	t.Run("Score Out Of Range", func(t *testing.T) {
+		_, err := authenticator.Authenticate(context.Background(), &AuthenticationInput{
+			Product: &models.Product{ID: "2", Category: "bags"},
+		})
+		assert.Error(t, err)
+	})
+}
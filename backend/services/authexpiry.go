@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourusername/revibe/backend/config"
+	"github.com/yourusername/revibe/backend/models"
+	"github.com/yourusername/revibe/backend/utils"
+	"gorm.io/gorm"
+)
+
+const authExpiryInterval = time.Hour
+
+var (
+	ErrReauthenticationRequired    = errors.New("product authentication has expired or been revoked and must be renewed")
+	ErrReauthenticationNeedsReview = errors.New("expired or revoked authentications must be renewed through expert review")
+	ErrAuthenticationNotActive     = errors.New("authentication is not active")
+)
+
+// ParseCategoryDurations parses a comma-separated list of category=duration
+// pairs, such as "sneakers=4380h,watches=17520h"
+func ParseCategoryDurations(spec string) (map[string]time.Duration, error) {
+	durations := make(map[string]time.Duration)
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		category, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(category) == "" {
+			return nil, fmt.Errorf("invalid category duration %q", pair)
+		}
+		duration, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || duration < 0 {
+			return nil, fmt.Errorf("invalid duration for category %q: %s", category, value)
+		}
+		durations[strings.ToLower(strings.TrimSpace(category))] = duration
+	}
+	return durations, nil
+}
+
+// ReauthenticationRequired reports whether a product's latest authentication
+// passed but has since expired or been revoked
+func ReauthenticationRequired(db *gorm.DB, productID string) (bool, error) {
+	var auth models.Authentication
+	err := db.Where("product_id = ?", productID).Order("created_at desc").First(&auth).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch authentication: %v", err)
+	}
+
+	status := auth.CurrentStatus(time.Now())
+	return status == models.AuthStatusExpired || status == models.AuthStatusRevoked, nil
+}
+
+// AuthExpiryService applies the per-category validity of authentications
+// and clears the on-chain flag of products whose authentication lapses
+type AuthExpiryService struct {
+	db                 *gorm.DB
+	operatorService    *OperatorService
+	certificateService *CertificateService
+	validity           time.Duration
+	validityByCategory map[string]time.Duration
+}
+
+// NewAuthExpiryService creates a new AuthExpiryService instance from
+// AUTH_VALIDITY and AUTH_VALIDITY_BY_CATEGORY
+func NewAuthExpiryService(db *gorm.DB, operatorService *OperatorService, certificateService *CertificateService) (*AuthExpiryService, error) {
+	byCategory, err := ParseCategoryDurations(config.AppConfig.AuthValidityByCategory)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthExpiryService{
+		db:                 db,
+		operatorService:    operatorService,
+		certificateService: certificateService,
+		validity:           config.AppConfig.AuthValidity,
+		validityByCategory: byCategory,
+	}, nil
+}
+
+// ValidityFor returns how long a passing authentication lasts in a
+// category. Zero means it never expires.
+func (s *AuthExpiryService) ValidityFor(category string) time.Duration {
+	if validity, ok := s.validityByCategory[strings.ToLower(category)]; ok {
+		return validity
+	}
+	return s.validity
+}
+
+// SetExpiry sets the expiry of a new passing authentication
+func (s *AuthExpiryService) SetExpiry(auth *models.Authentication, category string, now time.Time) {
+	if auth.Verdict != models.AuthVerdictPass {
+		return
+	}
+	if validity := s.ValidityFor(category); validity > 0 {
+		expiresAt := now.Add(validity)
+		auth.ExpiresAt = &expiresAt
+	}
+}
+
+// WithStatus fills in the current status of authentications
+func WithStatus(auths []models.Authentication) []models.Authentication {
+	now := time.Now()
+	for i := range auths {
+		auths[i].Status = auths[i].CurrentStatus(now)
+	}
+	return auths
+}
+
+// Revoke withdraws a passing authentication on behalf of staff, revoking its
+// certificate and clearing the product's on-chain flag
+func (s *AuthExpiryService) Revoke(authenticationID, staffID, reason string) (*models.Authentication, error) {
+	var auth models.Authentication
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&auth, "id = ?", authenticationID).Error; err != nil {
+			return err
+		}
+		if auth.Verdict != models.AuthVerdictPass || auth.RevokedAt != nil {
+			return ErrAuthenticationNotActive
+		}
+
+		now := time.Now()
+		auth.RevokedAt = &now
+		auth.RevokedByID = &staffID
+		auth.RevocationReason = reason
+		if err := tx.Model(&auth).
+			Select("revoked_at", "revoked_by_id", "revocation_reason").
+			Updates(&auth).Error; err != nil {
+			return fmt.Errorf("failed to revoke authentication: %v", err)
+		}
+
+		if err := s.certificateService.RevokeForAuthentication(tx, auth.ID, "authentication revoked: "+reason); err != nil {
+			return err
+		}
+		return s.deauthorize(tx, &auth)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	auth.Status = auth.CurrentStatus(time.Now())
+	return &auth, nil
+}
+
+// StartExpiryMonitor expires lapsed authentications until ctx is cancelled
+func (s *AuthExpiryService) StartExpiryMonitor(ctx context.Context) {
+	ticker := time.NewTicker(authExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.ExpireDue(); err != nil {
+				utils.LogError(err, map[string]interface{}{
+					"component": "auth_expiry",
+				})
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ExpireDue marks passing authentications past their expiry as expired,
+// revokes their certificates and clears the on-chain flag. It returns how
+// many were expired.
+func (s *AuthExpiryService) ExpireDue() (int, error) {
+	var due []models.Authentication
+	if err := s.db.Where("verdict = ? AND expires_at <= ? AND expired_at IS NULL AND revoked_at IS NULL",
+		models.AuthVerdictPass, time.Now()).
+		Order("expires_at asc").
+		Find(&due).Error; err != nil {
+		return 0, fmt.Errorf("failed to fetch expired authentications: %v", err)
+	}
+
+	expired := 0
+	for i := range due {
+		auth := &due[i]
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			now := time.Now()
+			result := tx.Model(&models.Authentication{}).
+				Where("id = ? AND expired_at IS NULL AND revoked_at IS NULL", auth.ID).
+				Update("expired_at", now)
+			if result.Error != nil {
+				return fmt.Errorf("failed to expire authentication: %v", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return nil
+			}
+			auth.ExpiredAt = &now
+
+			if err := s.certificateService.RevokeForAuthentication(tx, auth.ID, "authentication expired"); err != nil {
+				return err
+			}
+			return s.deauthorize(tx, auth)
+		})
+		if err != nil {
+			utils.LogError(err, map[string]interface{}{
+				"component":        "auth_expiry",
+				"authenticationId": auth.ID,
+			})
+			continue
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+// deauthorize queues authenticateProduct(tokenId, false) for a lapsed
+// authentication, unless the product has since been authenticated again
+func (s *AuthExpiryService) deauthorize(tx *gorm.DB, auth *models.Authentication) error {
+	var product models.Product
+	if err := tx.First(&product, "id = ?", auth.ProductID).Error; err != nil {
+		return err
+	}
+	if product.TokenID == nil {
+		return nil
+	}
+
+	var newer int64
+	if err := tx.Model(&models.Authentication{}).
+		Where("product_id = ? AND created_at > ? AND verdict = ? AND expired_at IS NULL AND revoked_at IS NULL",
+			auth.ProductID, auth.CreatedAt, models.AuthVerdictPass).
+		Count(&newer).Error; err != nil {
+		return err
+	}
+	if newer > 0 {
+		return nil
+	}
+
+	op := models.OperatorTransaction{
+		Kind:            models.OperatorTxKindAuthenticate,
+		Reference:       auth.ID,
+		ChainID:         product.ChainID,
+		ContractAddress: product.ContractAddress,
+		TokenID:         *product.TokenID,
+		Authenticated:   false,
+	}
+	if err := s.operatorService.Enqueue(tx, &op); err != nil {
+		return err
+	}
+
+	auth.DeauthTxID = &op.ID
+	return tx.Model(auth).Update("deauth_tx_id", op.ID).Error
+}
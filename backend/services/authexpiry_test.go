@@ -0,0 +1,70 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/models"
+)
+
+func TestParseCategoryDurations(t *testing.T) {
+	durations, err := ParseCategoryDurations("Sneakers=4380h, watches=17520h,,bags=0s")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{
+		"sneakers": 4380 * time.Hour,
+		"watches":  17520 * time.Hour,
+		"bags":     0,
+	}, durations)
+
+	_, err = ParseCategoryDurations("sneakers")
+	assert.Error(t, err)
+	_, err = ParseCategoryDurations("sneakers=soon")
+	assert.Error(t, err)
+}
+
+func TestAuthenticationExpiry(t *testing.T) {
+	service := &AuthExpiryService{
+		validity:           365 * 24 * time.Hour,
+		validityByCategory: map[string]time.Duration{"sneakers": 180 * 24 * time.Hour, "bags": 0},
+	}
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Category Validity", func(t *testing.T) {
+		auth := &models.Authentication{Verdict: models.AuthVerdictPass}
+		service.SetExpiry(auth, "Sneakers", now)
+		require.NotNil(t, auth.ExpiresAt)
+		assert.Equal(t, now.Add(180*24*time.Hour), *auth.ExpiresAt)
+	})
+
+	t.Run("Default Validity", func(t *testing.T) {
+		auth := &models.Authentication{Verdict: models.AuthVerdictPass}
+		service.SetExpiry(auth, "watches", now)
+		require.NotNil(t, auth.ExpiresAt)
+		assert.Equal(t, now.Add(365*24*time.Hour), *auth.ExpiresAt)
+	})
+
+	t.Run("Never Expires", func(t *testing.T) {
+		auth := &models.Authentication{Verdict: models.AuthVerdictPass}
+		service.SetExpiry(auth, "bags", now)
+		assert.Nil(t, auth.ExpiresAt)
+	})
+
+	t.Run("Failed Authentication", func(t *testing.T) {
+		auth := &models.Authentication{Verdict: models.AuthVerdictFail}
+		service.SetExpiry(auth, "sneakers", now)
+		assert.Nil(t, auth.ExpiresAt)
+	})
+}
+
+func TestAuthenticationStatus(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+	earlier := now.Add(-time.Hour)
+
+	assert.Equal(t, models.AuthStatusActive, (&models.Authentication{Verdict: models.AuthVerdictPass, ExpiresAt: &later}).CurrentStatus(now))
+	assert.Equal(t, models.AuthStatusExpired, (&models.Authentication{Verdict: models.AuthVerdictPass, ExpiresAt: &earlier}).CurrentStatus(now))
+	assert.Equal(t, models.AuthStatusRevoked, (&models.Authentication{Verdict: models.AuthVerdictPass, RevokedAt: &earlier}).CurrentStatus(now))
+	assert.Equal(t, models.AuthVerdictFail, (&models.Authentication{Verdict: models.AuthVerdictFail}).CurrentStatus(now))
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrTrackingNotFound is returned when a carrier has no record of a tracking number
+var ErrTrackingNotFound = errors.New("tracking number not found")
+
+// TrackingEvent is a status update reported by a carrier
+type TrackingEvent struct {
+	Status      string    `json:"status"`
+	Location    string    `json:"location"`
+	Description string    `json:"description"`
+	OccurredAt  time.Time `json:"occurredAt"`
+}
+
+// CarrierTracker looks up the tracking history of a shipment. Events use the
+// shipment statuses from the models package and are returned oldest first.
+type CarrierTracker interface {
+	Track(ctx context.Context, carrier, trackingNumber string) ([]TrackingEvent, error)
+}
+
+// FileCarrierTracker is a CarrierTracker that reads tracking histories from
+// JSON files laid out as <dir>/<carrier>/<trackingNumber>.json. It stands in
+// for real carrier APIs in tests and local development.
+type FileCarrierTracker struct {
+	dir string
+}
+
+// NewFileCarrierTracker creates a FileCarrierTracker rooted at dir
+func NewFileCarrierTracker(dir string) *FileCarrierTracker {
+	return &FileCarrierTracker{dir: dir}
+}
+
+// Track reads the tracking history file for a shipment
+func (t *FileCarrierTracker) Track(ctx context.Context, carrier, trackingNumber string) ([]TrackingEvent, error) {
+	// Keep lookups inside the tracker directory
+	name := filepath.Base(filepath.Clean("/" + trackingNumber))
+	carrierDir := filepath.Base(filepath.Clean("/" + strings.ToLower(carrier)))
+
+	data, err := os.ReadFile(filepath.Join(t.dir, carrierDir, name+".json"))
+	if os.IsNotExist(err) {
+		return nil, ErrTrackingNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tracking file: %v", err)
+	}
+
+	var events []TrackingEvent
+	if err := json.Unmarshal(data, &events); err != nil {
+		return nil, fmt.Errorf("invalid tracking file: %v", err)
+	}
+
+	return events, nil
+}
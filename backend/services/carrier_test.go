@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/revibe/backend/models"
+	"github.com/yourusername/revibe/backend/test"
+)
+
+func TestFileCarrierTracker(t *testing.T) {
+	// Setup test environment
+	env := test.SetupTestEnv(t)
+	defer env.CleanupTestEnv()
+
+	env.CreateTestFile(t, "tracking/ups/1Z999.json", `[
+		{"status": "shipped", "location": "Berlin", "occurredAt": "2024-03-20T09:00:00Z"},
+		{"status": "in_transit", "location": "Hamburg", "occurredAt": "2024-03-21T09:00:00Z"},
+		{"status": "delivered", "location": "Hamburg", "occurredAt": "2024-03-22T09:00:00Z"}
+	]`)
+
+	env.CreateTestFile(t, "secret.json", `[]`)
+
+	tracker := NewFileCarrierTracker(env.GetTestFilePath("tracking"))
+
+	t.Run("Track", func(t *testing.T) {
+		events, err := tracker.Track(context.Background(), "UPS", "1Z999")
+		assert.NoError(t, err)
+		assert.Len(t, events, 3)
+		assert.Equal(t, models.ShipmentStatusShipped, events[0].Status)
+		assert.Equal(t, "Hamburg", events[2].Location)
+		assert.Equal(t, models.ShipmentStatusDelivered, events[2].Status)
+	})
+
+	t.Run("UnknownTrackingNumber", func(t *testing.T) {
+		_, err := tracker.Track(context.Background(), "ups", "unknown")
+		assert.Equal(t, ErrTrackingNotFound, err)
+	})
+
+	t.Run("PathTraversal", func(t *testing.T) {
+		_, err := tracker.Track(context.Background(), "ups", "../../secret")
+		assert.Equal(t, ErrTrackingNotFound, err)
+	})
+}
@@ -0,0 +1,387 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/yourusername/revibe/backend/config"
+	"github.com/yourusername/revibe/backend/models"
+	"github.com/yourusername/revibe/backend/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidSigningKey      = errors.New("CERTIFICATE_SIGNING_KEY must be a hex-encoded 32-byte Ed25519 seed")
+	ErrCertificateRevoked     = errors.New("certificate is already revoked")
+	ErrUnknownCertificateKey  = errors.New("certificate was not signed with a platform key")
+	ErrCertificateNotIssuable = errors.New("only passing authentications can be certified")
+)
+
+// CertificateSubject describes the certified product
+type CertificateSubject struct {
+	ProductID        string    `json:"productId"`
+	Name             string    `json:"name"`
+	Category         string    `json:"category"`
+	TokenID          string    `json:"tokenId,omitempty"`
+	ChainID          int64     `json:"chainId,omitempty"`
+	ContractAddress  string    `json:"contractAddress,omitempty"`
+	AuthenticationID string    `json:"authenticationId"`
+	Verdict          string    `json:"verdict"`
+	Score            float64   `json:"score"`
+	Method           string    `json:"method"`
+	Authenticators   []string  `json:"authenticators"`
+	EvidenceRoot     string    `json:"evidenceRoot,omitempty"`
+	AuthenticatedAt  time.Time `json:"authenticatedAt"`
+}
+
+// CertificateStatus points verifiers to the certificate's revocation status
+type CertificateStatus struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// CertificateCredential is the W3C Verifiable Credential carried in the
+// certificate's vc claim
+type CertificateCredential struct {
+	Context           []string           `json:"@context"`
+	Type              []string           `json:"type"`
+	CredentialSubject CertificateSubject `json:"credentialSubject"`
+	CredentialStatus  CertificateStatus  `json:"credentialStatus"`
+}
+
+// CertificateClaims are the JWT claims of a certificate, following the
+// VC-JWT encoding: jti is the certificate ID and sub the product ID
+type CertificateClaims struct {
+	VC CertificateCredential `json:"vc"`
+	jwt.StandardClaims
+}
+
+// CertificateVerification is the outcome of checking a certificate token
+type CertificateVerification struct {
+	Valid            bool               `json:"valid"`
+	SignatureValid   bool               `json:"signatureValid"`
+	Revoked          bool               `json:"revoked"`
+	RevokedAt        *time.Time         `json:"revokedAt,omitempty"`
+	RevocationReason string             `json:"revocationReason,omitempty"`
+	Error            string             `json:"error,omitempty"`
+	Claims           *CertificateClaims `json:"claims,omitempty"`
+}
+
+// CertificateJWK is a platform public key in JWK form
+type CertificateJWK struct {
+	KeyType string `json:"kty"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	KeyID   string `json:"kid"`
+	Alg     string `json:"alg"`
+	Use     string `json:"use"`
+}
+
+// CertificateService issues, verifies and revokes certificates of
+// authenticity signed with the platform's Ed25519 key
+type CertificateService struct {
+	db         *gorm.DB
+	privateKey ed25519.PrivateKey
+	publicKey  ed25519.PublicKey
+	keyID      string
+	issuer     string
+}
+
+// NewCertificateService creates a new CertificateService instance. Without
+// CERTIFICATE_SIGNING_KEY a temporary key is generated, so certificates
+// will no longer verify once the server restarts.
+func NewCertificateService(db *gorm.DB) (*CertificateService, error) {
+	var privateKey ed25519.PrivateKey
+	if config.AppConfig.CertificateSigningKey == "" {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate certificate key: %v", err)
+		}
+		privateKey = key
+		utils.LogWarning("CERTIFICATE_SIGNING_KEY is not set, signing certificates with a temporary key", nil)
+	} else {
+		key, err := ParseSigningKey(config.AppConfig.CertificateSigningKey)
+		if err != nil {
+			return nil, err
+		}
+		privateKey = key
+	}
+
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	return &CertificateService{
+		db:         db,
+		privateKey: privateKey,
+		publicKey:  publicKey,
+		keyID:      CertificateKeyID(publicKey),
+		issuer:     config.AppConfig.BaseURL,
+	}, nil
+}
+
+// ParseSigningKey decodes a hex-encoded Ed25519 seed
+func ParseSigningKey(value string) (ed25519.PrivateKey, error) {
+	seed, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(value), "0x"))
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, ErrInvalidSigningKey
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// CertificateKeyID derives a stable key ID from a public key
+func CertificateKeyID(publicKey ed25519.PublicKey) string {
+	sum := sha256.Sum256(publicKey)
+	return hex.EncodeToString(sum[:8])
+}
+
+// PublicKeys returns the keys certificates can be verified with
+func (s *CertificateService) PublicKeys() []CertificateJWK {
+	return []CertificateJWK{{
+		KeyType: "OKP",
+		Curve:   "Ed25519",
+		X:       base64.RawURLEncoding.EncodeToString(s.publicKey),
+		KeyID:   s.keyID,
+		Alg:     jwt.SigningMethodEdDSA.Alg(),
+		Use:     "sig",
+	}}
+}
+
+// RecordAuthentication updates a product's certificates for a newly stored
+// authentication: a pass issues a certificate and a fail revokes the
+// product's active ones. It runs in the caller's database transaction.
+func (s *CertificateService) RecordAuthentication(tx *gorm.DB, auth *models.Authentication) error {
+	switch auth.Verdict {
+	case models.AuthVerdictPass:
+		cert, err := s.issue(tx, auth)
+		if err != nil {
+			return err
+		}
+		auth.Certificate = cert
+		return nil
+	case models.AuthVerdictFail:
+		return s.revokeProduct(tx, auth.ProductID, fmt.Sprintf("superseded by failed authentication %s", auth.ID))
+	default:
+		return nil
+	}
+}
+
+func (s *CertificateService) issue(tx *gorm.DB, auth *models.Authentication) (*models.Certificate, error) {
+	if auth.Verdict != models.AuthVerdictPass {
+		return nil, ErrCertificateNotIssuable
+	}
+
+	var product models.Product
+	if err := tx.First(&product, "id = ?", auth.ProductID).Error; err != nil {
+		return nil, err
+	}
+
+	subject := CertificateSubject{
+		ProductID:        product.ID,
+		Name:             product.Name,
+		Category:         product.Category,
+		ChainID:          product.ChainID,
+		ContractAddress:  product.ContractAddress,
+		AuthenticationID: auth.ID,
+		Verdict:          auth.Verdict,
+		Score:            auth.Score,
+		Method:           auth.Method,
+		Authenticators:   authenticatorNames(auth),
+		AuthenticatedAt:  auth.CreatedAt.UTC(),
+	}
+	if product.TokenID != nil {
+		subject.TokenID = *product.TokenID
+	}
+	if auth.Evidence != nil {
+		subject.EvidenceRoot = auth.Evidence.MerkleRoot
+	}
+
+	now := time.Now()
+	cert := models.Certificate{
+		ID:               uuid.NewString(),
+		AuthenticationID: auth.ID,
+		ProductID:        product.ID,
+		TokenID:          product.TokenID,
+		KeyID:            s.keyID,
+		IssuedAt:         now,
+	}
+
+	token, err := s.sign(CertificateClaims{
+		VC: CertificateCredential{
+			Context:           []string{"https://www.w3.org/2018/credentials/v1"},
+			Type:              []string{"VerifiableCredential", "AuthenticityCertificate"},
+			CredentialSubject: subject,
+			CredentialStatus: CertificateStatus{
+				ID:   s.issuer + "/certificates/" + cert.ID,
+				Type: "ReVibeRevocationStatus",
+			},
+		},
+		StandardClaims: jwt.StandardClaims{
+			Id:        cert.ID,
+			Issuer:    s.issuer,
+			Subject:   product.ID,
+			IssuedAt:  now.Unix(),
+			NotBefore: now.Unix(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	cert.Token = token
+
+	if err := tx.Create(&cert).Error; err != nil {
+		return nil, fmt.Errorf("failed to save certificate: %v", err)
+	}
+
+	return &cert, nil
+}
+
+func (s *CertificateService) sign(claims CertificateClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = s.keyID
+	signed, err := token.SignedString(s.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign certificate: %v", err)
+	}
+	return signed, nil
+}
+
+// authenticatorNames lists who reached the verdict: the authenticators that
+// answered for automated runs, or the reviewers for expert reviews
+func authenticatorNames(auth *models.Authentication) []string {
+	names := []string{}
+	switch auth.Method {
+	case models.AuthMethodReview:
+		var details ReviewDetails
+		if err := json.Unmarshal([]byte(auth.Details), &details); err == nil {
+			for _, review := range details.Reviews {
+				names = append(names, review.ReviewerID)
+			}
+		}
+	default:
+		var details AuthenticationDetails
+		if err := json.Unmarshal([]byte(auth.Details), &details); err == nil {
+			for _, result := range details.Authenticators {
+				if result.Error == "" {
+					names = append(names, result.Authenticator)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// ParseCertificate checks a certificate token's signature and returns its
+// claims. It needs no database access, so it works for offline verification.
+func (s *CertificateService) ParseCertificate(token string) (*CertificateClaims, error) {
+	var claims CertificateClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodEd25519); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		if kid, _ := t.Header["kid"].(string); kid != s.keyID {
+			return nil, ErrUnknownCertificateKey
+		}
+		return s.publicKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &claims, nil
+}
+
+// Verify checks a certificate token's signature and its revocation status
+func (s *CertificateService) Verify(token string) (*CertificateVerification, error) {
+	claims, err := s.ParseCertificate(token)
+	if err != nil {
+		return &CertificateVerification{Error: err.Error()}, nil
+	}
+
+	result := &CertificateVerification{SignatureValid: true, Claims: claims}
+	var cert models.Certificate
+	if err := s.db.First(&cert, "id = ?", claims.Id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			result.Error = "certificate is not on record"
+			return result, nil
+		}
+		return nil, fmt.Errorf("failed to fetch certificate: %v", err)
+	}
+
+	result.Revoked = cert.Revoked()
+	result.RevokedAt = cert.RevokedAt
+	result.RevocationReason = cert.RevocationReason
+	result.Valid = !result.Revoked
+	return result, nil
+}
+
+// GetCertificate retrieves a certificate
+func (s *CertificateService) GetCertificate(certificateID string) (*models.Certificate, error) {
+	var cert models.Certificate
+	if err := s.db.First(&cert, "id = ?", certificateID).Error; err != nil {
+		return nil, err
+	}
+
+	return &cert, nil
+}
+
+// Revoke marks a certificate revoked on behalf of a staff member
+func (s *CertificateService) Revoke(certificateID, revokerID, reason string) (*models.Certificate, error) {
+	var cert models.Certificate
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&cert, "id = ?", certificateID).Error; err != nil {
+			return err
+		}
+		if cert.Revoked() {
+			return ErrCertificateRevoked
+		}
+
+		now := time.Now()
+		cert.RevokedAt = &now
+		cert.RevokedByID = &revokerID
+		cert.RevocationReason = reason
+		return tx.Model(&cert).
+			Select("revoked_at", "revoked_by_id", "revocation_reason").
+			Updates(&cert).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &cert, nil
+}
+
+// RevokeForAuthentication revokes an authentication's certificate, if it has
+// an active one, in the caller's database transaction
+func (s *CertificateService) RevokeForAuthentication(tx *gorm.DB, authenticationID, reason string) error {
+	err := tx.Model(&models.Certificate{}).
+		Where("authentication_id = ? AND revoked_at IS NULL", authenticationID).
+		Updates(map[string]interface{}{
+			"revoked_at":        time.Now(),
+			"revocation_reason": reason,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to revoke certificate: %v", err)
+	}
+	return nil
+}
+
+func (s *CertificateService) revokeProduct(tx *gorm.DB, productID, reason string) error {
+	err := tx.Model(&models.Certificate{}).
+		Where("product_id = ? AND revoked_at IS NULL", productID).
+		Updates(map[string]interface{}{
+			"revoked_at":        time.Now(),
+			"revocation_reason": reason,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to revoke certificates: %v", err)
+	}
+	return nil
+}
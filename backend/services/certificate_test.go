@@ -0,0 +1,99 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/models"
+)
+
+const testSigningSeed = "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60"
+
+func newTestCertificateService(t *testing.T, seed string) *CertificateService {
+	key, err := ParseSigningKey(seed)
+	require.NoError(t, err)
+	publicKey := key.Public().(ed25519.PublicKey)
+	return &CertificateService{
+		privateKey: key,
+		publicKey:  publicKey,
+		keyID:      CertificateKeyID(publicKey),
+		issuer:     "https://revibe.example",
+	}
+}
+
+func TestParseSigningKey(t *testing.T) {
+	t.Run("Hex Seed", func(t *testing.T) {
+		key, err := ParseSigningKey("0x" + testSigningSeed)
+		require.NoError(t, err)
+		assert.Len(t, key, ed25519.PrivateKeySize)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseSigningKey("not-a-key")
+		assert.ErrorIs(t, err, ErrInvalidSigningKey)
+
+		_, err = ParseSigningKey(testSigningSeed[:32])
+		assert.ErrorIs(t, err, ErrInvalidSigningKey)
+	})
+}
+
+func TestCertificateSignature(t *testing.T) {
+	service := newTestCertificateService(t, testSigningSeed)
+	token, err := service.sign(CertificateClaims{
+		VC: CertificateCredential{
+			Type: []string{"VerifiableCredential", "AuthenticityCertificate"},
+			CredentialSubject: CertificateSubject{
+				ProductID: "product-1",
+				TokenID:   "42",
+				Score:     91.5,
+				Verdict:   models.AuthVerdictPass,
+			},
+		},
+		StandardClaims: jwt.StandardClaims{Id: "cert-1", Subject: "product-1"},
+	})
+	require.NoError(t, err)
+
+	t.Run("Round Trip", func(t *testing.T) {
+		claims, err := service.ParseCertificate(token)
+		require.NoError(t, err)
+		assert.Equal(t, "cert-1", claims.Id)
+		assert.Equal(t, "42", claims.VC.CredentialSubject.TokenID)
+		assert.Equal(t, 91.5, claims.VC.CredentialSubject.Score)
+	})
+
+	t.Run("Tampered Payload", func(t *testing.T) {
+		parts := strings.Split(token, ".")
+		other, err := service.sign(CertificateClaims{
+			VC: CertificateCredential{CredentialSubject: CertificateSubject{ProductID: "product-2"}},
+		})
+		require.NoError(t, err)
+		parts[1] = strings.Split(other, ".")[1]
+
+		_, err = service.ParseCertificate(strings.Join(parts, "."))
+		assert.Error(t, err)
+	})
+
+	t.Run("Other Key", func(t *testing.T) {
+		other := newTestCertificateService(t, strings.Repeat("01", 32))
+		_, err := other.ParseCertificate(token)
+		assert.Error(t, err)
+	})
+}
+
+func TestAuthenticatorNames(t *testing.T) {
+	automated := &models.Authentication{
+		Method:  models.AuthMethodAutomated,
+		Details: `{"authenticators":[{"authenticator":"image_evidence"},{"authenticator":"remote:auth.example","error":"timeout"}]}`,
+	}
+	assert.Equal(t, []string{"image_evidence"}, authenticatorNames(automated))
+
+	review := &models.Authentication{
+		Method:  models.AuthMethodReview,
+		Details: `{"reviews":[{"reviewerId":"reviewer-1"},{"reviewerId":"reviewer-2"}]}`,
+	}
+	assert.Equal(t, []string{"reviewer-1", "reviewer-2"}, authenticatorNames(review))
+}
@@ -0,0 +1,352 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/yourusername/revibe/backend/config"
+	"github.com/yourusername/revibe/backend/contracts"
+	"github.com/yourusername/revibe/backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// defaultABIVersion is the contract version of deployments configured
+// without one
+const defaultABIVersion = "v1"
+
+var (
+	ErrUnknownDeployment  = errors.New("unknown contract deployment")
+	ErrDeploymentReadOnly = errors.New("contract deployment is read-only")
+	ErrUnknownABIVersion  = errors.New("unknown contract ABI version")
+)
+
+// contractABIs are the contract versions the backend can talk to. The
+// generated bindings decode events and read products for every version, so
+// a new version must keep the methods and events the backend uses
+// compatible with them.
+var contractABIs = map[string]*bind.MetaData{
+	"v1": contracts.ReVibeContractMetaData,
+}
+
+// ParseDeployments parses a comma-separated list of contract deployments,
+// each chainId:address[:abiVersion[:status[:startBlock]]], such as
+// "1:0xabc...:v1:legacy:17000000,8453:0xdef...". The ABI version
+// defaults to v1, the status to active and the start block to
+// INDEXER_START_BLOCK.
+func ParseDeployments(spec string) ([]models.Deployment, error) {
+	var deployments []models.Deployment
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		fields := strings.Split(entry, ":")
+		if len(fields) < 2 || len(fields) > 5 {
+			return nil, fmt.Errorf("invalid contract deployment %q", entry)
+		}
+
+		chainID, err := strconv.ParseInt(strings.TrimSpace(fields[0]), 10, 64)
+		if err != nil || chainID <= 0 {
+			return nil, fmt.Errorf("invalid chain ID in contract deployment %q", entry)
+		}
+		address := strings.TrimSpace(fields[1])
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("invalid contract address in contract deployment %q", entry)
+		}
+
+		deployment := models.Deployment{
+			ChainID:         chainID,
+			ContractAddress: common.HexToAddress(address).Hex(),
+			ABIVersion:      defaultABIVersion,
+			Status:          models.DeploymentStatusActive,
+		}
+		if len(fields) > 2 && strings.TrimSpace(fields[2]) != "" {
+			deployment.ABIVersion = strings.TrimSpace(fields[2])
+			if _, ok := contractABIs[deployment.ABIVersion]; !ok {
+				return nil, fmt.Errorf("%w %q in contract deployment %q", ErrUnknownABIVersion, deployment.ABIVersion, entry)
+			}
+		}
+		if len(fields) > 3 && strings.TrimSpace(fields[3]) != "" {
+			deployment.Status = strings.TrimSpace(fields[3])
+			if deployment.Status != models.DeploymentStatusActive && deployment.Status != models.DeploymentStatusLegacy {
+				return nil, fmt.Errorf("invalid status %q in contract deployment %q", deployment.Status, entry)
+			}
+		}
+		if len(fields) > 4 && strings.TrimSpace(fields[4]) != "" {
+			deployment.StartBlock, err = strconv.ParseUint(strings.TrimSpace(fields[4]), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid start block in contract deployment %q", entry)
+			}
+		}
+		deployments = append(deployments, deployment)
+	}
+	return deployments, nil
+}
+
+// validateDeployments checks that no deployment is listed twice, that each
+// chain has at most one active deployment and that the default chain has one
+func validateDeployments(deployments []models.Deployment, defaultChainID int64) error {
+	seen := make(map[string]bool)
+	active := make(map[int64]bool)
+	for _, deployment := range deployments {
+		key := fmt.Sprintf("%d:%s", deployment.ChainID, strings.ToLower(deployment.ContractAddress))
+		if seen[key] {
+			return fmt.Errorf("contract deployment %s is listed twice", key)
+		}
+		seen[key] = true
+
+		if deployment.Status != models.DeploymentStatusActive {
+			continue
+		}
+		if active[deployment.ChainID] {
+			return fmt.Errorf("chain %d has more than one active contract deployment", deployment.ChainID)
+		}
+		active[deployment.ChainID] = true
+	}
+	if !active[defaultChainID] {
+		return fmt.Errorf("chain %d has no active contract deployment", defaultChainID)
+	}
+	return nil
+}
+
+// DeploymentRegistry holds a Web3Service for every configured ReVibe
+// deployment and routes chain calls to the right one. Deployments on the
+// same chain share that chain's RPC providers.
+type DeploymentRegistry struct {
+	pools       map[int64]*RPCPool
+	deployments []*Web3Service
+	active      *Web3Service
+}
+
+// NewDeploymentRegistry connects to every deployment in
+// CONTRACT_DEPLOYMENTS, or to CONTRACT_ADDRESS on CHAIN_ID if that is unset,
+// and records the deployments in the database
+func NewDeploymentRegistry(db *gorm.DB) (*DeploymentRegistry, error) {
+	defaultChainID, err := strconv.ParseInt(config.AppConfig.ChainID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid chain ID: %s", config.AppConfig.ChainID)
+	}
+
+	deployments, err := ParseDeployments(config.AppConfig.ContractDeployments)
+	if err != nil {
+		return nil, err
+	}
+	if len(deployments) == 0 {
+		deployments = []models.Deployment{{
+			ChainID:         defaultChainID,
+			ContractAddress: common.HexToAddress(config.AppConfig.ContractAddress).Hex(),
+			ABIVersion:      defaultABIVersion,
+			Status:          models.DeploymentStatusActive,
+		}}
+	}
+	if err := validateDeployments(deployments, defaultChainID); err != nil {
+		return nil, err
+	}
+
+	pools := make(map[int64]*RPCPool)
+	closePools := func() {
+		for _, pool := range pools {
+			pool.Close()
+		}
+	}
+
+	var services []*Web3Service
+	for i := range deployments {
+		deployment := &deployments[i]
+		if err := saveDeployment(db, deployment); err != nil {
+			closePools()
+			return nil, err
+		}
+
+		pool, ok := pools[deployment.ChainID]
+		if !ok {
+			pool, err = dialRPCPool(big.NewInt(deployment.ChainID))
+			if err != nil {
+				closePools()
+				return nil, err
+			}
+			pools[deployment.ChainID] = pool
+		}
+
+		s, err := newWeb3Service(pool.Backend(), func(ctx context.Context) (ChainBackend, error) {
+			return pool.Pin(ctx)
+		}, *deployment)
+		if err != nil {
+			closePools()
+			return nil, err
+		}
+		services = append(services, s)
+	}
+
+	registry, err := newDeploymentRegistry(services, defaultChainID)
+	if err != nil {
+		closePools()
+		return nil, err
+	}
+	registry.pools = pools
+	return registry, nil
+}
+
+// newDeploymentRegistry routes between the given services. The active
+// deployment of defaultChainID takes new listings.
+func newDeploymentRegistry(deployments []*Web3Service, defaultChainID int64) (*DeploymentRegistry, error) {
+	registry := &DeploymentRegistry{deployments: deployments}
+	for _, s := range deployments {
+		if s.ChainID().Int64() == defaultChainID && !s.ReadOnly() {
+			registry.active = s
+		}
+	}
+	if registry.active == nil {
+		return nil, fmt.Errorf("chain %d has no active contract deployment", defaultChainID)
+	}
+	return registry, nil
+}
+
+// saveDeployment records a configured deployment, updating its version,
+// status and start block if it is already known, and sets its ID
+func saveDeployment(db *gorm.DB, deployment *models.Deployment) error {
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chain_id"}, {Name: "contract_address"}},
+		DoUpdates: clause.AssignmentColumns([]string{"abi_version", "status", "start_block", "updated_at"}),
+	}).Create(deployment).Error
+	if err != nil {
+		return fmt.Errorf("failed to save contract deployment: %v", err)
+	}
+	return nil
+}
+
+// dialRPCPool connects to the RPC endpoints configured for a chain, falling
+// back to Infura
+func dialRPCPool(chainID *big.Int) (*RPCPool, error) {
+	endpoints, err := ParseRPCEndpoints(config.AppConfig.RPCEndpoints)
+	if err != nil {
+		return nil, err
+	}
+	urls := endpoints[chainID.String()]
+	if len(urls) == 0 {
+		urls = InfuraEndpoints(chainID.String(), config.AppConfig.InfuraID)
+	}
+
+	pool, err := NewRPCPool(context.Background(), chainID, urls,
+		config.AppConfig.RPCRequestsPerSecond, uint64(config.AppConfig.RPCMaxBlockLag))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to chain %s: %v", chainID, err)
+	}
+	return pool, nil
+}
+
+// deploymentID returns the ID of a service's deployment, or nil for one that
+// was not recorded in the database
+func deploymentID(s *Web3Service) *string {
+	if s.deployment.ID == "" {
+		return nil
+	}
+	id := s.deployment.ID
+	return &id
+}
+
+// Active returns the deployment new listings go to
+func (r *DeploymentRegistry) Active() *Web3Service {
+	return r.active
+}
+
+// Deployments returns every deployment, legacy ones included
+func (r *DeploymentRegistry) Deployments() []*Web3Service {
+	return r.deployments
+}
+
+// Get returns the deployment of a contract on a chain
+func (r *DeploymentRegistry) Get(chainID int64, contractAddress string) (*Web3Service, error) {
+	address := common.HexToAddress(contractAddress)
+	for _, s := range r.deployments {
+		if s.ChainID().Int64() == chainID && s.ContractAddress() == address {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s on chain %d", ErrUnknownDeployment, contractAddress, chainID)
+}
+
+// Chain returns a deployment on a chain for calls that are not about one
+// contract, such as transaction lookups. It prefers the chain's active
+// deployment.
+func (r *DeploymentRegistry) Chain(chainID int64) (*Web3Service, error) {
+	var found *Web3Service
+	for _, s := range r.deployments {
+		if s.ChainID().Int64() != chainID {
+			continue
+		}
+		if !s.ReadOnly() {
+			return s, nil
+		}
+		if found == nil {
+			found = s
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%w: no deployment on chain %d", ErrUnknownDeployment, chainID)
+	}
+	return found, nil
+}
+
+// ChainIDs returns the chains with a deployment, in ascending order
+func (r *DeploymentRegistry) ChainIDs() []int64 {
+	seen := make(map[int64]bool)
+	var chainIDs []int64
+	for _, s := range r.deployments {
+		chainID := s.ChainID().Int64()
+		if !seen[chainID] {
+			seen[chainID] = true
+			chainIDs = append(chainIDs, chainID)
+		}
+	}
+	sort.Slice(chainIDs, func(i, j int) bool { return chainIDs[i] < chainIDs[j] })
+	return chainIDs
+}
+
+// ForProduct returns the deployment that minted a product's token, or the
+// active deployment for a product that is not minted yet
+func (r *DeploymentRegistry) ForProduct(product *models.Product) (*Web3Service, error) {
+	if product.TokenID == nil {
+		return r.active, nil
+	}
+	return r.Get(product.ChainID, product.ContractAddress)
+}
+
+// StartRPCHealthChecks checks every chain's RPC providers until ctx is
+// cancelled
+func (r *DeploymentRegistry) StartRPCHealthChecks(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, pool := range r.pools {
+		wg.Add(1)
+		go func(pool *RPCPool) {
+			defer wg.Done()
+			pool.StartHealthChecks(ctx, config.AppConfig.RPCHealthInterval)
+		}(pool)
+	}
+	wg.Wait()
+}
+
+// RPCStatus reports the health of each chain's RPC providers
+func (r *DeploymentRegistry) RPCStatus() map[int64][]RPCProviderStatus {
+	statuses := make(map[int64][]RPCProviderStatus, len(r.pools))
+	for chainID, pool := range r.pools {
+		statuses[chainID] = pool.Status()
+	}
+	return statuses
+}
+
+// Close closes every chain's RPC connections
+func (r *DeploymentRegistry) Close() {
+	for _, pool := range r.pools {
+		pool.Close()
+	}
+}
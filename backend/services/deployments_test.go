@@ -0,0 +1,127 @@
+package services
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/models"
+)
+
+const (
+	testDeploymentA = "0x00000000000000000000000000000000000000aa"
+	testDeploymentB = "0x00000000000000000000000000000000000000bb"
+)
+
+func TestParseDeployments(t *testing.T) {
+	deployments, err := ParseDeployments(" 1:" + testDeploymentA + ":v1:legacy:17000000,,8453:" + testDeploymentB)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Deployment{
+		{
+			ChainID:         1,
+			ContractAddress: common.HexToAddress(testDeploymentA).Hex(),
+			ABIVersion:      "v1",
+			Status:          models.DeploymentStatusLegacy,
+			StartBlock:      17000000,
+		},
+		{
+			ChainID:         8453,
+			ContractAddress: common.HexToAddress(testDeploymentB).Hex(),
+			ABIVersion:      defaultABIVersion,
+			Status:          models.DeploymentStatusActive,
+		},
+	}, deployments)
+
+	deployments, err = ParseDeployments("")
+	require.NoError(t, err)
+	assert.Empty(t, deployments)
+
+	for _, spec := range []string{
+		"1",
+		"x:" + testDeploymentA,
+		"0:" + testDeploymentA,
+		"1:0xnotanaddress",
+		"1:" + testDeploymentA + ":v1:retired",
+		"1:" + testDeploymentA + ":v1:active:soon",
+		"1:" + testDeploymentA + ":v1:active:1:extra",
+	} {
+		_, err := ParseDeployments(spec)
+		assert.Error(t, err, spec)
+	}
+
+	_, err = ParseDeployments("1:" + testDeploymentA + ":v9")
+	assert.True(t, errors.Is(err, ErrUnknownABIVersion))
+}
+
+func TestValidateDeployments(t *testing.T) {
+	deployment := func(chainID int64, address, status string) models.Deployment {
+		return models.Deployment{ChainID: chainID, ContractAddress: address, Status: status}
+	}
+
+	assert.NoError(t, validateDeployments([]models.Deployment{
+		deployment(1, testDeploymentA, models.DeploymentStatusLegacy),
+		deployment(1, testDeploymentB, models.DeploymentStatusActive),
+		deployment(8453, testDeploymentA, models.DeploymentStatusActive),
+	}, 1))
+
+	assert.ErrorContains(t, validateDeployments([]models.Deployment{
+		deployment(1, testDeploymentA, models.DeploymentStatusActive),
+		deployment(1, common.HexToAddress(testDeploymentA).Hex(), models.DeploymentStatusLegacy),
+	}, 1), "listed twice")
+
+	assert.ErrorContains(t, validateDeployments([]models.Deployment{
+		deployment(1, testDeploymentA, models.DeploymentStatusActive),
+		deployment(1, testDeploymentB, models.DeploymentStatusActive),
+	}, 1), "more than one active")
+
+	assert.ErrorContains(t, validateDeployments([]models.Deployment{
+		deployment(1, testDeploymentA, models.DeploymentStatusLegacy),
+		deployment(8453, testDeploymentB, models.DeploymentStatusActive),
+	}, 1), "chain 1 has no active")
+}
+
+func TestDeploymentRegistryRouting(t *testing.T) {
+	legacy, err := newWeb3Service(nil, nil, models.Deployment{
+		ChainID:         1,
+		ContractAddress: common.HexToAddress(testDeploymentA).Hex(),
+		ABIVersion:      defaultABIVersion,
+		Status:          models.DeploymentStatusLegacy,
+	})
+	require.NoError(t, err)
+	active, err := NewWeb3ServiceWithBackend(nil, big.NewInt(1), common.HexToAddress(testDeploymentB))
+	require.NoError(t, err)
+	base, err := NewWeb3ServiceWithBackend(nil, big.NewInt(8453), common.HexToAddress(testDeploymentA))
+	require.NoError(t, err)
+
+	registry, err := newDeploymentRegistry([]*Web3Service{legacy, active, base}, 1)
+	require.NoError(t, err)
+	assert.Same(t, active, registry.Active())
+	assert.True(t, legacy.ReadOnly())
+	assert.Equal(t, []int64{1, 8453}, registry.ChainIDs())
+
+	s, err := registry.Get(1, testDeploymentA)
+	require.NoError(t, err)
+	assert.Same(t, legacy, s)
+	_, err = registry.Get(8453, testDeploymentB)
+	assert.True(t, errors.Is(err, ErrUnknownDeployment))
+
+	s, err = registry.Chain(1)
+	require.NoError(t, err)
+	assert.Same(t, active, s)
+	_, err = registry.Chain(10)
+	assert.True(t, errors.Is(err, ErrUnknownDeployment))
+
+	tokenID := "7"
+	s, err = registry.ForProduct(&models.Product{TokenID: &tokenID, ChainID: 1, ContractAddress: testDeploymentA})
+	require.NoError(t, err)
+	assert.Same(t, legacy, s)
+	s, err = registry.ForProduct(&models.Product{})
+	require.NoError(t, err)
+	assert.Same(t, active, s)
+
+	_, err = newDeploymentRegistry([]*Web3Service{legacy}, 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,332 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"mime/multipart"
+	"time"
+
+	"github.com/yourusername/revibe/backend/models"
+	"gorm.io/gorm"
+)
+
+// Dispute actions recorded in the audit trail
+const (
+	DisputeActionOpen            = "open"
+	DisputeActionSubmitEvidence  = "submit_evidence"
+	DisputeActionRequestEvidence = "request_evidence"
+	DisputeActionStartReview     = "start_review"
+	DisputeActionResolve         = "resolve"
+)
+
+var (
+	ErrDisputeExists         = errors.New("order already has an open dispute")
+	ErrOrderNotDisputable    = errors.New("only completed orders can be disputed")
+	ErrNotDisputeParty       = errors.New("not a party to this dispute")
+	ErrInvalidDisputeState   = errors.New("action not allowed in the dispute's current state")
+	ErrInvalidRefundAmount   = errors.New("invalid refund amount")
+	ErrInvalidDisputeOutcome = errors.New("invalid dispute resolution")
+)
+
+// disputeTransitions lists the statuses a dispute may move to from each status
+var disputeTransitions = map[string][]string{
+	models.DisputeStatusOpened:            {models.DisputeStatusEvidenceRequested, models.DisputeStatusUnderReview, models.DisputeStatusResolved},
+	models.DisputeStatusEvidenceRequested: {models.DisputeStatusUnderReview, models.DisputeStatusResolved},
+	models.DisputeStatusUnderReview:       {models.DisputeStatusEvidenceRequested, models.DisputeStatusResolved},
+}
+
+func canTransition(from, to string) bool {
+	for _, status := range disputeTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveDisputeInput is a staff decision on a dispute
+type ResolveDisputeInput struct {
+	Resolution      string
+	RefundAmountWei string
+	Note            string
+}
+
+// DisputeService manages buyer disputes and refund decisions
+type DisputeService struct {
+	db              *gorm.DB
+	uploadService   *UploadService
+	operatorService *OperatorService
+}
+
+// NewDisputeService creates a new DisputeService instance
+func NewDisputeService(db *gorm.DB, uploadService *UploadService, operatorService *OperatorService) *DisputeService {
+	return &DisputeService{
+		db:              db,
+		uploadService:   uploadService,
+		operatorService: operatorService,
+	}
+}
+
+func recordDisputeAction(tx *gorm.DB, dispute *models.Dispute, actorID, action, fromStatus, note string) error {
+	entry := models.DisputeAction{
+		DisputeID:  dispute.ID,
+		ActorID:    actorID,
+		Action:     action,
+		FromStatus: fromStatus,
+		ToStatus:   dispute.Status,
+		Note:       note,
+	}
+	if err := tx.Create(&entry).Error; err != nil {
+		return fmt.Errorf("failed to record dispute action: %v", err)
+	}
+	return nil
+}
+
+// OpenDispute opens a dispute on a completed order on behalf of its buyer
+func (s *DisputeService) OpenDispute(orderID, buyerID, reason, description string) (*models.Dispute, error) {
+	var dispute models.Dispute
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var order models.Order
+		if err := tx.First(&order, "id = ?", orderID).Error; err != nil {
+			return err
+		}
+		if order.BuyerID != buyerID {
+			return ErrNotOrderBuyer
+		}
+		if order.Status != models.OrderStatusCompleted {
+			return ErrOrderNotDisputable
+		}
+
+		var open int64
+		if err := tx.Model(&models.Dispute{}).
+			Where("order_id = ? AND status <> ?", order.ID, models.DisputeStatusResolved).
+			Count(&open).Error; err != nil {
+			return err
+		}
+		if open > 0 {
+			return ErrDisputeExists
+		}
+
+		dispute = models.Dispute{
+			OrderID:     order.ID,
+			OpenedByID:  buyerID,
+			Reason:      reason,
+			Description: description,
+			Status:      models.DisputeStatusOpened,
+		}
+		if err := tx.Create(&dispute).Error; err != nil {
+			return fmt.Errorf("failed to create dispute: %v", err)
+		}
+
+		return recordDisputeAction(tx, &dispute, buyerID, DisputeActionOpen, "", description)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dispute, nil
+}
+
+// GetDispute retrieves a dispute with its order, evidence and audit trail
+func (s *DisputeService) GetDispute(disputeID string) (*models.Dispute, error) {
+	var dispute models.Dispute
+	err := s.db.Preload("Order.Product").
+		Preload("RefundTx").
+		Preload("Evidence", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at asc")
+		}).
+		Preload("Actions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at asc")
+		}).
+		First(&dispute, "id = ?", disputeID).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &dispute, nil
+}
+
+// IsParty reports whether a user is the buyer or seller of a disputed order
+func (s *DisputeService) IsParty(dispute *models.Dispute, userID string) bool {
+	return dispute.Order.BuyerID == userID || dispute.Order.SellerID == userID
+}
+
+// ListDisputes returns a page of disputes, optionally filtered by status
+func (s *DisputeService) ListDisputes(status string, page, limit int) ([]models.Dispute, int64, error) {
+	query := s.db.Model(&models.Dispute{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count disputes: %v", err)
+	}
+
+	var disputes []models.Dispute
+	err := query.Preload("Order.Product").
+		Order("created_at asc").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&disputes).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch disputes: %v", err)
+	}
+
+	return disputes, total, nil
+}
+
+// SubmitEvidence stores an evidence file and/or note from the buyer or
+// seller. Evidence submitted after staff asked for it moves the dispute back
+// under review.
+func (s *DisputeService) SubmitEvidence(disputeID, userID string, file *multipart.FileHeader, note string) (*models.DisputeEvidence, error) {
+	dispute, err := s.GetDispute(disputeID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.IsParty(dispute, userID) {
+		return nil, ErrNotDisputeParty
+	}
+	if dispute.Status == models.DisputeStatusResolved {
+		return nil, ErrInvalidDisputeState
+	}
+
+	evidence := models.DisputeEvidence{
+		DisputeID:     dispute.ID,
+		SubmittedByID: userID,
+		Note:          note,
+	}
+	if file != nil {
+		path, err := s.uploadService.UploadFile(file, "disputes")
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload evidence: %v", err)
+		}
+		evidence.URL = path
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&evidence).Error; err != nil {
+			return fmt.Errorf("failed to save evidence: %v", err)
+		}
+
+		fromStatus := dispute.Status
+		if dispute.Status == models.DisputeStatusEvidenceRequested {
+			dispute.Status = models.DisputeStatusUnderReview
+			if err := tx.Model(dispute).Update("status", dispute.Status).Error; err != nil {
+				return fmt.Errorf("failed to update dispute: %v", err)
+			}
+		}
+
+		return recordDisputeAction(tx, dispute, userID, DisputeActionSubmitEvidence, fromStatus, note)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &evidence, nil
+}
+
+// Transition moves a dispute to evidence_requested or under_review on behalf of staff
+func (s *DisputeService) Transition(disputeID, staffID, status, note string) (*models.Dispute, error) {
+	action := DisputeActionStartReview
+	if status == models.DisputeStatusEvidenceRequested {
+		action = DisputeActionRequestEvidence
+	}
+
+	var dispute models.Dispute
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&dispute, "id = ?", disputeID).Error; err != nil {
+			return err
+		}
+		if status == models.DisputeStatusResolved || !canTransition(dispute.Status, status) {
+			return ErrInvalidDisputeState
+		}
+
+		fromStatus := dispute.Status
+		dispute.Status = status
+		if err := tx.Model(&dispute).Update("status", status).Error; err != nil {
+			return fmt.Errorf("failed to update dispute: %v", err)
+		}
+
+		return recordDisputeAction(tx, &dispute, staffID, action, fromStatus, note)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dispute, nil
+}
+
+// Resolve records a staff decision. Refund decisions queue an operator
+// transfer to the buyer's wallet.
+func (s *DisputeService) Resolve(disputeID, staffID string, input ResolveDisputeInput) (*models.Dispute, error) {
+	switch input.Resolution {
+	case models.DisputeResolutionRefund, models.DisputeResolutionPartialRefund, models.DisputeResolutionRejected:
+	default:
+		return nil, ErrInvalidDisputeOutcome
+	}
+
+	var dispute models.Dispute
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Preload("Order.Buyer").Preload("Order.Product", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		}).First(&dispute, "id = ?", disputeID).Error; err != nil {
+			return err
+		}
+		if !canTransition(dispute.Status, models.DisputeStatusResolved) {
+			return ErrInvalidDisputeState
+		}
+
+		if input.Resolution != models.DisputeResolutionRejected {
+			paid, ok := new(big.Int).SetString(dispute.Order.PriceWei, 10)
+			if !ok {
+				return fmt.Errorf("order has no recorded on-chain price")
+			}
+
+			refund := paid
+			if input.Resolution == models.DisputeResolutionPartialRefund {
+				amount, ok := new(big.Int).SetString(input.RefundAmountWei, 10)
+				if !ok || amount.Sign() <= 0 || amount.Cmp(paid) >= 0 {
+					return ErrInvalidRefundAmount
+				}
+				refund = amount
+			}
+
+			op := models.OperatorTransaction{
+				Kind:      models.OperatorTxKindRefund,
+				Reference: dispute.ID,
+				// The buyer paid on the chain the product was minted on
+				ChainID:   dispute.Order.Product.ChainID,
+				ToAddress: dispute.Order.Buyer.WalletAddress,
+				ValueWei:  refund.String(),
+			}
+			if err := s.operatorService.Enqueue(tx, &op); err != nil {
+				return err
+			}
+			dispute.RefundAmountWei = refund.String()
+			dispute.RefundTxID = &op.ID
+		}
+
+		now := time.Now()
+		fromStatus := dispute.Status
+		dispute.Status = models.DisputeStatusResolved
+		dispute.Resolution = input.Resolution
+		dispute.ResolvedByID = &staffID
+		dispute.ResolvedAt = &now
+		if err := tx.Omit("Order").Save(&dispute).Error; err != nil {
+			return fmt.Errorf("failed to update dispute: %v", err)
+		}
+
+		note := input.Resolution
+		if input.Note != "" {
+			note += ": " + input.Note
+		}
+		return recordDisputeAction(tx, &dispute, staffID, DisputeActionResolve, fromStatus, note)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dispute, nil
+}
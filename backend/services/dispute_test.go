@@ -0,0 +1,293 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/models"
+)
+
+func TestCanTransition(t *testing.T) {
+	statuses := []string{
+		models.DisputeStatusOpened,
+		models.DisputeStatusEvidenceRequested,
+		models.DisputeStatusUnderReview,
+		models.DisputeStatusResolved,
+	}
+	allowed := map[[2]string]bool{
+		{models.DisputeStatusOpened, models.DisputeStatusEvidenceRequested}:      true,
+		{models.DisputeStatusOpened, models.DisputeStatusUnderReview}:            true,
+		{models.DisputeStatusOpened, models.DisputeStatusResolved}:               true,
+		{models.DisputeStatusEvidenceRequested, models.DisputeStatusUnderReview}: true,
+		{models.DisputeStatusEvidenceRequested, models.DisputeStatusResolved}:    true,
+		{models.DisputeStatusUnderReview, models.DisputeStatusEvidenceRequested}: true,
+		{models.DisputeStatusUnderReview, models.DisputeStatusResolved}:          true,
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			assert.Equal(t, allowed[[2]string{from, to}], canTransition(from, to), "%s -> %s", from, to)
+		}
+	}
+}
+
+// disputeTest is a completed 0.1 ETH sale with a dispute service and a
+// staff member to act on disputes
+type disputeTest struct {
+	*chainFixture
+	disputes *DisputeService
+	order    *models.Order
+	staff    *models.User
+}
+
+func newDisputeTest(t *testing.T) *disputeTest {
+	f := newChainFixture(t)
+	product := f.mintedProduct("1")
+	order := models.Order{
+		ProductID: product.ID,
+		BuyerID:   f.buyer.ID,
+		SellerID:  f.seller.ID,
+		Price:     0.1,
+		PriceWei:  "100000000000000000",
+		TokenID:   "1",
+		Status:    models.OrderStatusCompleted,
+		TxHash:    txHash(1),
+	}
+	require.NoError(t, f.db.Create(&order).Error)
+
+	return &disputeTest{
+		chainFixture: f,
+		disputes:     NewDisputeService(f.db, nil, &OperatorService{db: f.db}),
+		order:        &order,
+		staff:        createUser(t, f.db, common.HexToAddress("0x57aff").Hex()),
+	}
+}
+
+// open opens a dispute on the order and moves it to status
+func (d *disputeTest) open(status string) *models.Dispute {
+	dispute, err := d.disputes.OpenDispute(d.order.ID, d.buyer.ID, "not_as_described", "Soles are worn through")
+	require.NoError(d.t, err)
+	require.NoError(d.t, d.db.Model(dispute).Update("status", status).Error)
+	return dispute
+}
+
+// lastAction returns the newest entry in a dispute's audit trail
+func (d *disputeTest) lastAction(dispute *models.Dispute) models.DisputeAction {
+	var action models.DisputeAction
+	require.NoError(d.t, d.db.Where("dispute_id = ?", dispute.ID).Order("created_at desc").First(&action).Error)
+	return action
+}
+
+func TestOpenDispute(t *testing.T) {
+	d := newDisputeTest(t)
+
+	_, err := d.disputes.OpenDispute(d.order.ID, d.seller.ID, "not_as_described", "")
+	assert.ErrorIs(t, err, ErrNotOrderBuyer)
+
+	dispute := d.open(models.DisputeStatusOpened)
+	action := d.lastAction(dispute)
+	assert.Equal(t, DisputeActionOpen, action.Action)
+	assert.Empty(t, action.FromStatus)
+	assert.Equal(t, models.DisputeStatusOpened, action.ToStatus)
+
+	_, err = d.disputes.OpenDispute(d.order.ID, d.buyer.ID, "not_as_described", "")
+	assert.ErrorIs(t, err, ErrDisputeExists)
+
+	// A resolved dispute does not stop a new one
+	require.NoError(t, d.db.Model(dispute).Update("status", models.DisputeStatusResolved).Error)
+	_, err = d.disputes.OpenDispute(d.order.ID, d.buyer.ID, "not_received", "")
+	assert.NoError(t, err)
+
+	require.NoError(t, d.db.Model(d.order).Update("status", models.OrderStatusPending).Error)
+	require.NoError(t, d.db.Model(&models.Dispute{}).Where("order_id = ?", d.order.ID).
+		Update("status", models.DisputeStatusResolved).Error)
+	_, err = d.disputes.OpenDispute(d.order.ID, d.buyer.ID, "not_received", "")
+	assert.ErrorIs(t, err, ErrOrderNotDisputable)
+}
+
+func TestDisputeTransition(t *testing.T) {
+	tests := []struct {
+		from       string
+		to         string
+		wantAction string
+	}{
+		{models.DisputeStatusOpened, models.DisputeStatusEvidenceRequested, DisputeActionRequestEvidence},
+		{models.DisputeStatusOpened, models.DisputeStatusUnderReview, DisputeActionStartReview},
+		{models.DisputeStatusEvidenceRequested, models.DisputeStatusUnderReview, DisputeActionStartReview},
+		{models.DisputeStatusUnderReview, models.DisputeStatusEvidenceRequested, DisputeActionRequestEvidence},
+		{from: models.DisputeStatusEvidenceRequested, to: models.DisputeStatusEvidenceRequested},
+		{from: models.DisputeStatusUnderReview, to: models.DisputeStatusOpened},
+		{from: models.DisputeStatusResolved, to: models.DisputeStatusUnderReview},
+		// Resolving goes through Resolve, which records the decision
+		{from: models.DisputeStatusOpened, to: models.DisputeStatusResolved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			d := newDisputeTest(t)
+			dispute := d.open(tt.from)
+
+			updated, err := d.disputes.Transition(dispute.ID, d.staff.ID, tt.to, "note")
+			stored, getErr := d.disputes.GetDispute(dispute.ID)
+			require.NoError(t, getErr)
+			if tt.wantAction == "" {
+				assert.ErrorIs(t, err, ErrInvalidDisputeState)
+				assert.Equal(t, tt.from, stored.Status)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.to, updated.Status)
+			assert.Equal(t, tt.to, stored.Status)
+
+			action := d.lastAction(dispute)
+			assert.Equal(t, tt.wantAction, action.Action)
+			assert.Equal(t, d.staff.ID, action.ActorID)
+			assert.Equal(t, tt.from, action.FromStatus)
+			assert.Equal(t, tt.to, action.ToStatus)
+		})
+	}
+}
+
+func TestSubmitEvidence(t *testing.T) {
+	tests := []struct {
+		status     string
+		wantStatus string
+		wantErr    error
+	}{
+		{models.DisputeStatusOpened, models.DisputeStatusOpened, nil},
+		{models.DisputeStatusEvidenceRequested, models.DisputeStatusUnderReview, nil},
+		{models.DisputeStatusUnderReview, models.DisputeStatusUnderReview, nil},
+		{models.DisputeStatusResolved, models.DisputeStatusResolved, ErrInvalidDisputeState},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			d := newDisputeTest(t)
+			dispute := d.open(tt.status)
+
+			_, err := d.disputes.SubmitEvidence(dispute.ID, d.staff.ID, nil, "not a party")
+			assert.ErrorIs(t, err, ErrNotDisputeParty)
+
+			evidence, err := d.disputes.SubmitEvidence(dispute.ID, d.seller.ID, nil, "Shipped unworn")
+			stored, getErr := d.disputes.GetDispute(dispute.ID)
+			require.NoError(t, getErr)
+			assert.Equal(t, tt.wantStatus, stored.Status)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, stored.Evidence)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, d.seller.ID, evidence.SubmittedByID)
+			require.Len(t, stored.Evidence, 1)
+
+			action := d.lastAction(dispute)
+			assert.Equal(t, DisputeActionSubmitEvidence, action.Action)
+			assert.Equal(t, tt.status, action.FromStatus)
+			assert.Equal(t, tt.wantStatus, action.ToStatus)
+		})
+	}
+}
+
+func TestResolveDispute(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     string
+		input      ResolveDisputeInput
+		wantRefund string
+		wantErr    error
+	}{
+		{
+			name:       "full refund",
+			status:     models.DisputeStatusUnderReview,
+			input:      ResolveDisputeInput{Resolution: models.DisputeResolutionRefund},
+			wantRefund: "100000000000000000",
+		},
+		{
+			name:       "partial refund",
+			status:     models.DisputeStatusEvidenceRequested,
+			input:      ResolveDisputeInput{Resolution: models.DisputeResolutionPartialRefund, RefundAmountWei: "40000000000000000"},
+			wantRefund: "40000000000000000",
+		},
+		{
+			name:   "rejected",
+			status: models.DisputeStatusOpened,
+			input:  ResolveDisputeInput{Resolution: models.DisputeResolutionRejected, Note: "no evidence"},
+		},
+		{
+			name:    "partial refund of the whole price",
+			status:  models.DisputeStatusUnderReview,
+			input:   ResolveDisputeInput{Resolution: models.DisputeResolutionPartialRefund, RefundAmountWei: "100000000000000000"},
+			wantErr: ErrInvalidRefundAmount,
+		},
+		{
+			name:    "partial refund of nothing",
+			status:  models.DisputeStatusUnderReview,
+			input:   ResolveDisputeInput{Resolution: models.DisputeResolutionPartialRefund, RefundAmountWei: "0"},
+			wantErr: ErrInvalidRefundAmount,
+		},
+		{
+			name:    "partial refund without an amount",
+			status:  models.DisputeStatusUnderReview,
+			input:   ResolveDisputeInput{Resolution: models.DisputeResolutionPartialRefund},
+			wantErr: ErrInvalidRefundAmount,
+		},
+		{
+			name:    "unknown resolution",
+			status:  models.DisputeStatusUnderReview,
+			input:   ResolveDisputeInput{Resolution: "split"},
+			wantErr: ErrInvalidDisputeOutcome,
+		},
+		{
+			name:    "already resolved",
+			status:  models.DisputeStatusResolved,
+			input:   ResolveDisputeInput{Resolution: models.DisputeResolutionRefund},
+			wantErr: ErrInvalidDisputeState,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDisputeTest(t)
+			dispute := d.open(tt.status)
+
+			_, err := d.disputes.Resolve(dispute.ID, d.staff.ID, tt.input)
+			stored, getErr := d.disputes.GetDispute(dispute.ID)
+			require.NoError(t, getErr)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.status, stored.Status)
+				assert.Nil(t, stored.RefundTx)
+				assert.Equal(t, int64(0), d.count(&models.OperatorTransaction{}, "kind = ?", models.OperatorTxKindRefund))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, models.DisputeStatusResolved, stored.Status)
+			assert.Equal(t, tt.input.Resolution, stored.Resolution)
+			require.NotNil(t, stored.ResolvedByID)
+			assert.Equal(t, d.staff.ID, *stored.ResolvedByID)
+			assert.NotNil(t, stored.ResolvedAt)
+
+			action := d.lastAction(dispute)
+			assert.Equal(t, DisputeActionResolve, action.Action)
+			assert.Equal(t, tt.status, action.FromStatus)
+			assert.Contains(t, action.Note, tt.input.Resolution)
+
+			if tt.wantRefund == "" {
+				assert.Nil(t, stored.RefundTx)
+				assert.Equal(t, int64(0), d.count(&models.OperatorTransaction{}, "kind = ?", models.OperatorTxKindRefund))
+				return
+			}
+			assert.Equal(t, tt.wantRefund, stored.RefundAmountWei)
+			require.NotNil(t, stored.RefundTx)
+			assert.Equal(t, models.OperatorTxKindRefund, stored.RefundTx.Kind)
+			assert.Equal(t, models.OperatorTxStatusQueued, stored.RefundTx.Status)
+			assert.Equal(t, dispute.ID, stored.RefundTx.Reference)
+			assert.Equal(t, d.buyerWallet.Hex(), stored.RefundTx.ToAddress)
+			assert.Equal(t, tt.wantRefund, stored.RefundTx.ValueWei)
+			assert.Equal(t, d.chainID, stored.RefundTx.ChainID)
+		})
+	}
+}
@@ -0,0 +1,379 @@
+package services
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/yourusername/revibe/backend/config"
+	"github.com/yourusername/revibe/backend/models"
+	"gorm.io/gorm"
+)
+
+var ErrEvidenceFileMissing = errors.New("evidence photo not found in uploads")
+
+// unsafeArchiveChars are replaced in archive entry names
+var unsafeArchiveChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// EvidenceInput is an item to add to an evidence bundle. Photos reference
+// an upload by URL; measurements and notes carry their Content.
+type EvidenceInput struct {
+	Kind          string
+	Name          string
+	URL           string
+	Content       string
+	SubmittedByID *string
+}
+
+// EvidenceItemCheck is the result of re-hashing one evidence item
+type EvidenceItemCheck struct {
+	ItemID   string `json:"itemId"`
+	Name     string `json:"name"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual,omitempty"`
+	Valid    bool   `json:"valid"`
+	Error    string `json:"error,omitempty"`
+}
+
+// EvidenceVerification is the result of re-hashing an evidence bundle
+type EvidenceVerification struct {
+	Valid        bool                `json:"valid"`
+	MerkleRoot   string              `json:"merkleRoot"`
+	ComputedRoot string              `json:"computedRoot"`
+	Items        []EvidenceItemCheck `json:"items"`
+}
+
+// EvidenceManifest is written to an evidence archive alongside its files
+type EvidenceManifest struct {
+	AuthenticationID string                `json:"authenticationId"`
+	ProductID        string                `json:"productId"`
+	MerkleRoot       string                `json:"merkleRoot"`
+	Algorithm        string                `json:"algorithm"`
+	Items            []models.EvidenceItem `json:"items"`
+	GeneratedAt      time.Time             `json:"generatedAt"`
+}
+
+// EvidenceService builds and verifies the evidence bundles authentications
+// are based on
+type EvidenceService struct {
+	db        *gorm.DB
+	uploadDir string
+}
+
+// NewEvidenceService creates a new EvidenceService instance
+func NewEvidenceService(db *gorm.DB) *EvidenceService {
+	return &EvidenceService{
+		db:        db,
+		uploadDir: config.AppConfig.UploadDir,
+	}
+}
+
+// MerkleRoot computes the root of a binary SHA-256 Merkle tree over leaf
+// hashes. Each parent is SHA-256(left || right) and an unpaired node is
+// carried up unchanged. An empty tree has the hash of no data as its root.
+func MerkleRoot(leaves [][]byte) []byte {
+	if len(leaves) == 0 {
+		sum := sha256.Sum256(nil)
+		return sum[:]
+	}
+
+	level := leaves
+	for len(level) > 1 {
+		var next [][]byte
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			h := sha256.New()
+			h.Write(level[i])
+			h.Write(level[i+1])
+			next = append(next, h.Sum(nil))
+		}
+		level = next
+	}
+	return level[0]
+}
+
+// BundleRoot computes the Merkle root of a bundle's items from their hashes
+func BundleRoot(items []models.EvidenceItem) (string, error) {
+	leaves := make([][]byte, len(items))
+	for i, item := range items {
+		leaf, err := hex.DecodeString(item.SHA256)
+		if err != nil || len(leaf) != sha256.Size {
+			return "", fmt.Errorf("invalid hash for evidence item %q", item.Name)
+		}
+		leaves[i] = leaf
+	}
+	return hex.EncodeToString(MerkleRoot(leaves)), nil
+}
+
+// ProductPhotoEvidence lists a product's photos as evidence inputs
+func ProductPhotoEvidence(product *models.Product) []EvidenceInput {
+	inputs := make([]EvidenceInput, 0, len(product.Images))
+	for _, image := range product.Images {
+		inputs = append(inputs, EvidenceInput{
+			Kind: models.EvidenceKindPhoto,
+			Name: filepath.Base(image.URL),
+			URL:  image.URL,
+		})
+	}
+	return inputs
+}
+
+// ReviewEvidence lists a reviewer's findings, photos and measurements as evidence inputs
+func ReviewEvidence(review models.AuthenticationReview) ([]EvidenceInput, error) {
+	reviewerID := review.ReviewerID
+	inputs := []EvidenceInput{{
+		Kind:          models.EvidenceKindNote,
+		Name:          "findings",
+		Content:       review.Findings,
+		SubmittedByID: &reviewerID,
+	}}
+	for _, photo := range review.Photos {
+		inputs = append(inputs, EvidenceInput{
+			Kind:          models.EvidenceKindPhoto,
+			Name:          filepath.Base(photo),
+			URL:           photo,
+			SubmittedByID: &reviewerID,
+		})
+	}
+	for _, measurement := range review.Measurements {
+		content, err := json.Marshal(measurement)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode measurement: %v", err)
+		}
+		inputs = append(inputs, EvidenceInput{
+			Kind:          models.EvidenceKindMeasurement,
+			Name:          measurement.Name,
+			Content:       string(content),
+			SubmittedByID: &reviewerID,
+		})
+	}
+	return inputs, nil
+}
+
+func (s *EvidenceService) filePath(url string) string {
+	return filepath.Join(s.uploadDir, filepath.Clean("/"+UploadPath(url)))
+}
+
+// CheckPhotos confirms that photo URLs refer to stored uploads
+func (s *EvidenceService) CheckPhotos(urls []string) error {
+	for _, url := range urls {
+		if info, err := os.Stat(s.filePath(url)); err != nil || info.IsDir() {
+			return fmt.Errorf("%w: %s", ErrEvidenceFileMissing, url)
+		}
+	}
+	return nil
+}
+
+// CheckBundleFiles confirms that every photo in a bundle is still stored
+func (s *EvidenceService) CheckBundleFiles(bundle *models.EvidenceBundle) error {
+	var urls []string
+	for _, item := range bundle.Items {
+		if item.Kind == models.EvidenceKindPhoto {
+			urls = append(urls, item.URL)
+		}
+	}
+	return s.CheckPhotos(urls)
+}
+
+// hashItem hashes an item's file or content and returns the hash and size
+func (s *EvidenceService) hashItem(item *models.EvidenceItem) (string, int64, error) {
+	if item.Kind != models.EvidenceKindPhoto {
+		sum := sha256.Sum256([]byte(item.Content))
+		return hex.EncodeToString(sum[:]), int64(len(item.Content)), nil
+	}
+
+	file, err := os.Open(s.filePath(item.URL))
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: %s", ErrEvidenceFileMissing, item.URL)
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, file)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to hash %s: %v", item.URL, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// BuildBundle hashes evidence inputs into an unsaved bundle for a product
+func (s *EvidenceService) BuildBundle(productID string, inputs []EvidenceInput) (*models.EvidenceBundle, error) {
+	bundle := &models.EvidenceBundle{ProductID: productID}
+	for i, input := range inputs {
+		item := models.EvidenceItem{
+			Position:      i,
+			Kind:          input.Kind,
+			Name:          input.Name,
+			Content:       input.Content,
+			SubmittedByID: input.SubmittedByID,
+		}
+		switch input.Kind {
+		case models.EvidenceKindPhoto:
+			item.URL = UploadPath(input.URL)
+			item.ContentType = mime.TypeByExtension(filepath.Ext(item.URL))
+			if item.ContentType == "" {
+				item.ContentType = "application/octet-stream"
+			}
+		case models.EvidenceKindMeasurement:
+			item.ContentType = "application/json"
+		default:
+			item.ContentType = "text/plain; charset=utf-8"
+		}
+
+		hash, size, err := s.hashItem(&item)
+		if err != nil {
+			return nil, err
+		}
+		item.SHA256 = hash
+		item.Size = size
+		bundle.Items = append(bundle.Items, item)
+	}
+
+	root, err := BundleRoot(bundle.Items)
+	if err != nil {
+		return nil, err
+	}
+	bundle.MerkleRoot = root
+	return bundle, nil
+}
+
+// SaveBundle stores a bundle for an authentication in the caller's database
+// transaction and attaches it to the authentication
+func (s *EvidenceService) SaveBundle(tx *gorm.DB, auth *models.Authentication, bundle *models.EvidenceBundle) error {
+	bundle.AuthenticationID = auth.ID
+	if err := tx.Create(bundle).Error; err != nil {
+		return fmt.Errorf("failed to save evidence bundle: %v", err)
+	}
+	auth.Evidence = bundle
+	return nil
+}
+
+// GetBundle retrieves an authentication's evidence bundle with its items in order
+func (s *EvidenceService) GetBundle(authenticationID string) (*models.EvidenceBundle, error) {
+	var bundle models.EvidenceBundle
+	err := s.db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position asc")
+	}).First(&bundle, "authentication_id = ?", authenticationID).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &bundle, nil
+}
+
+// VerifyBundle re-hashes every item of an authentication's evidence bundle
+// and recomputes its Merkle root
+func (s *EvidenceService) VerifyBundle(authenticationID string) (*EvidenceVerification, error) {
+	bundle, err := s.GetBundle(authenticationID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EvidenceVerification{Valid: true, MerkleRoot: bundle.MerkleRoot}
+	current := make([]models.EvidenceItem, len(bundle.Items))
+	for i, item := range bundle.Items {
+		check := EvidenceItemCheck{ItemID: item.ID, Name: item.Name, Expected: item.SHA256}
+		hash, _, err := s.hashItem(&item)
+		if err != nil {
+			check.Error = err.Error()
+		} else {
+			check.Actual = hash
+			check.Valid = hash == item.SHA256
+		}
+		if !check.Valid {
+			result.Valid = false
+		}
+		result.Items = append(result.Items, check)
+
+		// Missing files count as an all-zero leaf so the root still differs
+		current[i] = item
+		current[i].SHA256 = hash
+		if hash == "" {
+			current[i].SHA256 = hex.EncodeToString(make([]byte, sha256.Size))
+		}
+	}
+
+	root, err := BundleRoot(current)
+	if err != nil {
+		return nil, err
+	}
+	result.ComputedRoot = root
+	if root != bundle.MerkleRoot {
+		result.Valid = false
+	}
+	return result, nil
+}
+
+// archiveName names an item's entry in an evidence archive
+func archiveName(item models.EvidenceItem) string {
+	name := unsafeArchiveChars.ReplaceAllString(item.Name, "_")
+	switch item.Kind {
+	case models.EvidenceKindMeasurement:
+		name += ".json"
+	case models.EvidenceKindNote:
+		name += ".txt"
+	}
+	return fmt.Sprintf("%03d-%s-%s", item.Position, item.Kind, name)
+}
+
+// WriteArchive writes a bundle as a zip archive of its items and a
+// manifest.json listing each item's hash and the Merkle root
+func (s *EvidenceService) WriteArchive(w io.Writer, bundle *models.EvidenceBundle) error {
+	archive := zip.NewWriter(w)
+
+	manifest, err := json.MarshalIndent(EvidenceManifest{
+		AuthenticationID: bundle.AuthenticationID,
+		ProductID:        bundle.ProductID,
+		MerkleRoot:       bundle.MerkleRoot,
+		Algorithm:        "sha256-merkle",
+		Items:            bundle.Items,
+		GeneratedAt:      time.Now().UTC(),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %v", err)
+	}
+	entry, err := archive.Create("manifest.json")
+	if err != nil {
+		return err
+	}
+	if _, err := entry.Write(manifest); err != nil {
+		return err
+	}
+
+	for _, item := range bundle.Items {
+		entry, err := archive.Create(archiveName(item))
+		if err != nil {
+			return err
+		}
+		if item.Kind != models.EvidenceKindPhoto {
+			if _, err := io.WriteString(entry, item.Content); err != nil {
+				return err
+			}
+			continue
+		}
+
+		file, err := os.Open(s.filePath(item.URL))
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrEvidenceFileMissing, item.URL)
+		}
+		_, err = io.Copy(entry, file)
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("failed to archive %s: %v", item.URL, err)
+		}
+	}
+
+	return archive.Close()
+}
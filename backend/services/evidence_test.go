@@ -0,0 +1,98 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/models"
+)
+
+func sha(data ...[]byte) []byte {
+	h := sha256.New()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+func TestMerkleRoot(t *testing.T) {
+	a, b, c := sha([]byte("a")), sha([]byte("b")), sha([]byte("c"))
+
+	assert.Equal(t, sha(), MerkleRoot(nil))
+	assert.Equal(t, a, MerkleRoot([][]byte{a}))
+	assert.Equal(t, sha(a, b), MerkleRoot([][]byte{a, b}))
+	// The unpaired leaf is carried up to the next level
+	assert.Equal(t, sha(sha(a, b), c), MerkleRoot([][]byte{a, b, c}))
+	assert.NotEqual(t, MerkleRoot([][]byte{a, b}), MerkleRoot([][]byte{b, a}))
+}
+
+func TestEvidenceBundle(t *testing.T) {
+	uploadDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(uploadDir, "products"), 0755))
+	photo := filepath.Join(uploadDir, "products", "front.jpg")
+	require.NoError(t, os.WriteFile(photo, []byte("jpeg bytes"), 0644))
+
+	service := &EvidenceService{uploadDir: uploadDir}
+	reviewerID := "reviewer-1"
+	inputs, err := ReviewEvidence(models.AuthenticationReview{
+		ReviewerID:   reviewerID,
+		Findings:     "Stitching matches retail",
+		Photos:       []string{"http://localhost:8080/uploads/products/front.jpg"},
+		Measurements: []models.Measurement{{Name: "insole", Value: 27.5, Unit: "cm"}},
+	})
+	require.NoError(t, err)
+
+	bundle, err := service.BuildBundle("product-1", inputs)
+	require.NoError(t, err)
+	require.Len(t, bundle.Items, 3)
+
+	photoItem := bundle.Items[1]
+	assert.Equal(t, models.EvidenceKindPhoto, photoItem.Kind)
+	assert.Equal(t, "products/front.jpg", photoItem.URL)
+	assert.Equal(t, hex.EncodeToString(sha([]byte("jpeg bytes"))), photoItem.SHA256)
+	assert.Equal(t, `{"name":"insole","value":27.5,"unit":"cm"}`, bundle.Items[2].Content)
+
+	root, err := BundleRoot(bundle.Items)
+	require.NoError(t, err)
+	assert.Equal(t, root, bundle.MerkleRoot)
+
+	t.Run("Tampering Changes Hash", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(photo, []byte("edited bytes"), 0644))
+		defer os.WriteFile(photo, []byte("jpeg bytes"), 0644)
+
+		hash, _, err := service.hashItem(&photoItem)
+		require.NoError(t, err)
+		assert.NotEqual(t, photoItem.SHA256, hash)
+	})
+
+	t.Run("Missing Photo", func(t *testing.T) {
+		err := service.CheckPhotos([]string{"products/missing.jpg"})
+		assert.ErrorIs(t, err, ErrEvidenceFileMissing)
+	})
+
+	t.Run("Archive", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, service.WriteArchive(&buf, bundle))
+
+		archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+
+		var names []string
+		for _, file := range archive.File {
+			names = append(names, file.Name)
+		}
+		assert.Equal(t, []string{
+			"manifest.json",
+			"000-note-findings.txt",
+			"001-photo-front.jpg",
+			"002-measurement-insole.json",
+		}, names)
+	})
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const rateDateLayout = "2006-01-02"
+
+// weiPerEther is the number of wei in one ether
+var weiPerEther = new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+
+// FiatRate is the value of one ether in fiat on a given day
+type FiatRate struct {
+	Date time.Time
+	Rate *big.Rat
+}
+
+// FiatRateTable holds daily ether to fiat rates read from a local file. It
+// is kept offline so reports are reproducible for tax filing.
+type FiatRateTable struct {
+	Currency string
+	rates    []FiatRate
+}
+
+type fiatRateFile struct {
+	Currency string `json:"currency"`
+	Rates    []struct {
+		Date string `json:"date"`
+		Rate string `json:"rate"`
+	} `json:"rates"`
+}
+
+// LoadFiatRateTable reads a rate table laid out as
+// {"currency": "USD", "rates": [{"date": "2024-03-01", "rate": "3400.50"}]}
+func LoadFiatRateTable(path string) (*FiatRateTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fiat rate table: %v", err)
+	}
+
+	var file fiatRateFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid fiat rate table: %v", err)
+	}
+
+	table := &FiatRateTable{Currency: strings.ToUpper(file.Currency)}
+	for _, r := range file.Rates {
+		date, err := time.Parse(rateDateLayout, r.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q in fiat rate table", r.Date)
+		}
+		rate, ok := new(big.Rat).SetString(r.Rate)
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid rate %q in fiat rate table", r.Rate)
+		}
+		table.rates = append(table.rates, FiatRate{Date: date, Rate: rate})
+	}
+
+	sort.Slice(table.rates, func(i, j int) bool {
+		return table.rates[i].Date.Before(table.rates[j].Date)
+	})
+
+	return table, nil
+}
+
+// RateAt returns the rate for the day of t, falling back to the latest
+// earlier day in the table. It returns nil if the table has no such rate.
+func (t *FiatRateTable) RateAt(at time.Time) *big.Rat {
+	if t == nil {
+		return nil
+	}
+
+	day := at.UTC().Truncate(24 * time.Hour)
+	i := sort.Search(len(t.rates), func(i int) bool {
+		return t.rates[i].Date.After(day)
+	})
+	if i == 0 {
+		return nil
+	}
+	return t.rates[i-1].Rate
+}
+
+// WeiToEther converts an amount in wei to ether
+func WeiToEther(wei *big.Int) *big.Rat {
+	return new(big.Rat).Quo(new(big.Rat).SetInt(wei), weiPerEther)
+}
+
+// FormatEther formats an amount in wei as a decimal ether string without
+// trailing zeros
+func FormatEther(wei *big.Int) string {
+	s := WeiToEther(wei).FloatString(18)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
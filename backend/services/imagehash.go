@@ -0,0 +1,467 @@
+package services
+
+import (
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/revibe/backend/config"
+	"github.com/yourusername/revibe/backend/models"
+	"gorm.io/gorm"
+)
+
+// ImageHashService computes and indexes perceptual hashes of uploaded images
+// so that listings re-using another seller's photos can be detected
+type ImageHashService struct {
+	db          *gorm.DB
+	uploadDir   string
+	maxDistance int
+}
+
+// NewImageHashService creates a new ImageHashService instance
+func NewImageHashService(db *gorm.DB) *ImageHashService {
+	return &ImageHashService{
+		db:          db,
+		uploadDir:   config.AppConfig.UploadDir,
+		maxDistance: config.AppConfig.ImageHashMaxDistance,
+	}
+}
+
+// DifferenceHash computes a 64-bit dHash of an image. The image is reduced to
+// a 9x8 grayscale grid and each bit records whether a cell is darker than its
+// right-hand neighbour, which survives resizing, re-compression and small
+// colour adjustments.
+func DifferenceHash(img image.Image) uint64 {
+	const width, height = 9, 8
+
+	bounds := img.Bounds()
+	var grid [height][width]float64
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := bounds.Min.Y + (y+1)*bounds.Dy()/height
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := bounds.Min.X + (x+1)*bounds.Dx()/width
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			// Average the luminance of every pixel in the cell
+			var sum float64
+			for py := y0; py < y1; py++ {
+				for px := x0; px < x1; px++ {
+					r, g, b, _ := img.At(px, py).RGBA()
+					sum += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+				}
+			}
+			grid[y][x] = sum / float64((x1-x0)*(y1-y0))
+		}
+	}
+
+	var hash uint64
+	for y := 0; y < height; y++ {
+		for x := 0; x < width-1; x++ {
+			hash <<= 1
+			if grid[y][x] < grid[y][x+1] {
+				hash |= 1
+			}
+		}
+	}
+
+	return hash
+}
+
+// HammingDistance returns the number of differing bits between two hashes
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// FormatImageHash encodes a hash for storage
+func FormatImageHash(hash uint64) string {
+	return fmt.Sprintf("%016x", hash)
+}
+
+// ParseImageHash decodes a stored hash
+func ParseImageHash(value string) (uint64, error) {
+	hash, err := strconv.ParseUint(value, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid image hash: %s", value)
+	}
+	return hash, nil
+}
+
+// UploadPath converts a public file URL into the path relative to the upload
+// directory, which is how image URLs are stored in the database
+func UploadPath(url string) string {
+	if idx := strings.Index(url, "/uploads/"); idx != -1 {
+		return url[idx+len("/uploads/"):]
+	}
+	return strings.TrimPrefix(url, "/")
+}
+
+// HashFile computes the perceptual hash of a stored upload
+func (s *ImageHashService) HashFile(path string) (uint64, error) {
+	file, err := os.Open(filepath.Join(s.uploadDir, filepath.Clean("/"+path)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to open image: %v", err)
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode image: %v", err)
+	}
+
+	return DifferenceHash(img), nil
+}
+
+// Perceptual hashes are split into hashBands bands of 16 bits for lookup.
+// Two hashes within distance d of each other differ by at most d/hashBands
+// bits on at least one band, so near-duplicates are found by looking up the
+// band values close to each of a hash's bands. Beyond maxBandRadius the
+// lookup would match most of the index and the bands are not used.
+const (
+	hashBands     = 4
+	maxBandRadius = 3
+)
+
+// hashBandValues splits a hash into its bands, most significant first
+func hashBandValues(hash uint64) [hashBands]int {
+	var bands [hashBands]int
+	for i := range bands {
+		bands[i] = int(hash >> (16 * (hashBands - 1 - i)) & 0xffff)
+	}
+	return bands
+}
+
+// nearbyBandValues returns every band value within radius bits of value,
+// including value itself
+func nearbyBandValues(value, radius int) []int {
+	values := []int{value}
+	var flip func(from, left, current int)
+	flip = func(from, left, current int) {
+		for bit := from; bit < 16; bit++ {
+			next := current ^ 1<<bit
+			values = append(values, next)
+			if left > 1 {
+				flip(bit+1, left-1, next)
+			}
+		}
+	}
+	if radius > 0 {
+		flip(0, radius, value)
+	}
+	return values
+}
+
+// setBands stores a hash's bands on its index record
+func setBands(record *models.ImageHash, hash uint64) {
+	bands := hashBandValues(hash)
+	record.Band0, record.Band1, record.Band2, record.Band3 = &bands[0], &bands[1], &bands[2], &bands[3]
+}
+
+// IndexBands fills in the bands of hashes indexed before they were stored
+func (s *ImageHashService) IndexBands() error {
+	var records []models.ImageHash
+	return s.db.Where("band0 IS NULL").FindInBatches(&records, 500, func(tx *gorm.DB, batch int) error {
+		for i := range records {
+			hash, err := ParseImageHash(records[i].Hash)
+			if err != nil {
+				continue
+			}
+			setBands(&records[i], hash)
+			if err := tx.Save(&records[i]).Error; err != nil {
+				return fmt.Errorf("failed to save image hash bands: %v", err)
+			}
+		}
+		return nil
+	}).Error
+}
+
+// IndexFile hashes a stored upload and records it in the index
+func (s *ImageHashService) IndexFile(path, uploaderID string) (*models.ImageHash, error) {
+	return s.indexFile(s.db, path, uploaderID)
+}
+
+func (s *ImageHashService) indexFile(db *gorm.DB, path, uploaderID string) (*models.ImageHash, error) {
+	path = UploadPath(path)
+
+	hash, err := s.HashFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var record models.ImageHash
+	err = db.Where("url = ?", path).First(&record).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, fmt.Errorf("failed to fetch image hash: %v", err)
+	}
+
+	record.URL = path
+	record.Hash = FormatImageHash(hash)
+	setBands(&record, hash)
+	if uploaderID != "" {
+		record.UploaderID = &uploaderID
+	}
+
+	if err := db.Save(&record).Error; err != nil {
+		return nil, fmt.Errorf("failed to save image hash: %v", err)
+	}
+
+	return &record, nil
+}
+
+// FindMatches compares a product's images against images on listings from
+// other sellers and returns any near-duplicates. Images that were not indexed
+// at upload time are indexed on the fly.
+func (s *ImageHashService) FindMatches(productID, sellerID string, urls []string) ([]models.ImageMatch, error) {
+	return s.findMatches(s.db, productID, sellerID, urls)
+}
+
+func (s *ImageHashService) findMatches(db *gorm.DB, productID, sellerID string, urls []string) ([]models.ImageMatch, error) {
+	var matches []models.ImageMatch
+	for _, url := range urls {
+		path := UploadPath(url)
+
+		var record models.ImageHash
+		if err := db.Where("url = ?", path).First(&record).Error; err != nil {
+			if err != gorm.ErrRecordNotFound {
+				return nil, fmt.Errorf("failed to fetch image hash: %v", err)
+			}
+			indexed, err := s.indexFile(db, path, sellerID)
+			if err != nil {
+				// Remote or unreadable images cannot be compared
+				continue
+			}
+			record = *indexed
+		}
+
+		hash, err := ParseImageHash(record.Hash)
+		if err != nil {
+			continue
+		}
+
+		candidates, err := s.candidates(db, hash, sellerID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range candidates {
+			other, err := ParseImageHash(c.Hash)
+			if err != nil {
+				continue
+			}
+			if distance := HammingDistance(hash, other); distance <= s.maxDistance {
+				matches = append(matches, models.ImageMatch{
+					ProductID:        productID,
+					ImageURL:         path,
+					MatchedProductID: c.ProductID,
+					MatchedImageURL:  c.URL,
+					Distance:         distance,
+					Status:           models.ImageMatchStatusOpen,
+				})
+			}
+		}
+	}
+
+	return matches, nil
+}
+
+type imageCandidate struct {
+	URL       string
+	Hash      string
+	ProductID string
+}
+
+// candidates returns the images on other sellers' listings whose bands are
+// close enough to hash's for the two to be within the match distance
+func (s *ImageHashService) candidates(db *gorm.DB, hash uint64, sellerID string) ([]imageCandidate, error) {
+	query := db.Table("image_hashes").
+		Select("image_hashes.url, image_hashes.hash, product_images.product_id").
+		Joins("JOIN product_images ON product_images.url = image_hashes.url").
+		Joins("JOIN products ON products.id = product_images.product_id").
+		Where("products.seller_id <> ? AND products.deleted_at IS NULL", sellerID)
+
+	if radius := s.maxDistance / hashBands; radius <= maxBandRadius {
+		bands := hashBandValues(hash)
+		near := db.Where("image_hashes.band0 IN ?", nearbyBandValues(bands[0], radius))
+		for i := 1; i < hashBands; i++ {
+			near = near.Or(fmt.Sprintf("image_hashes.band%d IN ?", i), nearbyBandValues(bands[i], radius))
+		}
+		query = query.Where(near)
+	}
+
+	var candidates []imageCandidate
+	if err := query.Scan(&candidates).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch image hashes: %v", err)
+	}
+	return candidates, nil
+}
+
+// FlagProduct records the matches and sends the listing to moderation
+func (s *ImageHashService) FlagProduct(productID string, matches []models.ImageMatch) error {
+	return s.flagProduct(s.db, productID, matches)
+}
+
+func (s *ImageHashService) flagProduct(db *gorm.DB, productID string, matches []models.ImageMatch) error {
+	if len(matches) == 0 {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&matches).Error; err != nil {
+			return fmt.Errorf("failed to save image matches: %v", err)
+		}
+
+		if err := tx.Model(&models.Product{}).
+			Where("id = ?", productID).
+			Update("moderation_status", models.ModerationStatusPendingReview).Error; err != nil {
+			return fmt.Errorf("failed to flag product: %v", err)
+		}
+
+		return nil
+	})
+}
+
+// SetProductImages replaces a product's images within tx, checks them
+// against other sellers' listings and returns the matches found, which send
+// the listing to moderation. Matches a moderator already dismissed are not
+// raised again. A listing that was held only for matches on images it no
+// longer uses is approved, as when its last match is dismissed.
+func (s *ImageHashService) SetProductImages(tx *gorm.DB, productID, sellerID string, urls []string) ([]models.ImageMatch, error) {
+	if err := tx.Where("product_id = ?", productID).Delete(&models.ProductImage{}).Error; err != nil {
+		return nil, fmt.Errorf("failed to remove product images: %v", err)
+	}
+	for _, url := range urls {
+		image := models.ProductImage{ProductID: productID, URL: UploadPath(url)}
+		if err := tx.Create(&image).Error; err != nil {
+			return nil, fmt.Errorf("failed to save product image: %v", err)
+		}
+	}
+
+	// Check the new images from scratch
+	cleared := tx.Where("product_id = ? AND status = ?", productID, models.ImageMatchStatusOpen).
+		Delete(&models.ImageMatch{})
+	if cleared.Error != nil {
+		return nil, fmt.Errorf("failed to clear image matches: %v", cleared.Error)
+	}
+
+	found, err := s.findMatches(tx, productID, sellerID, urls)
+	if err != nil {
+		return nil, err
+	}
+
+	var dismissed []models.ImageMatch
+	if err := tx.Where("product_id = ? AND status = ?", productID, models.ImageMatchStatusDismissed).
+		Find(&dismissed).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch image matches: %v", err)
+	}
+	var matches []models.ImageMatch
+	for _, match := range found {
+		seen := false
+		for _, d := range dismissed {
+			if d.ImageURL == match.ImageURL && d.MatchedImageURL == match.MatchedImageURL {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			matches = append(matches, match)
+		}
+	}
+
+	if len(matches) > 0 {
+		if err := s.flagProduct(tx, productID, matches); err != nil {
+			return nil, err
+		}
+		return matches, nil
+	}
+
+	if cleared.RowsAffected > 0 {
+		if err := tx.Model(&models.Product{}).
+			Where("id = ? AND moderation_status = ?", productID, models.ModerationStatusPendingReview).
+			Update("moderation_status", models.ModerationStatusApproved).Error; err != nil {
+			return nil, fmt.Errorf("failed to approve product: %v", err)
+		}
+	}
+	return nil, nil
+}
+
+// ResolveMatch closes an image match. Confirming a match rejects the listing;
+// dismissing it approves the listing once no other open matches remain.
+func (s *ImageHashService) ResolveMatch(matchID, reviewerID string, confirm bool) (*models.ImageMatch, error) {
+	var match models.ImageMatch
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&match, "id = ?", matchID).Error; err != nil {
+			return err
+		}
+		if match.Status != models.ImageMatchStatusOpen {
+			return fmt.Errorf("image match already resolved")
+		}
+
+		now := time.Now()
+		match.Status = models.ImageMatchStatusDismissed
+		if confirm {
+			match.Status = models.ImageMatchStatusConfirmed
+		}
+		match.ResolvedBy = &reviewerID
+		match.ResolvedAt = &now
+		if err := tx.Save(&match).Error; err != nil {
+			return fmt.Errorf("failed to update image match: %v", err)
+		}
+
+		if confirm {
+			return tx.Model(&models.Product{}).
+				Where("id = ?", match.ProductID).
+				Update("moderation_status", models.ModerationStatusRejected).Error
+		}
+
+		var open int64
+		if err := tx.Model(&models.ImageMatch{}).
+			Where("product_id = ? AND status = ?", match.ProductID, models.ImageMatchStatusOpen).
+			Count(&open).Error; err != nil {
+			return err
+		}
+		if open == 0 {
+			return tx.Model(&models.Product{}).
+				Where("id = ? AND moderation_status = ?", match.ProductID, models.ModerationStatusPendingReview).
+				Update("moderation_status", models.ModerationStatusApproved).Error
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &match, nil
+}
+
+// GetOpenMatches returns unresolved matches with both listings loaded so
+// they can be reviewed side by side
+func (s *ImageHashService) GetOpenMatches() ([]models.ImageMatch, error) {
+	var matches []models.ImageMatch
+	err := s.db.Preload("Product.Seller").
+		Preload("Product.Images").
+		Preload("MatchedProduct.Seller").
+		Preload("MatchedProduct.Images").
+		Where("status = ?", models.ImageMatchStatusOpen).
+		Order("created_at desc").
+		Find(&matches).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch image matches: %v", err)
+	}
+
+	return matches, nil
+}
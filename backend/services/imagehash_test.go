@@ -0,0 +1,414 @@
+package services
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/models"
+	"gorm.io/gorm"
+)
+
+func newGradientImage(width, height int, brightness uint8, reverse bool) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			v := uint8(x * 200 / width)
+			if reverse {
+				v = 200 - v
+			}
+			v += uint8(y*40/height) + brightness
+			img.Set(x, y, color.RGBA{R: v, G: v, B: v, A: 255})
+		}
+	}
+	return img
+}
+
+func TestDifferenceHash(t *testing.T) {
+	original := newGradientImage(640, 480, 0, false)
+
+	t.Run("ResizedCopy", func(t *testing.T) {
+		resized := newGradientImage(320, 240, 0, false)
+		distance := HammingDistance(DifferenceHash(original), DifferenceHash(resized))
+		assert.LessOrEqual(t, distance, 2)
+	})
+
+	t.Run("BrightenedCopy", func(t *testing.T) {
+		brightened := newGradientImage(640, 480, 10, false)
+		distance := HammingDistance(DifferenceHash(original), DifferenceHash(brightened))
+		assert.LessOrEqual(t, distance, 2)
+	})
+
+	t.Run("DifferentImage", func(t *testing.T) {
+		different := newGradientImage(640, 480, 0, true)
+		distance := HammingDistance(DifferenceHash(original), DifferenceHash(different))
+		assert.Greater(t, distance, 32)
+	})
+
+	t.Run("FormatAndParse", func(t *testing.T) {
+		hash := DifferenceHash(original)
+		parsed, err := ParseImageHash(FormatImageHash(hash))
+		assert.NoError(t, err)
+		assert.Equal(t, hash, parsed)
+	})
+
+	t.Run("UploadPath", func(t *testing.T) {
+		assert.Equal(t, "products/a.jpg", UploadPath("http://localhost:8080/uploads/products/a.jpg"))
+		assert.Equal(t, "products/a.jpg", UploadPath("/uploads/products/a.jpg"))
+		assert.Equal(t, "products/a.jpg", UploadPath("products/a.jpg"))
+	})
+}
+
+func TestHashBands(t *testing.T) {
+	bands := hashBandValues(0x0123456789abcdef)
+	assert.Equal(t, [hashBands]int{0x0123, 0x4567, 0x89ab, 0xcdef}, bands)
+
+	// Every value within the radius, each once
+	for radius, want := range []int{1, 17, 137, 697} {
+		values := nearbyBandValues(0x5a5a, radius)
+		assert.Len(t, values, want, "radius %d", radius)
+		seen := map[int]bool{}
+		for _, v := range values {
+			assert.LessOrEqual(t, HammingDistance(uint64(v), 0x5a5a), radius)
+			assert.False(t, seen[v], "value %x repeated", v)
+			seen[v] = true
+		}
+	}
+}
+
+// imageHashTest is an image hash service over a test database with uploads
+// written to a temp dir
+type imageHashTest struct {
+	t       *testing.T
+	db      *gorm.DB
+	service *ImageHashService
+	seller  *models.User
+	other   *models.User
+}
+
+func newImageHashTest(t *testing.T) *imageHashTest {
+	db := newTestDB(t)
+	return &imageHashTest{
+		t:       t,
+		db:      db,
+		service: &ImageHashService{db: db, uploadDir: t.TempDir(), maxDistance: 10},
+		seller:  createUser(t, db, "0x5e11e500000000000000000000000000000000aa"),
+		other:   createUser(t, db, "0x07e4000000000000000000000000000000000bbb"),
+	}
+}
+
+// upload writes an image to the upload dir and returns its public URL
+func (h *imageHashTest) upload(name string, img image.Image) string {
+	path := filepath.Join(h.service.uploadDir, "products", name)
+	require.NoError(h.t, os.MkdirAll(filepath.Dir(path), 0755))
+	file, err := os.Create(path)
+	require.NoError(h.t, err)
+	defer file.Close()
+	require.NoError(h.t, png.Encode(file, img))
+	return "http://localhost:8080/uploads/products/" + name
+}
+
+// listing creates an approved product for seller with the given images
+func (h *imageHashTest) listing(seller *models.User, urls ...string) *models.Product {
+	product := createProduct(h.t, h.db, seller)
+	for _, url := range urls {
+		require.NoError(h.t, h.db.Create(&models.ProductImage{ProductID: product.ID, URL: UploadPath(url)}).Error)
+		_, err := h.service.IndexFile(url, seller.ID)
+		require.NoError(h.t, err)
+	}
+	return product
+}
+
+// indexHash records a made-up hash for an image on a product
+func (h *imageHashTest) indexHash(product *models.Product, url string, hash uint64) {
+	record := models.ImageHash{URL: url, Hash: FormatImageHash(hash)}
+	setBands(&record, hash)
+	require.NoError(h.t, h.db.Create(&record).Error)
+	require.NoError(h.t, h.db.Create(&models.ProductImage{ProductID: product.ID, URL: url}).Error)
+}
+
+func (h *imageHashTest) moderationStatus(productID string) string {
+	var product models.Product
+	require.NoError(h.t, h.db.First(&product, "id = ?", productID).Error)
+	return product.ModerationStatus
+}
+
+func (h *imageHashTest) openMatches(productID string) int64 {
+	var n int64
+	require.NoError(h.t, h.db.Model(&models.ImageMatch{}).
+		Where("product_id = ? AND status = ?", productID, models.ImageMatchStatusOpen).
+		Count(&n).Error)
+	return n
+}
+
+func TestFindMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		image    image.Image
+		ownPhoto bool
+		deleted  bool
+		match    bool
+	}{
+		{name: "same photo", image: newGradientImage(640, 480, 0, false), match: true},
+		{name: "resized copy", image: newGradientImage(320, 240, 0, false), match: true},
+		{name: "brightened copy", image: newGradientImage(640, 480, 10, false), match: true},
+		{name: "different photo", image: newGradientImage(640, 480, 0, true)},
+		{name: "seller's own photo", image: newGradientImage(640, 480, 0, false), ownPhoto: true},
+		{name: "deleted listing", image: newGradientImage(640, 480, 0, false), deleted: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newImageHashTest(t)
+			matched := h.listing(h.other, h.upload("original.png", newGradientImage(640, 480, 0, false)))
+			if tt.deleted {
+				require.NoError(t, h.db.Delete(matched).Error)
+			}
+			seller := h.seller
+			if tt.ownPhoto {
+				seller = h.other
+			}
+
+			// Not indexed at upload, so indexed on the fly
+			url := h.upload("new.png", tt.image)
+			matches, err := h.service.FindMatches("new-product", seller.ID, []string{url})
+			require.NoError(t, err)
+
+			if !tt.match {
+				assert.Empty(t, matches)
+				return
+			}
+			require.Len(t, matches, 1)
+			assert.Equal(t, "new-product", matches[0].ProductID)
+			assert.Equal(t, "products/new.png", matches[0].ImageURL)
+			assert.Equal(t, matched.ID, matches[0].MatchedProductID)
+			assert.Equal(t, "products/original.png", matches[0].MatchedImageURL)
+			assert.Equal(t, models.ImageMatchStatusOpen, matches[0].Status)
+
+			var record models.ImageHash
+			require.NoError(t, h.db.First(&record, "url = ?", "products/new.png").Error)
+			assert.NotNil(t, record.Band0)
+		})
+	}
+
+	t.Run("unreadable image", func(t *testing.T) {
+		h := newImageHashTest(t)
+		matches, err := h.service.FindMatches("new-product", h.seller.ID, []string{"https://elsewhere.example/a.jpg"})
+		require.NoError(t, err)
+		assert.Empty(t, matches)
+	})
+}
+
+func TestFindMatchesUsesBands(t *testing.T) {
+	const hash = uint64(0x0123456789abcdef)
+
+	tests := []struct {
+		name  string
+		flips []int
+		match bool
+	}{
+		// The match distance spread as evenly as possible over the bands, so
+		// no band agrees exactly
+		{name: "distance spread over every band", flips: []int{0, 1, 16, 17, 18, 32, 33, 48, 49, 50}, match: true},
+		{name: "distance in one band", flips: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, match: true},
+		{name: "just over the distance", flips: []int{0, 1, 2, 16, 17, 18, 32, 33, 48, 49, 50}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newImageHashTest(t)
+			other := hash
+			for _, bit := range tt.flips {
+				other ^= 1 << bit
+			}
+			h.indexHash(h.listing(h.other), "products/other.png", other)
+			h.indexHash(createProduct(t, h.db, h.seller), "products/new.png", hash)
+
+			matches, err := h.service.FindMatches("new-product", h.seller.ID, []string{"products/new.png"})
+			require.NoError(t, err)
+			if tt.match {
+				require.Len(t, matches, 1)
+				assert.Equal(t, len(tt.flips), matches[0].Distance)
+			} else {
+				assert.Empty(t, matches)
+			}
+		})
+	}
+
+	t.Run("far hashes are not fetched", func(t *testing.T) {
+		h := newImageHashTest(t)
+		h.indexHash(h.listing(h.other), "products/other.png", ^hash)
+		candidates, err := h.service.candidates(h.db, hash, h.seller.ID)
+		require.NoError(t, err)
+		assert.Empty(t, candidates)
+	})
+}
+
+func TestIndexBands(t *testing.T) {
+	h := newImageHashTest(t)
+	require.NoError(t, h.db.Create(&models.ImageHash{URL: "products/old.png", Hash: "0123456789abcdef"}).Error)
+
+	require.NoError(t, h.service.IndexBands())
+
+	var record models.ImageHash
+	require.NoError(t, h.db.First(&record, "url = ?", "products/old.png").Error)
+	require.NotNil(t, record.Band0)
+	assert.Equal(t, []int{0x0123, 0x4567, 0x89ab, 0xcdef}, []int{*record.Band0, *record.Band1, *record.Band2, *record.Band3})
+}
+
+func TestFlagProduct(t *testing.T) {
+	h := newImageHashTest(t)
+	original := h.listing(h.other)
+	product := createProduct(t, h.db, h.seller)
+
+	require.NoError(t, h.service.FlagProduct(product.ID, nil))
+	assert.Equal(t, models.ModerationStatusApproved, h.moderationStatus(product.ID))
+
+	require.NoError(t, h.service.FlagProduct(product.ID, []models.ImageMatch{{
+		ProductID:        product.ID,
+		ImageURL:         "products/new.png",
+		MatchedProductID: original.ID,
+		MatchedImageURL:  "products/original.png",
+		Distance:         1,
+		Status:           models.ImageMatchStatusOpen,
+	}}))
+	assert.Equal(t, models.ModerationStatusPendingReview, h.moderationStatus(product.ID))
+	assert.Equal(t, int64(1), h.openMatches(product.ID))
+}
+
+func TestSetProductImages(t *testing.T) {
+	h := newImageHashTest(t)
+	stolen := h.upload("original.png", newGradientImage(640, 480, 0, false))
+	h.listing(h.other, stolen)
+	copied := h.upload("copy.png", newGradientImage(320, 240, 0, false))
+	own := h.upload("own.png", newGradientImage(640, 480, 0, true))
+
+	setImages := func(product *models.Product, urls ...string) []models.ImageMatch {
+		var matches []models.ImageMatch
+		require.NoError(t, h.db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			matches, err = h.service.SetProductImages(tx, product.ID, h.seller.ID, urls)
+			return err
+		}))
+		return matches
+	}
+	images := func(product *models.Product) []string {
+		var urls []string
+		require.NoError(t, h.db.Model(&models.ProductImage{}).Where("product_id = ?", product.ID).
+			Order("url").Pluck("url", &urls).Error)
+		return urls
+	}
+
+	t.Run("clean images", func(t *testing.T) {
+		product := createProduct(t, h.db, h.seller)
+		assert.Empty(t, setImages(product, own))
+		assert.Equal(t, []string{"products/own.png"}, images(product))
+		assert.Equal(t, models.ModerationStatusApproved, h.moderationStatus(product.ID))
+	})
+
+	t.Run("replaced with another seller's photo", func(t *testing.T) {
+		product := createProduct(t, h.db, h.seller)
+		setImages(product, own)
+
+		matches := setImages(product, own, copied)
+		require.Len(t, matches, 1)
+		assert.Equal(t, "products/copy.png", matches[0].ImageURL)
+		assert.Equal(t, []string{"products/copy.png", "products/own.png"}, images(product))
+		assert.Equal(t, models.ModerationStatusPendingReview, h.moderationStatus(product.ID))
+		assert.Equal(t, int64(1), h.openMatches(product.ID))
+
+		// Saving the same images again does not duplicate the match
+		setImages(product, own, copied)
+		assert.Equal(t, int64(1), h.openMatches(product.ID))
+
+		// Removing the photo clears the match and approves the listing
+		assert.Empty(t, setImages(product, own))
+		assert.Equal(t, int64(0), h.openMatches(product.ID))
+		assert.Equal(t, models.ModerationStatusApproved, h.moderationStatus(product.ID))
+	})
+
+	t.Run("dismissed match", func(t *testing.T) {
+		product := createProduct(t, h.db, h.seller)
+		matches := setImages(product, copied)
+		require.Len(t, matches, 1)
+
+		var match models.ImageMatch
+		require.NoError(t, h.db.First(&match, "product_id = ?", product.ID).Error)
+		_, err := h.service.ResolveMatch(match.ID, h.other.ID, false)
+		require.NoError(t, err)
+
+		assert.Empty(t, setImages(product, copied, own))
+		assert.Equal(t, models.ModerationStatusApproved, h.moderationStatus(product.ID))
+	})
+
+	t.Run("held for other reasons", func(t *testing.T) {
+		product := createProduct(t, h.db, h.seller)
+		require.NoError(t, h.db.Model(product).Update("moderation_status", models.ModerationStatusPendingReview).Error)
+
+		assert.Empty(t, setImages(product, own))
+		assert.Equal(t, models.ModerationStatusPendingReview, h.moderationStatus(product.ID))
+	})
+}
+
+func TestResolveMatch(t *testing.T) {
+	newMatches := func(h *imageHashTest) (*models.Product, []models.ImageMatch) {
+		original := h.listing(h.other)
+		product := createProduct(h.t, h.db, h.seller)
+		matches := []models.ImageMatch{
+			{ProductID: product.ID, ImageURL: "products/a.png", MatchedProductID: original.ID, MatchedImageURL: "products/b.png", Distance: 2, Status: models.ImageMatchStatusOpen},
+			{ProductID: product.ID, ImageURL: "products/c.png", MatchedProductID: original.ID, MatchedImageURL: "products/d.png", Distance: 4, Status: models.ImageMatchStatusOpen},
+		}
+		require.NoError(h.t, h.service.FlagProduct(product.ID, matches))
+		return product, matches
+	}
+
+	tests := []struct {
+		name    string
+		confirm []bool
+		status  string
+	}{
+		{name: "confirmed", confirm: []bool{true}, status: models.ModerationStatusRejected},
+		{name: "one of two dismissed", confirm: []bool{false}, status: models.ModerationStatusPendingReview},
+		{name: "both dismissed", confirm: []bool{false, false}, status: models.ModerationStatusApproved},
+		{name: "confirmed then dismissed", confirm: []bool{true, false}, status: models.ModerationStatusRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newImageHashTest(t)
+			product, matches := newMatches(h)
+			admin := createUser(t, h.db, "0xad000000000000000000000000000000000000aa")
+
+			for i, confirm := range tt.confirm {
+				resolved, err := h.service.ResolveMatch(matches[i].ID, admin.ID, confirm)
+				require.NoError(t, err)
+				want := models.ImageMatchStatusDismissed
+				if confirm {
+					want = models.ImageMatchStatusConfirmed
+				}
+				assert.Equal(t, want, resolved.Status)
+				require.NotNil(t, resolved.ResolvedBy)
+				assert.Equal(t, admin.ID, *resolved.ResolvedBy)
+				assert.NotNil(t, resolved.ResolvedAt)
+			}
+			assert.Equal(t, tt.status, h.moderationStatus(product.ID))
+		})
+	}
+
+	t.Run("already resolved", func(t *testing.T) {
+		h := newImageHashTest(t)
+		_, matches := newMatches(h)
+		_, err := h.service.ResolveMatch(matches[0].ID, h.other.ID, false)
+		require.NoError(t, err)
+		_, err = h.service.ResolveMatch(matches[0].ID, h.other.ID, true)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown match", func(t *testing.T) {
+		h := newImageHashTest(t)
+		_, err := h.service.ResolveMatch("00000000-0000-0000-0000-000000000000", h.other.ID, true)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
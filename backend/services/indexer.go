@@ -0,0 +1,583 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/yourusername/revibe/backend/config"
+	"github.com/yourusername/revibe/backend/models"
+	"github.com/yourusername/revibe/backend/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// indexerHashHistory is how many processed block hashes are kept to trace
+// a reorg back to the common ancestor
+const indexerHashHistory = 256
+
+var ErrNoStartBlock = errors.New("indexer has no checkpoint and no start block: set the deployment's start block or INDEXER_START_BLOCK")
+
+// ChainEventHandler projects indexed contract events into the database.
+// Both methods run in the indexer's database transaction; RevertEvent undoes
+// HandleEvent when a reorg removes the event's block.
+type ChainEventHandler interface {
+	HandleEvent(tx *gorm.DB, event *models.ChainEvent, decoded interface{}) error
+	RevertEvent(tx *gorm.DB, event *models.ChainEvent) error
+}
+
+// ChainEventPreparer is implemented by handlers that read the chain to
+// project an event. PrepareEvent runs before the indexer opens its database
+// transaction, so no RPC call is made while the transaction holds locks, and
+// the value it returns is passed to the handler's HandleEvent in place of
+// the decoded event.
+type ChainEventPreparer interface {
+	PrepareEvent(ctx context.Context, event *models.ChainEvent, decoded interface{}) (interface{}, error)
+}
+
+// pendingLog is a log decoded before the indexer's database transaction,
+// with the value each handler is passed for it
+type pendingLog struct {
+	event   models.ChainEvent
+	decoded []interface{}
+}
+
+// IndexerStatus reports how far the indexer has got
+type IndexerStatus struct {
+	DeploymentID    string    `json:"deploymentId,omitempty"`
+	ChainID         int64     `json:"chainId"`
+	ContractAddress string    `json:"contractAddress"`
+	BlockNumber     uint64    `json:"blockNumber"`
+	BlockHash       string    `json:"blockHash"`
+	HeadBlock       uint64    `json:"headBlock"`
+	Confirmations   uint64    `json:"confirmations"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+// IndexerService follows one ReVibe deployment's events from a stored
+// checkpoint, processing each log once it has enough confirmations
+type IndexerService struct {
+	db            *gorm.DB
+	web3Service   *Web3Service
+	handlers      []ChainEventHandler
+	confirmations uint64
+	startBlock    uint64
+	blockRange    uint64
+	pollInterval  time.Duration
+}
+
+// NewIndexerService creates a new IndexerService instance for the
+// deployment web3Service talks to. Indexing starts at the deployment's start
+// block, or at INDEXER_START_BLOCK if it has none. Without either a new
+// deployment cannot be indexed; see CheckStartBlock.
+func NewIndexerService(db *gorm.DB, web3Service *Web3Service) *IndexerService {
+	blockRange := uint64(config.AppConfig.IndexerBlockRange)
+	if blockRange == 0 {
+		blockRange = 1
+	}
+	startBlock := web3Service.Deployment().StartBlock
+	if startBlock == 0 {
+		startBlock = uint64(config.AppConfig.IndexerStartBlock)
+	}
+
+	return &IndexerService{
+		db:            db,
+		web3Service:   web3Service,
+		confirmations: uint64(config.AppConfig.IndexerConfirmations),
+		startBlock:    startBlock,
+		blockRange:    blockRange,
+		pollInterval:  config.AppConfig.IndexerPollInterval,
+	}
+}
+
+// Deployment returns the deployment the indexer follows
+func (s *IndexerService) Deployment() models.Deployment {
+	return s.web3Service.Deployment()
+}
+
+// AddHandler registers a handler for indexed events. Handlers run in the
+// order they were added.
+func (s *IndexerService) AddHandler(handler ChainEventHandler) {
+	s.handlers = append(s.handlers, handler)
+}
+
+// CheckStartBlock returns ErrNoStartBlock if the indexer has no checkpoint
+// to resume from and no block to start at. Starting at the chain head would
+// silently skip the deployment's history.
+func (s *IndexerService) CheckStartBlock() error {
+	if s.startBlock > 0 {
+		return nil
+	}
+	var checkpoints int64
+	if err := s.db.Model(&models.IndexerCheckpoint{}).
+		Where("chain_id = ? AND contract_address = ?", s.web3Service.ChainID().Int64(), s.web3Service.ContractAddress().Hex()).
+		Count(&checkpoints).Error; err != nil {
+		return fmt.Errorf("failed to load indexer checkpoint: %v", err)
+	}
+	if checkpoints == 0 {
+		return ErrNoStartBlock
+	}
+	return nil
+}
+
+// StartIndexer backfills missed blocks and then follows new ones until ctx
+// is cancelled
+func (s *IndexerService) StartIndexer(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Sync(ctx); err != nil && ctx.Err() == nil {
+			utils.LogError(err, map[string]interface{}{
+				"component": "indexer",
+				"chain_id":  s.web3Service.ChainID().Int64(),
+				"contract":  s.web3Service.ContractAddress().Hex(),
+			})
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// safeHead returns the newest block with enough confirmations, and false if
+// the chain is not that long yet
+func safeHead(head, confirmations uint64) (uint64, bool) {
+	if head < confirmations {
+		return 0, false
+	}
+	return head - confirmations, true
+}
+
+// nextRange returns the next block range to index after a checkpoint,
+// bounded by the range size and the safe head
+func nextRange(checkpoint, safe, size uint64) (uint64, uint64, bool) {
+	from := checkpoint + 1
+	if from > safe {
+		return 0, 0, false
+	}
+	to := from + size - 1
+	if to > safe || to < from {
+		to = safe
+	}
+	return from, to, true
+}
+
+// Sync rolls back any reorged blocks and indexes every confirmed block
+// after the checkpoint, one bounded range at a time
+func (s *IndexerService) Sync(ctx context.Context) error {
+	head, err := s.web3Service.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get block number: %v", err)
+	}
+	safe, ok := safeHead(head, s.confirmations)
+	if !ok {
+		return nil
+	}
+
+	checkpoint, err := s.checkpoint()
+	if err != nil {
+		return err
+	}
+	if err := s.checkReorg(ctx, checkpoint); err != nil {
+		return err
+	}
+
+	for {
+		from, to, ok := nextRange(checkpoint.BlockNumber, safe, s.blockRange)
+		if !ok {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.indexRange(ctx, checkpoint, from, to); err != nil {
+			return err
+		}
+	}
+}
+
+// checkpoint loads the contract's checkpoint, creating it on first run just
+// before the start block. It returns ErrNoStartBlock on first run if there
+// is no start block.
+func (s *IndexerService) checkpoint() (*models.IndexerCheckpoint, error) {
+	if err := s.CheckStartBlock(); err != nil {
+		return nil, err
+	}
+	checkpoint := models.IndexerCheckpoint{
+		ChainID:         s.web3Service.ChainID().Int64(),
+		ContractAddress: s.web3Service.ContractAddress().Hex(),
+	}
+	if s.startBlock > 0 {
+		checkpoint.BlockNumber = s.startBlock - 1
+	}
+
+	err := s.db.Where("chain_id = ? AND contract_address = ?", checkpoint.ChainID, checkpoint.ContractAddress).
+		FirstOrCreate(&checkpoint).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load indexer checkpoint: %v", err)
+	}
+	return &checkpoint, nil
+}
+
+// checkReorg compares the checkpoint's hash with the canonical chain and
+// rolls back to the common ancestor if they differ
+func (s *IndexerService) checkReorg(ctx context.Context, checkpoint *models.IndexerCheckpoint) error {
+	if checkpoint.BlockHash == "" {
+		return nil
+	}
+	hash, err := s.web3Service.BlockHash(ctx, checkpoint.BlockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get block %d: %v", checkpoint.BlockNumber, err)
+	}
+	if hash.Hex() == checkpoint.BlockHash {
+		return nil
+	}
+
+	ancestor, ancestorHash, err := s.findAncestor(ctx, checkpoint)
+	if err != nil {
+		return err
+	}
+	utils.LogWarning("chain reorganisation detected, rolling back indexed events", map[string]interface{}{
+		"component": "indexer",
+		"from":      checkpoint.BlockNumber,
+		"to":        ancestor,
+	})
+	return s.rollback(checkpoint, ancestor, ancestorHash)
+}
+
+// findAncestor walks back the stored block hashes to the newest one still
+// on the canonical chain. If none is, it falls back to the block before the
+// oldest stored hash.
+func (s *IndexerService) findAncestor(ctx context.Context, checkpoint *models.IndexerCheckpoint) (uint64, string, error) {
+	var blocks []models.IndexedBlock
+	if err := s.db.Where("chain_id = ? AND contract_address = ? AND number <= ?",
+		checkpoint.ChainID, checkpoint.ContractAddress, checkpoint.BlockNumber).
+		Order("number desc").
+		Find(&blocks).Error; err != nil {
+		return 0, "", fmt.Errorf("failed to fetch indexed blocks: %v", err)
+	}
+
+	for _, block := range blocks {
+		hash, err := s.web3Service.BlockHash(ctx, block.Number)
+		if err != nil {
+			return 0, "", fmt.Errorf("failed to get block %d: %v", block.Number, err)
+		}
+		if hash.Hex() == block.Hash {
+			return block.Number, block.Hash, nil
+		}
+	}
+
+	oldest := checkpoint.BlockNumber
+	if len(blocks) > 0 {
+		oldest = blocks[len(blocks)-1].Number
+	}
+	if oldest == 0 {
+		return 0, "", nil
+	}
+	return oldest - 1, "", nil
+}
+
+// rollback reverts the events after the ancestor block, newest first, drops
+// the receipts cached for the contract from those blocks and moves the
+// checkpoint back to it
+func (s *IndexerService) rollback(checkpoint *models.IndexerCheckpoint, ancestor uint64, ancestorHash string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var events []models.ChainEvent
+		if err := tx.Where("chain_id = ? AND contract_address = ? AND block_number > ?",
+			checkpoint.ChainID, checkpoint.ContractAddress, ancestor).
+			Order("block_number desc, log_index desc").
+			Find(&events).Error; err != nil {
+			return fmt.Errorf("failed to fetch reorged events: %v", err)
+		}
+
+		txHashes := make([]string, 0, len(events))
+		for i := range events {
+			txHashes = append(txHashes, events[i].TxHash)
+			for j := len(s.handlers) - 1; j >= 0; j-- {
+				if err := s.handlers[j].RevertEvent(tx, &events[i]); err != nil {
+					return fmt.Errorf("failed to revert %s in %s: %v", events[i].Name, events[i].TxHash, err)
+				}
+			}
+			if err := tx.Delete(&events[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("chain_id = ? AND contract_address = ? AND number > ?",
+			checkpoint.ChainID, checkpoint.ContractAddress, ancestor).
+			Delete(&models.IndexedBlock{}).Error; err != nil {
+			return err
+		}
+
+		// Receipts of other deployments' transactions on the chain are left
+		// to their own indexers
+		if err := tx.Where("chain_id = ? AND block_number > ? AND (to_address = ? OR tx_hash IN ?)",
+			checkpoint.ChainID, ancestor, checkpoint.ContractAddress, txHashes).
+			Delete(&models.CachedReceipt{}).Error; err != nil {
+			return err
+		}
+
+		checkpoint.BlockNumber = ancestor
+		checkpoint.BlockHash = ancestorHash
+		return tx.Save(checkpoint).Error
+	})
+}
+
+// indexRange processes the logs of a block range and advances the
+// checkpoint to its end in one database transaction
+func (s *IndexerService) indexRange(ctx context.Context, checkpoint *models.IndexerCheckpoint, from, to uint64) error {
+	logs, err := s.web3Service.GetPastEvents(ctx, new(big.Int).SetUint64(from), new(big.Int).SetUint64(to))
+	if err != nil {
+		return fmt.Errorf("failed to get events for blocks %d-%d: %v", from, to, err)
+	}
+	blocks, err := s.blockHashes(ctx, logs, to)
+	if err != nil {
+		return err
+	}
+	receipts, err := s.fetchReceipts(ctx, logs)
+	if err != nil {
+		return err
+	}
+	times, err := s.blockTimes(ctx, logs)
+	if err != nil {
+		return err
+	}
+	pending, err := s.prepareLogs(ctx, checkpoint, logs, times)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the checkpoint so only one instance indexes at a time
+		var current models.IndexerCheckpoint
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&current, "id = ?", checkpoint.ID).Error; err != nil {
+			return err
+		}
+		if current.BlockNumber != checkpoint.BlockNumber {
+			*checkpoint = current
+			return nil
+		}
+
+		for i := range pending {
+			if err := s.processLog(tx, &pending[i]); err != nil {
+				return err
+			}
+		}
+
+		if err := cacheReceipts(tx, receipts); err != nil {
+			return err
+		}
+
+		for number, hash := range blocks {
+			block := models.IndexedBlock{
+				ChainID:         checkpoint.ChainID,
+				ContractAddress: checkpoint.ContractAddress,
+				Number:          number,
+				Hash:            hash.Hex(),
+			}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "chain_id"}, {Name: "contract_address"}, {Name: "number"}},
+				DoUpdates: clause.AssignmentColumns([]string{"hash"}),
+			}).Create(&block).Error; err != nil {
+				return fmt.Errorf("failed to record block %d: %v", number, err)
+			}
+		}
+		if err := s.pruneBlocks(tx, checkpoint); err != nil {
+			return err
+		}
+
+		checkpoint.BlockNumber = to
+		checkpoint.BlockHash = blocks[to].Hex()
+		return tx.Save(checkpoint).Error
+	})
+}
+
+// blockHashes fetches the canonical hashes of a range's end block and the
+// blocks with logs in it, and checks every log is from the canonical block.
+// A reorg after the logs were fetched would otherwise index logs from an
+// orphaned fork; the range is fetched again on the next sync instead.
+func (s *IndexerService) blockHashes(ctx context.Context, logs []types.Log, to uint64) (map[uint64]common.Hash, error) {
+	numbers := []uint64{to}
+	for _, vLog := range logs {
+		if !vLog.Removed {
+			numbers = append(numbers, vLog.BlockNumber)
+		}
+	}
+
+	hashes := make(map[uint64]common.Hash)
+	for _, number := range numbers {
+		if _, ok := hashes[number]; ok {
+			continue
+		}
+		hash, err := s.web3Service.BlockHash(ctx, number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block %d: %v", number, err)
+		}
+		hashes[number] = hash
+	}
+
+	for _, vLog := range logs {
+		if !vLog.Removed && vLog.BlockHash != hashes[vLog.BlockNumber] {
+			return nil, fmt.Errorf("log %d of %s is from block %s, no longer canonical at %d",
+				vLog.Index, vLog.TxHash.Hex(), vLog.BlockHash.Hex(), vLog.BlockNumber)
+		}
+	}
+	return hashes, nil
+}
+
+// fetchReceipts fetches the receipts of the transactions behind a range's
+// logs for the receipt cache
+func (s *IndexerService) fetchReceipts(ctx context.Context, logs []types.Log) ([]*models.CachedReceipt, error) {
+	var receipts []*models.CachedReceipt
+	seen := make(map[common.Hash]bool)
+	for _, vLog := range logs {
+		if vLog.Removed || seen[vLog.TxHash] {
+			continue
+		}
+		seen[vLog.TxHash] = true
+
+		record, err := fetchReceipt(ctx, s.web3Service, vLog.TxHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch receipt of %s: %v", vLog.TxHash.Hex(), err)
+		}
+		receipts = append(receipts, record)
+	}
+	return receipts, nil
+}
+
+// blockTimes fetches the timestamps of the blocks with logs in a range
+func (s *IndexerService) blockTimes(ctx context.Context, logs []types.Log) (map[uint64]time.Time, error) {
+	times := make(map[uint64]time.Time)
+	for _, vLog := range logs {
+		if _, ok := times[vLog.BlockNumber]; ok || vLog.Removed {
+			continue
+		}
+		blockTime, err := s.web3Service.BlockTime(ctx, vLog.BlockNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block %d: %v", vLog.BlockNumber, err)
+		}
+		times[vLog.BlockNumber] = blockTime
+	}
+	return times, nil
+}
+
+// prepareLogs decodes a range's logs into events and lets handlers that
+// read the chain prepare them. Logs of events the indexer does not know are
+// skipped.
+func (s *IndexerService) prepareLogs(ctx context.Context, checkpoint *models.IndexerCheckpoint, logs []types.Log, times map[uint64]time.Time) ([]pendingLog, error) {
+	var pending []pendingLog
+	for _, vLog := range logs {
+		if vLog.Removed {
+			continue
+		}
+		name, decoded, tokenID, err := s.web3Service.DecodeEvent(vLog)
+		if errors.Is(err, ErrUnknownEvent) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		p := pendingLog{
+			event: models.ChainEvent{
+				ChainID:         checkpoint.ChainID,
+				ContractAddress: checkpoint.ContractAddress,
+				DeploymentID:    deploymentID(s.web3Service),
+				BlockNumber:     vLog.BlockNumber,
+				BlockHash:       vLog.BlockHash.Hex(),
+				BlockTime:       times[vLog.BlockNumber],
+				TxHash:          vLog.TxHash.Hex(),
+				LogIndex:        vLog.Index,
+				Name:            name,
+				TokenID:         tokenID.String(),
+			},
+			decoded: make([]interface{}, len(s.handlers)),
+		}
+		for i, handler := range s.handlers {
+			p.decoded[i] = decoded
+			preparer, ok := handler.(ChainEventPreparer)
+			if !ok {
+				continue
+			}
+			if p.decoded[i], err = preparer.PrepareEvent(ctx, &p.event, decoded); err != nil {
+				return nil, fmt.Errorf("failed to prepare %s in %s: %v", name, p.event.TxHash, err)
+			}
+		}
+		pending = append(pending, p)
+	}
+	return pending, nil
+}
+
+// processLog records a prepared log and passes it to the handlers, unless
+// it has already been processed
+func (s *IndexerService) processLog(tx *gorm.DB, pending *pendingLog) error {
+	event := pending.event
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&event)
+	if result.Error != nil {
+		return fmt.Errorf("failed to record event: %v", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil
+	}
+
+	for i, handler := range s.handlers {
+		if err := handler.HandleEvent(tx, &event, pending.decoded[i]); err != nil {
+			return fmt.Errorf("failed to handle %s in %s: %v", event.Name, event.TxHash, err)
+		}
+	}
+	return nil
+}
+
+// pruneBlocks keeps only the newest block hashes
+func (s *IndexerService) pruneBlocks(tx *gorm.DB, checkpoint *models.IndexerCheckpoint) error {
+	var cutoff models.IndexedBlock
+	err := tx.Where("chain_id = ? AND contract_address = ?", checkpoint.ChainID, checkpoint.ContractAddress).
+		Order("number desc").
+		Offset(indexerHashHistory).
+		First(&cutoff).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Where("chain_id = ? AND contract_address = ? AND number <= ?",
+		checkpoint.ChainID, checkpoint.ContractAddress, cutoff.Number).
+		Delete(&models.IndexedBlock{}).Error
+}
+
+// GetStatus reports the indexer's checkpoint and the chain head
+func (s *IndexerService) GetStatus(ctx context.Context) (*IndexerStatus, error) {
+	var checkpoint models.IndexerCheckpoint
+	if err := s.db.Where("chain_id = ? AND contract_address = ?",
+		s.web3Service.ChainID().Int64(), s.web3Service.ContractAddress().Hex()).
+		First(&checkpoint).Error; err != nil {
+		return nil, err
+	}
+
+	head, err := s.web3Service.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block number: %v", err)
+	}
+
+	return &IndexerStatus{
+		DeploymentID:    s.web3Service.Deployment().ID,
+		ChainID:         checkpoint.ChainID,
+		ContractAddress: checkpoint.ContractAddress,
+		BlockNumber:     checkpoint.BlockNumber,
+		BlockHash:       checkpoint.BlockHash,
+		HeadBlock:       head,
+		Confirmations:   s.confirmations,
+		UpdatedAt:       checkpoint.UpdatedAt,
+	}, nil
+}
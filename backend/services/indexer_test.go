@@ -0,0 +1,359 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/models"
+	"gorm.io/gorm"
+)
+
+func TestSafeHead(t *testing.T) {
+	safe, ok := safeHead(100, 12)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(88), safe)
+
+	_, ok = safeHead(5, 12)
+	assert.False(t, ok)
+}
+
+func TestNextRange(t *testing.T) {
+	from, to, ok := nextRange(99, 5000, 2000)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(100), from)
+	assert.Equal(t, uint64(2099), to)
+
+	// The last range stops at the safe head
+	from, to, ok = nextRange(4099, 5000, 2000)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(4100), from)
+	assert.Equal(t, uint64(5000), to)
+
+	_, _, ok = nextRange(5000, 5000, 2000)
+	assert.False(t, ok)
+}
+
+func TestIndexerProjectsContractEvents(t *testing.T) {
+	chain := newMockChain(t)
+	market := newTestMarket(t, chain.web3)
+	sellerWallet := common.HexToAddress("0x5e11e5")
+	buyerWallet := common.HexToAddress("0xb0b")
+	seller := createUser(t, market.db, sellerWallet.Hex())
+	buyer := createUser(t, market.db, buyerWallet.Hex())
+	chainID := chain.chainID.Int64()
+	contract := mockReVibeAddress.Hex()
+
+	// One product is listed through the app, another directly on chain
+	product := createProduct(t, market.db, seller)
+	listTx := chain.list(1, sellerWallet, big.NewInt(1e17))
+	listing := models.ListingTransaction{ProductID: product.ID, TxHash: listTx.Hex(), ChainID: chainID, ContractAddress: contract}
+	require.NoError(t, market.db.Create(&listing).Error)
+	chain.list(2, sellerWallet, big.NewInt(2e17))
+	chain.commit()
+
+	chain.authenticate(1, true)
+	chain.updatePrice(1, big.NewInt(9e16))
+	chain.commit()
+
+	saleTx := chain.sell(1, sellerWallet, buyerWallet, big.NewInt(9e16))
+	order := models.Order{
+		ProductID: product.ID,
+		BuyerID:   buyer.ID,
+		SellerID:  seller.ID,
+		Price:     0.09,
+		TokenID:   "1",
+		Status:    models.OrderStatusPending,
+		TxHash:    saleTx.Hex(),
+	}
+	require.NoError(t, market.db.Create(&order).Error)
+	saleBlock := chain.commit()
+
+	// A later fee change does not affect the sale
+	chain.setFee(50)
+	head := chain.commit()
+
+	market.sync()
+
+	var checkpoint models.IndexerCheckpoint
+	require.NoError(t, market.db.First(&checkpoint, "chain_id = ? AND contract_address = ?", chainID, contract).Error)
+	assert.Equal(t, head, checkpoint.BlockNumber)
+	headHash, err := chain.web3.BlockHash(context.Background(), head)
+	require.NoError(t, err)
+	assert.Equal(t, headHash.Hex(), checkpoint.BlockHash)
+	assert.Equal(t, int64(8), market.count(&models.ChainEvent{}, "chain_id = ?", chainID))
+	assert.Equal(t, int64(3), market.count(&models.ChainEvent{}, "name = ?", EventTransfer))
+
+	market.reload(&listing, listing.ID)
+	assert.Equal(t, models.ListingStatusConfirmed, listing.Status)
+
+	market.reload(product, product.ID)
+	require.NotNil(t, product.TokenID)
+	assert.Equal(t, "1", *product.TokenID)
+	assert.Equal(t, models.ProductStatusSold, product.Status)
+	assert.Equal(t, buyerWallet.Hex(), product.OwnerAddress)
+	assert.Equal(t, "90000000000000000", product.PriceWei)
+	assert.Equal(t, 0.09, product.Price)
+	assert.Equal(t, int64(1), market.count(&models.Authentication{},
+		"product_id = ? AND method = ? AND verdict = ?", product.ID, models.AuthMethodOnChain, models.AuthVerdictPass))
+
+	market.reload(&order, order.ID)
+	assert.Equal(t, models.OrderStatusCompleted, order.Status)
+	assert.Equal(t, "90000000000000000", order.PriceWei)
+	assert.Equal(t, saleBlock, order.BlockNumber)
+
+	var journal models.LedgerJournal
+	require.NoError(t, market.db.Preload("Entries").First(&journal, "order_id = ?", order.ID).Error)
+	assert.Equal(t, int64(25), journal.FeeRatePerMille)
+	assert.Equal(t, "2250000000000000", journal.FeeWei)
+	assert.Equal(t, "87750000000000000", journal.NetWei)
+	assert.Len(t, journal.Entries, 3)
+
+	var transfers []models.TokenTransfer
+	require.NoError(t, market.db.Where("token_id = ?", "1").Order("block_number asc").Find(&transfers).Error)
+	require.Len(t, transfers, 2)
+	assert.Equal(t, (common.Address{}).Hex(), transfers[0].FromAddress)
+	assert.Empty(t, transfers[0].PriceWei)
+	assert.Equal(t, "90000000000000000", transfers[1].PriceWei)
+	require.NotNil(t, transfers[1].OrderID)
+	assert.Equal(t, order.ID, *transfers[1].OrderID)
+
+	var direct models.Product
+	require.NoError(t, market.db.First(&direct, "token_id = ?", "2").Error)
+	assert.Equal(t, models.ModerationStatusPendingReview, direct.ModerationStatus)
+	assert.Equal(t, seller.ID, direct.SellerID)
+	assert.Equal(t, sellerWallet.Hex(), direct.OwnerAddress)
+
+	// Indexing again finds nothing new
+	market.sync()
+	assert.Equal(t, int64(8), market.count(&models.ChainEvent{}, "chain_id = ?", chainID))
+}
+
+// recordingHandler records the events it is given, by name, block and log
+// index
+type recordingHandler struct {
+	handled  []string
+	reverted []string
+}
+
+func eventKey(event *models.ChainEvent) string {
+	return fmt.Sprintf("%s@%d/%d", event.Name, event.BlockNumber, event.LogIndex)
+}
+
+func (h *recordingHandler) HandleEvent(tx *gorm.DB, event *models.ChainEvent, decoded interface{}) error {
+	h.handled = append(h.handled, eventKey(event))
+	return nil
+}
+
+func (h *recordingHandler) RevertEvent(tx *gorm.DB, event *models.ChainEvent) error {
+	h.reverted = append(h.reverted, eventKey(event))
+	return nil
+}
+
+// indexThreeBlocks lists two tokens and authenticates the second, one block
+// each, and indexes them
+func indexThreeBlocks(t *testing.T) (*mockChain, *testMarket, *recordingHandler) {
+	chain := newMockChain(t)
+	market := newTestMarket(t, chain.web3)
+	recorder := &recordingHandler{}
+	market.indexer.AddHandler(recorder)
+	seller := common.HexToAddress("0x5e11e5")
+	createUser(t, market.db, seller.Hex())
+
+	chain.list(1, seller, big.NewInt(1e17))
+	chain.commit()
+	chain.list(2, seller, big.NewInt(2e17))
+	chain.commit()
+	chain.authenticate(2, true)
+	chain.commit()
+
+	market.sync()
+	require.Equal(t, []string{
+		"Transfer@1/0", "ProductListed@1/1",
+		"Transfer@2/0", "ProductListed@2/1",
+		"ProductAuthenticated@3/0",
+	}, recorder.handled)
+	return chain, market, recorder
+}
+
+// loadCheckpoint reads the indexer's checkpoint for the mock contract
+func loadCheckpoint(t *testing.T, market *testMarket) *models.IndexerCheckpoint {
+	var checkpoint models.IndexerCheckpoint
+	require.NoError(t, market.db.First(&checkpoint, "contract_address = ?", mockReVibeAddress.Hex()).Error)
+	return &checkpoint
+}
+
+func TestIndexerFindAncestor(t *testing.T) {
+	chain, market, _ := indexThreeBlocks(t)
+	ctx := context.Background()
+	ancestorHash := chain.hash(1)
+	chain.reorg(1, 3)
+
+	checkpoint := loadCheckpoint(t, market)
+	ancestor, hash, err := market.indexer.findAncestor(ctx, checkpoint)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), ancestor)
+	assert.Equal(t, ancestorHash.Hex(), hash)
+
+	// With no stored hash left on the chain, it falls back to the block
+	// before the oldest one
+	require.NoError(t, market.db.Model(&models.IndexedBlock{}).Where("number = ?", 1).
+		Update("hash", common.Hash{}.Hex()).Error)
+	ancestor, hash, err = market.indexer.findAncestor(ctx, checkpoint)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), ancestor)
+	assert.Empty(t, hash)
+}
+
+func TestIndexerRollback(t *testing.T) {
+	chain, market, recorder := indexThreeBlocks(t)
+	ancestorHash := chain.hash(1).Hex()
+	assert.Equal(t, int64(3), market.count(&models.CachedReceipt{}, "block_number > ?", 0))
+
+	checkpoint := loadCheckpoint(t, market)
+	require.NoError(t, market.indexer.rollback(checkpoint, 1, ancestorHash))
+
+	// Events are reverted newest first
+	assert.Equal(t, []string{"ProductAuthenticated@3/0", "ProductListed@2/1", "Transfer@2/0"}, recorder.reverted)
+	assert.Equal(t, int64(2), market.count(&models.ChainEvent{}, "block_number <= ?", 1))
+	assert.Equal(t, int64(0), market.count(&models.ChainEvent{}, "block_number > ?", 1))
+	assert.Equal(t, int64(0), market.count(&models.IndexedBlock{}, "number > ?", 1))
+	assert.Equal(t, int64(0), market.count(&models.CachedReceipt{}, "block_number > ?", 1))
+
+	stored := loadCheckpoint(t, market)
+	assert.Equal(t, uint64(1), stored.BlockNumber)
+	assert.Equal(t, ancestorHash, stored.BlockHash)
+}
+
+func TestIndexerReindexesReorgedLogs(t *testing.T) {
+	chain, market, recorder := indexThreeBlocks(t)
+	reorgedHash := chain.hash(2)
+	var listed models.ChainEvent
+	require.NoError(t, market.db.First(&listed, "name = ? AND block_number = ?", EventProductListed, 2).Error)
+
+	// The side chain mines the listing and the authentication together in
+	// its block 2
+	head := chain.reorg(1, 3)
+	market.sync()
+
+	assert.Equal(t, []string{"ProductAuthenticated@3/0", "ProductListed@2/1", "Transfer@2/0"}, recorder.reverted)
+	assert.Equal(t, []string{"Transfer@2/0", "ProductListed@2/1", "ProductAuthenticated@2/2"}, recorder.handled[5:])
+
+	checkpoint := loadCheckpoint(t, market)
+	assert.Equal(t, head, checkpoint.BlockNumber)
+	assert.Equal(t, chain.hash(head).Hex(), checkpoint.BlockHash)
+
+	var blocks []models.IndexedBlock
+	require.NoError(t, market.db.Order("number asc").Find(&blocks).Error)
+	require.Len(t, blocks, 3)
+	for i, number := range []uint64{1, 2, head} {
+		assert.Equal(t, number, blocks[i].Number)
+		assert.Equal(t, chain.hash(number).Hex(), blocks[i].Hash)
+	}
+
+	// The listing's log is the same log, delivered again in the new block
+	var relisted models.ChainEvent
+	require.NoError(t, market.db.First(&relisted, "tx_hash = ? AND log_index = ?", listed.TxHash, listed.LogIndex).Error)
+	assert.NotEqual(t, listed.ID, relisted.ID)
+	assert.Equal(t, chain.hash(2).Hex(), relisted.BlockHash)
+	assert.NotEqual(t, reorgedHash.Hex(), relisted.BlockHash)
+	assert.Equal(t, int64(5), market.count(&models.ChainEvent{}, "chain_id = ?", chain.chainID.Int64()))
+	assert.Equal(t, int64(0), market.count(&models.ChainEvent{}, "block_number > ?", 2))
+
+	var direct models.Product
+	require.NoError(t, market.db.First(&direct, "token_id = ?", "2").Error)
+	assert.Equal(t, int64(1), market.count(&models.Authentication{}, "product_id = ?", direct.ID))
+}
+
+func TestIndexerProcessesLogOnce(t *testing.T) {
+	_, market, recorder := indexThreeBlocks(t)
+
+	// Logs delivered again from the same blocks are already recorded, so
+	// handlers do not see them twice
+	require.NoError(t, market.db.Model(&models.IndexerCheckpoint{}).Where("1 = 1").
+		Updates(map[string]interface{}{"block_number": 0, "block_hash": ""}).Error)
+	market.sync()
+
+	assert.Len(t, recorder.handled, 5)
+	assert.Equal(t, int64(5), market.count(&models.ChainEvent{}, "block_number > ?", 0))
+	assert.Equal(t, uint64(3), loadCheckpoint(t, market).BlockNumber)
+}
+
+func TestIndexerRollbackKeepsOtherContractsReceipts(t *testing.T) {
+	chain, market, _ := indexThreeBlocks(t)
+	other := models.CachedReceipt{
+		ChainID:     chain.chainID.Int64(),
+		TxHash:      txHash(99),
+		BlockNumber: 3,
+		BlockHash:   chain.hash(3).Hex(),
+		FromAddress: common.HexToAddress("0x5e11e5").Hex(),
+		ToAddress:   common.HexToAddress("0x0de9").Hex(),
+		Status:      1,
+	}
+	require.NoError(t, market.db.Create(&other).Error)
+
+	require.NoError(t, market.indexer.rollback(loadCheckpoint(t, market), 1, chain.hash(1).Hex()))
+	assert.Equal(t, int64(0), market.count(&models.CachedReceipt{}, "block_number > ? AND to_address = ?", 1, mockReVibeAddress.Hex()))
+	assert.Equal(t, int64(1), market.count(&models.CachedReceipt{}, "tx_hash = ?", other.TxHash))
+}
+
+func TestIndexerNeedsStartBlock(t *testing.T) {
+	chain := newMockChain(t)
+	market := newTestMarket(t, chain.web3)
+	chain.list(1, common.HexToAddress("0x5e11e5"), big.NewInt(1e17))
+	chain.commit()
+
+	// With no start block and no checkpoint the indexer does not guess
+	market.indexer.startBlock = 0
+	assert.ErrorIs(t, market.indexer.CheckStartBlock(), ErrNoStartBlock)
+	assert.ErrorIs(t, market.indexer.Sync(context.Background()), ErrNoStartBlock)
+	assert.Equal(t, int64(0), market.count(&models.IndexerCheckpoint{}, "1 = 1"))
+
+	// A checkpoint from an earlier run is enough to resume from
+	market.indexer.startBlock = 1
+	market.sync()
+	market.indexer.startBlock = 0
+	require.NoError(t, market.indexer.CheckStartBlock())
+	market.sync()
+	assert.Equal(t, int64(2), market.count(&models.ChainEvent{}, "block_number = ?", 1))
+}
+
+func TestIndexerSkipsLogsFromOrphanedBlocks(t *testing.T) {
+	chain := newMockChain(t)
+	market := newTestMarket(t, chain.web3)
+	recorder := &recordingHandler{}
+	market.indexer.AddHandler(recorder)
+	ctx := context.Background()
+	chain.list(1, common.HexToAddress("0x5e11e5"), big.NewInt(1e17))
+	chain.commit()
+	chain.list(2, common.HexToAddress("0x5e11e5"), big.NewInt(2e17))
+	chain.commit()
+
+	// Logs fetched just before a reorg replaces block 2
+	logs, err := chain.web3.GetPastEvents(ctx, big.NewInt(1), big.NewInt(2))
+	require.NoError(t, err)
+	orphaned := chain.hash(2)
+	head := chain.reorg(1, 2)
+
+	_, err = market.indexer.blockHashes(ctx, logs, head)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), orphaned.Hex())
+
+	hashes, err := market.indexer.blockHashes(ctx, logs[:2], head)
+	require.NoError(t, err)
+	assert.Equal(t, chain.hash(1), hashes[1])
+	assert.Equal(t, chain.hash(head), hashes[head])
+
+	// Logs fetched afresh are from the canonical blocks
+	market.sync()
+	assert.Len(t, recorder.handled, 4)
+	var events []models.ChainEvent
+	require.NoError(t, market.db.Find(&events).Error)
+	for _, event := range events {
+		assert.Equal(t, chain.hash(event.BlockNumber).Hex(), event.BlockHash)
+	}
+}
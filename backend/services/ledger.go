@@ -0,0 +1,282 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/yourusername/revibe/backend/models"
+	"gorm.io/gorm"
+)
+
+// feeDenominator is the contract's platform fee scale (parts per thousand)
+var feeDenominator = big.NewInt(1000)
+
+// Revenue report periods
+const (
+	RevenuePeriodDay   = "day"
+	RevenuePeriodWeek  = "week"
+	RevenuePeriodMonth = "month"
+)
+
+var ErrInvalidRevenuePeriod = errors.New("period must be day, week or month")
+
+// SplitSalePrice splits a sale price into the platform fee and the seller's
+// net amount using the same integer arithmetic as the contract
+func SplitSalePrice(gross *big.Int, feeRate int64) (fee, net *big.Int) {
+	fee = new(big.Int).Mul(gross, big.NewInt(feeRate))
+	fee.Quo(fee, feeDenominator)
+	net = new(big.Int).Sub(gross, fee)
+	return fee, net
+}
+
+// SellerBalance summarises a seller's proceeds in the ledger
+type SellerBalance struct {
+	SellerID   string `json:"sellerId"`
+	Sales      int64  `json:"sales"`
+	GrossWei   string `json:"grossWei"`
+	FeesWei    string `json:"feesWei"`
+	NetWei     string `json:"netWei"`
+	BalanceWei string `json:"balanceWei"`
+}
+
+// RevenuePeriod is the platform's fee revenue for one reporting period
+type RevenuePeriod struct {
+	Period   time.Time `json:"period"`
+	Sales    int64     `json:"sales"`
+	GrossWei string    `json:"grossWei"`
+	FeesWei  string    `json:"feesWei"`
+	NetWei   string    `json:"netWei"`
+}
+
+// LedgerService records sale proceeds and platform fees as double-entry journals
+type LedgerService struct {
+	db       *gorm.DB
+	registry *DeploymentRegistry
+}
+
+// NewLedgerService creates a new LedgerService instance
+func NewLedgerService(db *gorm.DB, registry *DeploymentRegistry) *LedgerService {
+	return &LedgerService{
+		db:       db,
+		registry: registry,
+	}
+}
+
+// FeeRateAt returns the platform fee a deployment charged at the given block
+func (s *LedgerService) FeeRateAt(chainID int64, contractAddress string, blockNumber uint64) (int64, error) {
+	web3Service, err := s.registry.Get(chainID, contractAddress)
+	if err != nil {
+		return 0, err
+	}
+	fee, err := web3Service.GetPlatformFee(new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return 0, err
+	}
+	return fee.Int64(), nil
+}
+
+// RecordSale posts the journal for a completed order. It takes the caller's
+// database transaction so the journal is written together with the order,
+// and does nothing if the order has already been posted.
+func (s *LedgerService) RecordSale(tx *gorm.DB, order *models.Order, feeRate int64) error {
+	var count int64
+	if err := tx.Model(&models.LedgerJournal{}).
+		Where("type = ? AND order_id = ?", models.JournalTypeSale, order.ID).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check ledger: %v", err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	gross, ok := new(big.Int).SetString(order.PriceWei, 10)
+	if !ok {
+		return fmt.Errorf("order has no recorded on-chain price")
+	}
+	fee, net := SplitSalePrice(gross, feeRate)
+
+	postedAt := time.Now()
+	if order.CompletedAt != nil {
+		postedAt = *order.CompletedAt
+	}
+
+	sellerID := order.SellerID
+	journal := models.LedgerJournal{
+		Type:            models.JournalTypeSale,
+		OrderID:         order.ID,
+		SellerID:        sellerID,
+		TxHash:          order.TxHash,
+		GrossWei:        gross.String(),
+		FeeWei:          fee.String(),
+		NetWei:          net.String(),
+		FeeRatePerMille: feeRate,
+		PostedAt:        postedAt,
+		Entries: []models.LedgerEntry{
+			{Account: models.AccountSales, Direction: models.EntryDebit, AmountWei: gross.String(), PostedAt: postedAt},
+			{Account: models.AccountSellerProceeds, OwnerID: &sellerID, Direction: models.EntryCredit, AmountWei: net.String(), PostedAt: postedAt},
+			{Account: models.AccountPlatformFees, Direction: models.EntryCredit, AmountWei: fee.String(), PostedAt: postedAt},
+		},
+	}
+	if err := tx.Create(&journal).Error; err != nil {
+		return fmt.Errorf("failed to post sale journal: %v", err)
+	}
+
+	return nil
+}
+
+// RevertSale removes the journal posted for an order whose sale was undone
+// by a chain reorganisation, in the caller's database transaction
+func (s *LedgerService) RevertSale(tx *gorm.DB, orderID string) error {
+	var journals []models.LedgerJournal
+	if err := tx.Where("type = ? AND order_id = ?", models.JournalTypeSale, orderID).
+		Find(&journals).Error; err != nil {
+		return fmt.Errorf("failed to check ledger: %v", err)
+	}
+
+	for _, journal := range journals {
+		if err := tx.Where("journal_id = ?", journal.ID).Delete(&models.LedgerEntry{}).Error; err != nil {
+			return fmt.Errorf("failed to revert sale journal: %v", err)
+		}
+		if err := tx.Delete(&journal).Error; err != nil {
+			return fmt.Errorf("failed to revert sale journal: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// GetSellerBalance returns a seller's totals across all posted sales
+func (s *LedgerService) GetSellerBalance(sellerID string) (*SellerBalance, error) {
+	balance := SellerBalance{SellerID: sellerID}
+	err := s.db.Model(&models.LedgerJournal{}).
+		Select("COUNT(*) AS sales, "+
+			"CAST(COALESCE(SUM(gross_wei), 0) AS TEXT) AS gross_wei, "+
+			"CAST(COALESCE(SUM(fee_wei), 0) AS TEXT) AS fees_wei, "+
+			"CAST(COALESCE(SUM(net_wei), 0) AS TEXT) AS net_wei").
+		Where("type = ? AND seller_id = ?", models.JournalTypeSale, sellerID).
+		Scan(&balance).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum seller journals: %v", err)
+	}
+
+	err = s.db.Model(&models.LedgerEntry{}).
+		Select("CAST(COALESCE(SUM(CASE WHEN direction = ? THEN amount_wei ELSE -amount_wei END), 0) AS TEXT)", models.EntryCredit).
+		Where("account = ? AND owner_id = ?", models.AccountSellerProceeds, sellerID).
+		Scan(&balance.BalanceWei).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute seller balance: %v", err)
+	}
+
+	return &balance, nil
+}
+
+// GetSellerStatement returns a page of postings to a seller's proceeds
+// account between from and to, newest first. Zero times leave a bound open.
+func (s *LedgerService) GetSellerStatement(sellerID string, from, to time.Time, page, limit int) ([]models.LedgerEntry, int64, error) {
+	query := s.db.Model(&models.LedgerEntry{}).
+		Where("account = ? AND owner_id = ?", models.AccountSellerProceeds, sellerID)
+	if !from.IsZero() {
+		query = query.Where("posted_at >= ?", from)
+	}
+	if !to.IsZero() {
+		query = query.Where("posted_at < ?", to)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count statement lines: %v", err)
+	}
+
+	var lines []models.LedgerEntry
+	err := query.Preload("Journal").
+		Order("posted_at desc").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&lines).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch statement: %v", err)
+	}
+
+	return lines, total, nil
+}
+
+// GetRevenueSummary returns platform fee revenue grouped by day, week or
+// month between from and to. Zero times leave a bound open. Periods start at
+// midnight UTC, weeks on a Monday.
+func (s *LedgerService) GetRevenueSummary(period string, from, to time.Time) ([]RevenuePeriod, error) {
+	switch period {
+	case RevenuePeriodDay, RevenuePeriodWeek, RevenuePeriodMonth:
+	default:
+		return nil, ErrInvalidRevenuePeriod
+	}
+
+	// Journals are bucketed here rather than in SQL, which has no portable
+	// way to truncate a timestamp, and summed as big integers so no wei is
+	// lost to a float column type
+	query := s.db.Model(&models.LedgerJournal{}).
+		Where("type = ?", models.JournalTypeSale)
+	if !from.IsZero() {
+		query = query.Where("posted_at >= ?", from)
+	}
+	if !to.IsZero() {
+		query = query.Where("posted_at < ?", to)
+	}
+
+	var journals []models.LedgerJournal
+	err := query.Select("posted_at, gross_wei, fee_wei, net_wei").
+		Order("posted_at").
+		Find(&journals).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarise revenue: %v", err)
+	}
+
+	var periods []RevenuePeriod
+	var gross, fees, net *big.Int
+	for _, journal := range journals {
+		start := periodStart(period, journal.PostedAt)
+		if len(periods) == 0 || !periods[len(periods)-1].Period.Equal(start) {
+			periods = append(periods, RevenuePeriod{Period: start})
+			gross, fees, net = new(big.Int), new(big.Int), new(big.Int)
+		}
+		current := &periods[len(periods)-1]
+		current.Sales++
+		if err := addWei(gross, journal.GrossWei); err != nil {
+			return nil, err
+		}
+		if err := addWei(fees, journal.FeeWei); err != nil {
+			return nil, err
+		}
+		if err := addWei(net, journal.NetWei); err != nil {
+			return nil, err
+		}
+		current.GrossWei, current.FeesWei, current.NetWei = gross.String(), fees.String(), net.String()
+	}
+
+	return periods, nil
+}
+
+// periodStart truncates t to the start of its day, week or month in UTC
+func periodStart(period string, t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch period {
+	case RevenuePeriodWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case RevenuePeriodMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// addWei adds a stored wei amount to total
+func addWei(total *big.Int, wei string) error {
+	amount, ok := new(big.Int).SetString(wei, 10)
+	if !ok {
+		return fmt.Errorf("invalid wei amount in ledger: %q", wei)
+	}
+	total.Add(total, amount)
+	return nil
+}
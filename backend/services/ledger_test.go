@@ -0,0 +1,344 @@
+package services
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/models"
+	"gorm.io/gorm"
+)
+
+func TestSplitSalePrice(t *testing.T) {
+	t.Run("Default Fee", func(t *testing.T) {
+		gross, _ := new(big.Int).SetString("1000000000000000000", 10)
+		fee, net := SplitSalePrice(gross, 25)
+		assert.Equal(t, "25000000000000000", fee.String())
+		assert.Equal(t, "975000000000000000", net.String())
+	})
+
+	t.Run("Rounds Fee Down", func(t *testing.T) {
+		fee, net := SplitSalePrice(big.NewInt(999), 25)
+		assert.Equal(t, "24", fee.String())
+		assert.Equal(t, "975", net.String())
+	})
+
+	t.Run("Zero Fee", func(t *testing.T) {
+		fee, net := SplitSalePrice(big.NewInt(500), 0)
+		assert.Equal(t, "0", fee.String())
+		assert.Equal(t, "500", net.String())
+	})
+}
+
+// assertBalanced checks a journal's debits equal its credits
+func assertBalanced(t *testing.T, journal *models.LedgerJournal) {
+	debits, credits := new(big.Int), new(big.Int)
+	for _, entry := range journal.Entries {
+		amount, ok := new(big.Int).SetString(entry.AmountWei, 10)
+		require.True(t, ok, entry.AmountWei)
+		if entry.Direction == models.EntryDebit {
+			debits.Add(debits, amount)
+		} else {
+			credits.Add(credits, amount)
+		}
+	}
+	assert.Equal(t, journal.GrossWei, debits.String())
+	assert.Equal(t, journal.GrossWei, credits.String())
+}
+
+// ledgerTest has completed orders for a seller to post to the ledger
+type ledgerTest struct {
+	*chainFixture
+	orders int64
+}
+
+// completedOrder creates a completed order for a sale at priceWei
+func (l *ledgerTest) completedOrder(seller *models.User, priceWei string, completedAt time.Time) *models.Order {
+	l.orders++
+	product := createProduct(l.t, l.db, seller)
+	order := models.Order{
+		ProductID:   product.ID,
+		BuyerID:     l.buyer.ID,
+		SellerID:    seller.ID,
+		Price:       product.Price,
+		PriceWei:    priceWei,
+		Status:      models.OrderStatusCompleted,
+		TxHash:      txHash(l.orders),
+		CompletedAt: &completedAt,
+	}
+	require.NoError(l.t, l.db.Create(&order).Error)
+	return &order
+}
+
+// post records an order's sale journal at feeRate
+func (l *ledgerTest) post(order *models.Order, feeRate int64) {
+	require.NoError(l.t, l.db.Transaction(func(tx *gorm.DB) error {
+		return l.ledger.RecordSale(tx, order, feeRate)
+	}))
+}
+
+func (l *ledgerTest) journal(orderID string) *models.LedgerJournal {
+	var journal models.LedgerJournal
+	require.NoError(l.t, l.db.Preload("Entries").First(&journal, "order_id = ?", orderID).Error)
+	return &journal
+}
+
+func TestRecordSale(t *testing.T) {
+	l := &ledgerTest{chainFixture: newChainFixture(t)}
+	completedAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	order := l.completedOrder(l.seller, "1000000000000000000", completedAt)
+
+	l.post(order, 25)
+	journal := l.journal(order.ID)
+	assert.Equal(t, models.JournalTypeSale, journal.Type)
+	assert.Equal(t, l.seller.ID, journal.SellerID)
+	assert.Equal(t, order.TxHash, journal.TxHash)
+	assert.Equal(t, int64(25), journal.FeeRatePerMille)
+	assert.Equal(t, "25000000000000000", journal.FeeWei)
+	assert.Equal(t, "975000000000000000", journal.NetWei)
+	assert.True(t, completedAt.Equal(journal.PostedAt))
+	assertBalanced(t, journal)
+
+	accounts := make(map[string]models.LedgerEntry)
+	for _, entry := range journal.Entries {
+		accounts[entry.Account] = entry
+	}
+	assert.Equal(t, models.EntryDebit, accounts[models.AccountSales].Direction)
+	assert.Equal(t, "975000000000000000", accounts[models.AccountSellerProceeds].AmountWei)
+	require.NotNil(t, accounts[models.AccountSellerProceeds].OwnerID)
+	assert.Equal(t, l.seller.ID, *accounts[models.AccountSellerProceeds].OwnerID)
+	assert.Equal(t, "25000000000000000", accounts[models.AccountPlatformFees].AmountWei)
+
+	// Posting the order again, even at another rate, changes nothing
+	l.post(order, 50)
+	assert.Equal(t, int64(1), l.count(&models.LedgerJournal{}, "order_id = ?", order.ID))
+	assert.Equal(t, int64(3), l.count(&models.LedgerEntry{}, "journal_id = ?", journal.ID))
+	assert.Equal(t, int64(25), l.journal(order.ID).FeeRatePerMille)
+
+	// The database holds one sale journal per order even if the check is
+	// raced
+	duplicate := models.LedgerJournal{
+		Type: models.JournalTypeSale, OrderID: order.ID, SellerID: l.seller.ID,
+		GrossWei: "1", FeeWei: "0", NetWei: "1", PostedAt: time.Now(),
+	}
+	assert.Error(t, l.db.Create(&duplicate).Error)
+
+	unpriced := l.completedOrder(l.seller, "", time.Now())
+	err := l.db.Transaction(func(tx *gorm.DB) error {
+		return l.ledger.RecordSale(tx, unpriced, 25)
+	})
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), l.count(&models.LedgerJournal{}, "order_id = ?", unpriced.ID))
+}
+
+func TestRevertSale(t *testing.T) {
+	l := &ledgerTest{chainFixture: newChainFixture(t)}
+	order := l.completedOrder(l.seller, "100000000000000000", time.Now())
+	l.post(order, 25)
+	journal := l.journal(order.ID)
+
+	require.NoError(t, l.db.Transaction(func(tx *gorm.DB) error {
+		return l.ledger.RevertSale(tx, order.ID)
+	}))
+	assert.Equal(t, int64(0), l.count(&models.LedgerJournal{}, "order_id = ?", order.ID))
+	assert.Equal(t, int64(0), l.count(&models.LedgerEntry{}, "journal_id = ?", journal.ID))
+	balance, err := l.ledger.GetSellerBalance(l.seller.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), balance.Sales)
+	assert.Equal(t, "0", balance.BalanceWei)
+
+	// Reverting an order with no journal does nothing, and a reverted sale
+	// can be posted again when it is mined again
+	require.NoError(t, l.db.Transaction(func(tx *gorm.DB) error {
+		return l.ledger.RevertSale(tx, order.ID)
+	}))
+	l.post(order, 30)
+	assert.Equal(t, int64(30), l.journal(order.ID).FeeRatePerMille)
+}
+
+func TestSellerBalanceAndStatement(t *testing.T) {
+	l := &ledgerTest{chainFixture: newChainFixture(t)}
+	other := createUser(t, l.db, common.HexToAddress("0xca7").Hex())
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	first := l.completedOrder(l.seller, "100000000000000000", start)
+	second := l.completedOrder(l.seller, "200000000000000000", start.Add(24*time.Hour))
+	third := l.completedOrder(l.seller, "40000000000000000", start.Add(48*time.Hour))
+	l.post(first, 25)
+	l.post(second, 25)
+	l.post(third, 50)
+	l.post(l.completedOrder(other, "500000000000000000", start), 25)
+
+	balance, err := l.ledger.GetSellerBalance(l.seller.ID)
+	require.NoError(t, err)
+	assert.Equal(t, l.seller.ID, balance.SellerID)
+	assert.Equal(t, int64(3), balance.Sales)
+	assert.Equal(t, "340000000000000000", balance.GrossWei)
+	assert.Equal(t, "9500000000000000", balance.FeesWei)
+	assert.Equal(t, "330500000000000000", balance.NetWei)
+	assert.Equal(t, balance.NetWei, balance.BalanceWei)
+
+	empty, err := l.ledger.GetSellerBalance(l.buyer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), empty.Sales)
+	assert.Equal(t, "0", empty.GrossWei)
+	assert.Equal(t, "0", empty.BalanceWei)
+
+	// Newest first, paged, and only the seller's own proceeds
+	lines, total, err := l.ledger.GetSellerStatement(l.seller.ID, time.Time{}, time.Time{}, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "38000000000000000", lines[0].AmountWei)
+	assert.Equal(t, "195000000000000000", lines[1].AmountWei)
+	require.NotNil(t, lines[0].Journal)
+	assert.Equal(t, third.ID, lines[0].Journal.OrderID)
+
+	lines, total, err = l.ledger.GetSellerStatement(l.seller.ID, time.Time{}, time.Time{}, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, lines, 1)
+	assert.Equal(t, first.ID, lines[0].Journal.OrderID)
+
+	// from is inclusive and to exclusive
+	lines, total, err = l.ledger.GetSellerStatement(l.seller.ID, start.Add(24*time.Hour), start.Add(48*time.Hour), 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, lines, 1)
+	assert.Equal(t, second.ID, lines[0].Journal.OrderID)
+}
+
+func TestCompletedOrderJournalUsesSaleBlockFee(t *testing.T) {
+	ctx := context.Background()
+	f := newChainFixture(t)
+	price := big.NewInt(1e17)
+
+	// One sale before the fee goes up and one after, both tracked once the
+	// new fee is in force
+	early := f.mintedProduct("1")
+	earlyTx := f.chain.sell(1, f.sellerWallet, f.buyerWallet, price)
+	f.chain.commit()
+	f.chain.setFee(50)
+	f.chain.commit()
+	late := f.mintedProduct("2")
+	lateTx := f.chain.sell(2, f.sellerWallet, f.buyerWallet, price)
+	f.chain.commit()
+
+	earlyOrder, err := f.orders.CreateOrder(ctx, early.ID, f.buyer.ID, earlyTx.Hex())
+	require.NoError(t, err)
+	lateOrder, err := f.orders.CreateOrder(ctx, late.ID, f.buyer.ID, lateTx.Hex())
+	require.NoError(t, err)
+	require.NoError(t, f.orders.ProcessPendingOrders(ctx))
+
+	l := &ledgerTest{chainFixture: f}
+	for _, tt := range []struct {
+		order   *models.Order
+		feeRate int64
+		feeWei  string
+	}{
+		{earlyOrder, 25, "2500000000000000"},
+		{lateOrder, 50, "5000000000000000"},
+	} {
+		f.reload(tt.order, tt.order.ID)
+		require.Equal(t, models.OrderStatusCompleted, tt.order.Status)
+		journal := l.journal(tt.order.ID)
+		assert.Equal(t, tt.feeRate, journal.FeeRatePerMille)
+		assert.Equal(t, "100000000000000000", journal.GrossWei)
+		assert.Equal(t, tt.feeWei, journal.FeeWei)
+		assert.True(t, tt.order.CompletedAt.Equal(journal.PostedAt))
+		assertBalanced(t, journal)
+	}
+}
+
+func TestGetRevenueSummary(t *testing.T) {
+	l := &ledgerTest{chainFixture: newChainFixture(t)}
+	other := createUser(t, l.db, common.HexToAddress("0xca7").Hex())
+	// Sunday 1 March, the Monday after, and a month later
+	sunday := time.Date(2026, 3, 1, 23, 30, 0, 0, time.UTC)
+	monday := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	april := time.Date(2026, 4, 15, 12, 0, 0, 0, time.UTC)
+
+	l.post(l.completedOrder(l.seller, "100000000000000000", sunday), 25)
+	l.post(l.completedOrder(other, "200000000000000000", sunday.Add(-time.Hour)), 25)
+	l.post(l.completedOrder(l.seller, "40000000000000000", monday), 50)
+	// Together more than fits in 64 bits
+	l.post(l.completedOrder(other, "9000000000000000000", april), 25)
+	l.post(l.completedOrder(other, "9000000000000000000", april.Add(time.Hour)), 25)
+
+	type bucket struct {
+		period time.Time
+		sales  int64
+		gross  string
+		fees   string
+	}
+	tests := []struct {
+		name     string
+		period   string
+		from, to time.Time
+		want     []bucket
+	}{
+		{
+			name:   "day",
+			period: RevenuePeriodDay,
+			want: []bucket{
+				{time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), 2, "300000000000000000", "7500000000000000"},
+				{time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), 1, "40000000000000000", "2000000000000000"},
+				{time.Date(2026, 4, 15, 0, 0, 0, 0, time.UTC), 2, "18000000000000000000", "450000000000000000"},
+			},
+		},
+		{
+			name:   "week",
+			period: RevenuePeriodWeek,
+			want: []bucket{
+				{time.Date(2026, 2, 23, 0, 0, 0, 0, time.UTC), 2, "300000000000000000", "7500000000000000"},
+				{time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), 1, "40000000000000000", "2000000000000000"},
+				{time.Date(2026, 4, 13, 0, 0, 0, 0, time.UTC), 2, "18000000000000000000", "450000000000000000"},
+			},
+		},
+		{
+			name:   "month",
+			period: RevenuePeriodMonth,
+			want: []bucket{
+				{time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), 3, "340000000000000000", "9500000000000000"},
+				{time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), 2, "18000000000000000000", "450000000000000000"},
+			},
+		},
+		{
+			name:   "bounded",
+			period: RevenuePeriodMonth,
+			from:   monday,
+			to:     april,
+			want: []bucket{
+				{time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), 1, "40000000000000000", "2000000000000000"},
+			},
+		},
+		{
+			name:   "no sales",
+			period: RevenuePeriodDay,
+			from:   april.Add(2 * time.Hour),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			periods, err := l.ledger.GetRevenueSummary(tt.period, tt.from, tt.to)
+			require.NoError(t, err)
+			require.Len(t, periods, len(tt.want))
+			for i, want := range tt.want {
+				assert.True(t, want.period.Equal(periods[i].Period), "period %d starts %s", i, periods[i].Period)
+				assert.Equal(t, want.sales, periods[i].Sales)
+				assert.Equal(t, want.gross, periods[i].GrossWei)
+				assert.Equal(t, want.fees, periods[i].FeesWei)
+				gross, _ := new(big.Int).SetString(want.gross, 10)
+				fees, _ := new(big.Int).SetString(want.fees, 10)
+				assert.Equal(t, new(big.Int).Sub(gross, fees).String(), periods[i].NetWei)
+			}
+		})
+	}
+
+	_, err := l.ledger.GetRevenueSummary("year", time.Time{}, time.Time{})
+	assert.ErrorIs(t, err, ErrInvalidRevenuePeriod)
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/yourusername/revibe/backend/models"
+	"github.com/yourusername/revibe/backend/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	listingPollInterval = 15 * time.Second
+	listingTimeout      = time.Hour
+)
+
+// ListingService links database products to the tokens minted by their
+// listProduct transactions
+type ListingService struct {
+	db       *gorm.DB
+	registry *DeploymentRegistry
+}
+
+// NewListingService creates a new ListingService instance
+func NewListingService(db *gorm.DB, registry *DeploymentRegistry) *ListingService {
+	return &ListingService{
+		db:       db,
+		registry: registry,
+	}
+}
+
+// TrackListing records a submitted listProduct transaction for a product.
+// New listings go to the active deployment.
+func (s *ListingService) TrackListing(productID, txHash string) (*models.ListingTransaction, error) {
+	if len(txHash) != 66 || !strings.HasPrefix(txHash, "0x") {
+		return nil, fmt.Errorf("invalid transaction hash: %s", txHash)
+	}
+
+	active := s.registry.Active()
+	listing := models.ListingTransaction{
+		ProductID:       productID,
+		TxHash:          common.HexToHash(txHash).Hex(),
+		ChainID:         active.ChainID().Int64(),
+		ContractAddress: active.ContractAddress().Hex(),
+		Status:          models.ListingStatusPending,
+	}
+	if err := s.db.Create(&listing).Error; err != nil {
+		return nil, fmt.Errorf("failed to save listing transaction: %v", err)
+	}
+
+	return &listing, nil
+}
+
+// GetProductByToken finds the product linked to a token on the active deployment
+func (s *ListingService) GetProductByToken(tokenID string) (*models.Product, error) {
+	active := s.registry.Active()
+	var product models.Product
+	err := s.db.Where("token_id = ? AND chain_id = ? AND contract_address = ?",
+		tokenID,
+		active.ChainID().Int64(),
+		active.ContractAddress().Hex(),
+	).First(&product).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &product, nil
+}
+
+// StartListingTracker polls receipts of pending listing transactions until ctx is cancelled
+func (s *ListingService) StartListingTracker(ctx context.Context) {
+	ticker := time.NewTicker(listingPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.ProcessPendingListings(ctx); err != nil {
+				utils.LogError(err, map[string]interface{}{
+					"component": "listing_tracker",
+				})
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ProcessPendingListings checks every pending listing transaction once
+func (s *ListingService) ProcessPendingListings(ctx context.Context) error {
+	var listings []models.ListingTransaction
+	if err := s.db.Where("status = ?", models.ListingStatusPending).Find(&listings).Error; err != nil {
+		return fmt.Errorf("failed to fetch pending listings: %v", err)
+	}
+
+	for i := range listings {
+		if err := s.processListing(ctx, &listings[i]); err != nil {
+			utils.LogError(err, map[string]interface{}{
+				"component": "listing_tracker",
+				"tx_hash":   listings[i].TxHash,
+			})
+		}
+	}
+
+	return nil
+}
+
+func (s *ListingService) processListing(ctx context.Context, listing *models.ListingTransaction) error {
+	web3Service, err := s.registry.Get(listing.ChainID, listing.ContractAddress)
+	if err != nil {
+		return err
+	}
+
+	receipt, err := web3Service.GetTransactionReceipt(ctx, listing.TxHash)
+	if err == ethereum.NotFound {
+		if time.Since(listing.CreatedAt) > listingTimeout {
+			return s.failListing(listing, models.ListingStatusExpired, "transaction not mined in time")
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get receipt: %v", err)
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return s.failListing(listing, models.ListingStatusFailed, "transaction reverted")
+	}
+
+	event := web3Service.FindProductListed(receipt)
+	if event == nil {
+		return s.failListing(listing, models.ListingStatusFailed, "no ProductListed event in receipt")
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var product models.Product
+		if err := tx.Preload("Seller").First(&product, "id = ?", listing.ProductID).Error; err != nil {
+			return fmt.Errorf("failed to fetch product: %v", err)
+		}
+
+		// Only the product's seller may link a token to it
+		if !strings.EqualFold(product.Seller.WalletAddress, event.Seller.Hex()) {
+			listing.Status = models.ListingStatusFailed
+			listing.Error = "listing seller does not match product seller"
+			return tx.Save(listing).Error
+		}
+
+		tokenID := event.TokenId.String()
+		owner, err := tokenOwner(tx, listing.ChainID, listing.ContractAddress, tokenID, event.Seller.Hex())
+		if err != nil {
+			return err
+		}
+
+		// A product links to one token. If another listing transaction for
+		// it was linked first, this one fails rather than orphaning that token.
+		linked := tx.Model(&models.Product{}).
+			Where("id = ? AND token_id IS NULL", product.ID).
+			Updates(map[string]interface{}{
+				"token_id":         tokenID,
+				"chain_id":         listing.ChainID,
+				"contract_address": listing.ContractAddress,
+				"deployment_id":    deploymentID(web3Service),
+				"owner_address":    owner,
+				"price_wei":        event.Price.String(),
+			})
+		if linked.Error != nil {
+			return fmt.Errorf("failed to link product: %v", linked.Error)
+		}
+		if linked.RowsAffected == 0 {
+			var current models.Product
+			if err := tx.First(&current, "id = ?", product.ID).Error; err != nil {
+				return fmt.Errorf("failed to fetch product: %v", err)
+			}
+			if current.TokenID == nil || *current.TokenID != tokenID ||
+				current.ChainID != listing.ChainID || current.ContractAddress != listing.ContractAddress {
+				listing.Status = models.ListingStatusFailed
+				listing.Error = "product is already linked to another token"
+				return tx.Save(listing).Error
+			}
+		}
+
+		now := time.Now()
+		listing.Status = models.ListingStatusConfirmed
+		listing.TokenID = &tokenID
+		listing.BlockNumber = receipt.BlockNumber.Uint64()
+		listing.ConfirmedAt = &now
+		if err := tx.Save(listing).Error; err != nil {
+			return fmt.Errorf("failed to update listing transaction: %v", err)
+		}
+		return nil
+	})
+}
+
+func (s *ListingService) failListing(listing *models.ListingTransaction, status, reason string) error {
+	listing.Status = status
+	listing.Error = reason
+	return s.db.Save(listing).Error
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/models"
+)
+
+// listingTest is a listing service over the mock chain
+type listingTest struct {
+	*chainFixture
+	listings *ListingService
+}
+
+func newListingTest(t *testing.T) *listingTest {
+	f := newChainFixture(t)
+	return &listingTest{chainFixture: f, listings: NewListingService(f.db, f.registry)}
+}
+
+// track records a listing transaction for a product
+func (l *listingTest) track(product *models.Product, txHash common.Hash) *models.ListingTransaction {
+	listing, err := l.listings.TrackListing(product.ID, txHash.Hex())
+	require.NoError(l.t, err)
+	return listing
+}
+
+// process checks a listing transaction once and reloads it and its product
+func (l *listingTest) process(listing *models.ListingTransaction, product *models.Product) {
+	require.NoError(l.t, l.listings.processListing(context.Background(), listing))
+	l.reload(listing, listing.ID)
+	l.reload(product, product.ID)
+}
+
+func TestTrackListing(t *testing.T) {
+	l := newListingTest(t)
+	product := createProduct(t, l.db, l.seller)
+
+	for _, hash := range []string{"", "0x1234", strings.Repeat("a", 66), "0x" + strings.Repeat("a", 66)} {
+		_, err := l.listings.TrackListing(product.ID, hash)
+		assert.Error(t, err, hash)
+	}
+
+	listing, err := l.listings.TrackListing(product.ID, "0x"+strings.Repeat("AB", 32))
+	require.NoError(t, err)
+	assert.Equal(t, "0x"+strings.Repeat("ab", 32), listing.TxHash)
+	assert.Equal(t, l.chainID, listing.ChainID)
+	assert.Equal(t, l.contract, listing.ContractAddress)
+	assert.Equal(t, models.ListingStatusPending, listing.Status)
+
+	// Each transaction is tracked once
+	_, err = l.listings.TrackListing(product.ID, listing.TxHash)
+	assert.Error(t, err)
+}
+
+func TestProcessListing(t *testing.T) {
+	price := big.NewInt(2e17)
+
+	tests := []struct {
+		name   string
+		send   func(l *listingTest) common.Hash
+		status string
+		reason string
+	}{
+		{
+			name:   "listed",
+			send:   func(l *listingTest) common.Hash { return l.chain.list(7, l.sellerWallet, price) },
+			status: models.ListingStatusConfirmed,
+		},
+		{
+			name:   "reverted",
+			send:   func(l *listingTest) common.Hash { return l.chain.send(common.FromHex("0x12345678")) },
+			status: models.ListingStatusFailed,
+			reason: "transaction reverted",
+		},
+		{
+			name:   "no listing event",
+			send:   func(l *listingTest) common.Hash { return l.chain.transfer(7, common.Address{}, l.sellerWallet) },
+			status: models.ListingStatusFailed,
+			reason: "no ProductListed event in receipt",
+		},
+		{
+			name:   "listed by someone else",
+			send:   func(l *listingTest) common.Hash { return l.chain.list(7, l.buyerWallet, price) },
+			status: models.ListingStatusFailed,
+			reason: "listing seller does not match product seller",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newListingTest(t)
+			product := createProduct(t, l.db, l.seller)
+			listing := l.track(product, tt.send(l))
+			block := l.chain.commit()
+
+			l.process(listing, product)
+			assert.Equal(t, tt.status, listing.Status)
+			assert.Equal(t, tt.reason, listing.Error)
+
+			if tt.status != models.ListingStatusConfirmed {
+				assert.Nil(t, product.TokenID)
+				return
+			}
+			require.NotNil(t, listing.TokenID)
+			assert.Equal(t, "7", *listing.TokenID)
+			assert.Equal(t, block, listing.BlockNumber)
+			assert.NotNil(t, listing.ConfirmedAt)
+
+			require.NotNil(t, product.TokenID)
+			assert.Equal(t, "7", *product.TokenID)
+			assert.Equal(t, l.chainID, product.ChainID)
+			assert.Equal(t, l.contract, product.ContractAddress)
+			assert.Equal(t, l.sellerWallet.Hex(), product.OwnerAddress)
+			assert.Equal(t, price.String(), product.PriceWei)
+		})
+	}
+}
+
+func TestProcessListingNotMined(t *testing.T) {
+	l := newListingTest(t)
+	product := createProduct(t, l.db, l.seller)
+	// Until a block is mined the node reports its transaction index as
+	// still being built
+	l.chain.commit()
+	listing := l.track(product, l.chain.list(7, l.sellerWallet, oneEther))
+
+	l.process(listing, product)
+	assert.Equal(t, models.ListingStatusPending, listing.Status)
+
+	require.NoError(t, l.db.Model(listing).Update("created_at", time.Now().Add(-listingTimeout-time.Minute)).Error)
+	l.reload(listing, listing.ID)
+	l.process(listing, product)
+	assert.Equal(t, models.ListingStatusExpired, listing.Status)
+	assert.Nil(t, product.TokenID)
+}
+
+func TestProcessListingLinksOneToken(t *testing.T) {
+	l := newListingTest(t)
+	product := createProduct(t, l.db, l.seller)
+	first := l.track(product, l.chain.list(7, l.sellerWallet, oneEther))
+	second := l.track(product, l.chain.list(8, l.sellerWallet, oneEther))
+	l.chain.commit()
+
+	l.process(first, product)
+	l.process(second, product)
+
+	assert.Equal(t, models.ListingStatusConfirmed, first.Status)
+	assert.Equal(t, models.ListingStatusFailed, second.Status)
+	assert.Equal(t, "product is already linked to another token", second.Error)
+	require.NotNil(t, product.TokenID)
+	assert.Equal(t, "7", *product.TokenID)
+
+	// A listing whose token was already linked, by the indexer say, confirms
+	already := l.mintedProduct("9")
+	listing := l.track(already, l.chain.list(9, l.sellerWallet, oneEther))
+	l.chain.commit()
+	l.process(listing, already)
+	assert.Equal(t, models.ListingStatusConfirmed, listing.Status)
+	assert.Equal(t, "9", *already.TokenID)
+}
+
+func TestProcessPendingListings(t *testing.T) {
+	l := newListingTest(t)
+	listed := createProduct(t, l.db, l.seller)
+	unmined := createProduct(t, l.db, l.seller)
+	l.track(listed, l.chain.list(7, l.sellerWallet, oneEther))
+	l.chain.commit()
+	l.track(unmined, l.chain.list(8, l.sellerWallet, oneEther))
+
+	require.NoError(t, l.listings.ProcessPendingListings(context.Background()))
+	l.reload(listed, listed.ID)
+	l.reload(unmined, unmined.ID)
+	require.NotNil(t, listed.TokenID)
+	assert.Equal(t, "7", *listed.TokenID)
+	assert.Nil(t, unmined.TokenID)
+
+	product, err := l.listings.GetProductByToken("7")
+	require.NoError(t, err)
+	assert.Equal(t, listed.ID, product.ID)
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/revibe/backend/config"
+	"github.com/yourusername/revibe/backend/models"
+	"gorm.io/gorm"
+)
+
+// Authentication statuses reported in token metadata
+const (
+	MetadataAuthPending       = "Pending"
+	MetadataAuthAuthenticated = "Authenticated"
+	MetadataAuthRejected      = "Rejected"
+	MetadataAuthExpired       = "Expired"
+	MetadataAuthRevoked       = "Revoked"
+)
+
+var (
+	ErrInvalidTokenID   = errors.New("invalid token ID")
+	ErrChainUnavailable = errors.New("chain unavailable")
+)
+
+// Tokens resolved from the chain keep their product, as a listing's metadata
+// URI never changes; tokens the chain did not know are looked up again after
+// tokenMissTTL in case they have since been listed. At most
+// tokenLookupCacheSize lookups are kept.
+const (
+	tokenMissTTL         = time.Minute
+	tokenLookupCacheSize = 10000
+)
+
+// tokenLookup is a cached chain lookup of a token's product. productID is
+// empty for tokens the chain did not know.
+type tokenLookup struct {
+	productID string
+	expiresAt time.Time
+}
+
+// TokenAttribute is an OpenSea-compatible metadata attribute
+type TokenAttribute struct {
+	TraitType   string      `json:"trait_type"`
+	Value       interface{} `json:"value"`
+	DisplayType string      `json:"display_type,omitempty"`
+	MaxValue    interface{} `json:"max_value,omitempty"`
+}
+
+// TokenAuthentication summarises the latest authentication of a product
+type TokenAuthentication struct {
+	Status          string  `json:"status"`
+	Score           float64 `json:"score,omitempty"`
+	AuthenticatedAt int64   `json:"authenticated_at,omitempty"`
+	EvidenceRoot    string  `json:"evidence_root,omitempty"`
+}
+
+// TokenMetadata is the ERC-721 metadata JSON served for a product
+type TokenMetadata struct {
+	Name           string              `json:"name"`
+	Description    string              `json:"description"`
+	Image          string              `json:"image,omitempty"`
+	ExternalURL    string              `json:"external_url,omitempty"`
+	Attributes     []TokenAttribute    `json:"attributes"`
+	Authentication TokenAuthentication `json:"authentication"`
+}
+
+// MetadataService builds ERC-721 metadata for listed products
+type MetadataService struct {
+	db            *gorm.DB
+	web3Service   *Web3Service
+	uploadService *UploadService
+	baseURL       string
+	evidenceRoot  bool
+	missTTL       time.Duration
+
+	lookupsMu sync.Mutex
+	lookups   map[string]tokenLookup
+}
+
+// NewMetadataService creates a new MetadataService instance
+func NewMetadataService(db *gorm.DB, web3Service *Web3Service, uploadService *UploadService) *MetadataService {
+	return &MetadataService{
+		db:            db,
+		web3Service:   web3Service,
+		uploadService: uploadService,
+		baseURL:       strings.TrimSuffix(config.AppConfig.BaseURL, "/"),
+		evidenceRoot:  config.AppConfig.EvidenceRootInMetadata,
+		missTTL:       tokenMissTTL,
+	}
+}
+
+// MetadataURI returns the metadata URL passed to listProduct for a product.
+// The token ID is only assigned once the listing is mined, so the URL is keyed
+// by product ID; the token ID form resolves to the same document.
+func (s *MetadataService) MetadataURI(productID string) string {
+	return s.baseURL + "/metadata/" + productID
+}
+
+// TokenURI returns the metadata URL for a minted token
+func (s *MetadataService) TokenURI(tokenID *big.Int) string {
+	return s.baseURL + "/metadata/" + tokenID.String()
+}
+
+// ResolveProductID maps a metadata path parameter to a product ID. The
+// parameter is either a product ID or a token ID of the active deployment.
+// Token IDs are looked up in the products table first and fall back to the
+// metadata URI stored on chain at listing time for listings not yet linked.
+// Chain lookups are cached. Failed chain calls return ErrChainUnavailable.
+func (s *MetadataService) ResolveProductID(ref string) (string, error) {
+	if _, err := uuid.Parse(ref); err == nil {
+		return ref, nil
+	}
+
+	tokenID, ok := new(big.Int).SetString(ref, 10)
+	if !ok || tokenID.Sign() <= 0 {
+		return "", fmt.Errorf("%w: %s", ErrInvalidTokenID, ref)
+	}
+
+	// Tokens linked from their listing receipt resolve without a chain call
+	var product models.Product
+	err := s.db.Select("id").Where("token_id = ? AND chain_id = ? AND contract_address = ?",
+		tokenID.String(),
+		s.web3Service.ChainID().Int64(),
+		s.web3Service.ContractAddress().Hex(),
+	).First(&product).Error
+	if err == nil {
+		return product.ID, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return "", fmt.Errorf("failed to fetch product: %v", err)
+	}
+
+	key := tokenID.String()
+	if lookup, ok := s.cachedLookup(key); ok {
+		if lookup.productID == "" {
+			return "", gorm.ErrRecordNotFound
+		}
+		return lookup.productID, nil
+	}
+
+	metadata, err := s.web3Service.GetProductMetadata(tokenID)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrChainUnavailable, err)
+	}
+
+	productID := s.productIDFromURI(metadata)
+	lookup := tokenLookup{productID: productID}
+	if productID == "" {
+		lookup.expiresAt = time.Now().Add(s.missTTL)
+	}
+	s.cacheLookup(key, lookup)
+
+	if productID == "" {
+		return "", gorm.ErrRecordNotFound
+	}
+	return productID, nil
+}
+
+// productIDFromURI returns the product ID in one of this backend's metadata
+// URIs, or an empty string for any other URI
+func (s *MetadataService) productIDFromURI(uri string) string {
+	prefix := s.baseURL + "/metadata/"
+	if !strings.HasPrefix(uri, prefix) {
+		return ""
+	}
+
+	productID := strings.TrimPrefix(uri, prefix)
+	if _, err := uuid.Parse(productID); err != nil {
+		return ""
+	}
+	return productID
+}
+
+func (s *MetadataService) cachedLookup(tokenID string) (tokenLookup, bool) {
+	s.lookupsMu.Lock()
+	defer s.lookupsMu.Unlock()
+
+	lookup, ok := s.lookups[tokenID]
+	if !ok || (!lookup.expiresAt.IsZero() && time.Now().After(lookup.expiresAt)) {
+		return tokenLookup{}, false
+	}
+	return lookup, true
+}
+
+func (s *MetadataService) cacheLookup(tokenID string, lookup tokenLookup) {
+	s.lookupsMu.Lock()
+	defer s.lookupsMu.Unlock()
+
+	if s.lookups == nil || len(s.lookups) >= tokenLookupCacheSize {
+		s.lookups = make(map[string]tokenLookup)
+	}
+	s.lookups[tokenID] = lookup
+}
+
+// BuildMetadata generates the metadata document for a product from its
+// current state, so authentication results are reflected as soon as they are
+// recorded
+func (s *MetadataService) BuildMetadata(productID string) (*TokenMetadata, error) {
+	var product models.Product
+	if err := s.db.Preload("Images").First(&product, "id = ?", productID).Error; err != nil {
+		return nil, err
+	}
+
+	metadata := &TokenMetadata{
+		Name:        product.Name,
+		Description: product.Description,
+		Attributes: []TokenAttribute{
+			{TraitType: "Category", Value: product.Category},
+			{TraitType: "Condition", Value: product.Condition},
+		},
+		Authentication: TokenAuthentication{
+			Status: MetadataAuthPending,
+		},
+	}
+	if len(product.Images) > 0 {
+		metadata.Image = s.uploadService.GetFileURL(product.Images[0].URL)
+	}
+
+	var auth models.Authentication
+	err := s.db.Preload("Evidence").Where("product_id = ?", product.ID).Order("created_at desc").First(&auth).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, fmt.Errorf("failed to fetch authentication: %v", err)
+	}
+	if err == nil {
+		metadata.Authentication = TokenAuthentication{
+			Status:          MetadataAuthRejected,
+			Score:           auth.Score,
+			AuthenticatedAt: auth.CreatedAt.Unix(),
+		}
+		switch auth.CurrentStatus(time.Now()) {
+		case models.AuthStatusActive:
+			metadata.Authentication.Status = MetadataAuthAuthenticated
+		case models.AuthStatusExpired:
+			metadata.Authentication.Status = MetadataAuthExpired
+		case models.AuthStatusRevoked:
+			metadata.Authentication.Status = MetadataAuthRevoked
+		case models.AuthVerdictInconclusive:
+			metadata.Authentication.Status = MetadataAuthPending
+		}
+		metadata.Attributes = append(metadata.Attributes,
+			TokenAttribute{TraitType: "Authentication Score", Value: auth.Score, DisplayType: "number", MaxValue: 100},
+			TokenAttribute{TraitType: "Authenticated On", Value: auth.CreatedAt.Unix(), DisplayType: "date"},
+		)
+
+		// The token URI stored on chain resolves here, so publishing the
+		// root lets holders check evidence against the token's metadata
+		if s.evidenceRoot && auth.Evidence != nil {
+			metadata.Authentication.EvidenceRoot = auth.Evidence.MerkleRoot
+			metadata.Attributes = append(metadata.Attributes,
+				TokenAttribute{TraitType: "Evidence Root", Value: auth.Evidence.MerkleRoot},
+			)
+		}
+	}
+	metadata.Attributes = append(metadata.Attributes,
+		TokenAttribute{TraitType: "Authentication", Value: metadata.Authentication.Status},
+	)
+
+	return metadata, nil
+}
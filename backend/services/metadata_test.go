@@ -0,0 +1,151 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const testMetadataBaseURL = "https://revibe.example"
+
+// metadataTest is a metadata service reading the mock chain
+type metadataTest struct {
+	*chainFixture
+	service *MetadataService
+}
+
+func newMetadataTest(t *testing.T) *metadataTest {
+	f := newChainFixture(t)
+	return &metadataTest{
+		chainFixture: f,
+		service: &MetadataService{
+			db:          f.db,
+			web3Service: f.chain.web3,
+			baseURL:     testMetadataBaseURL,
+			missTTL:     time.Hour,
+		},
+	}
+}
+
+// onChain has the contract report a token listed with a metadata URI; an
+// empty URI is the contract's answer for a token it does not know
+func (m *metadataTest) onChain(tokenID int64, uri string) {
+	price := big.NewInt(0)
+	if uri != "" {
+		price = oneEther
+	}
+	m.chain.respond("products", []interface{}{big.NewInt(tokenID)},
+		big.NewInt(tokenID), m.sellerWallet, price, false, false, uri)
+	m.chain.commit()
+}
+
+// offline swaps the service onto a chain with the same deployment that
+// answers no calls, so any chain read fails
+func (m *metadataTest) offline() {
+	m.service.web3Service = newMockChain(m.t).web3
+}
+
+func TestResolveProductID(t *testing.T) {
+	t.Run("product ID", func(t *testing.T) {
+		m := newMetadataTest(t)
+		product := createProduct(t, m.db, m.seller)
+		m.offline()
+
+		productID, err := m.service.ResolveProductID(product.ID)
+		require.NoError(t, err)
+		assert.Equal(t, product.ID, productID)
+	})
+
+	t.Run("invalid token ID", func(t *testing.T) {
+		m := newMetadataTest(t)
+		for _, ref := range []string{"abc", "0", "-1", "1.5"} {
+			_, err := m.service.ResolveProductID(ref)
+			assert.ErrorIs(t, err, ErrInvalidTokenID, ref)
+		}
+	})
+
+	t.Run("linked token", func(t *testing.T) {
+		m := newMetadataTest(t)
+		product := m.mintedProduct("7")
+		m.offline()
+
+		productID, err := m.service.ResolveProductID("7")
+		require.NoError(t, err)
+		assert.Equal(t, product.ID, productID)
+	})
+
+	t.Run("unlinked token", func(t *testing.T) {
+		m := newMetadataTest(t)
+		product := createProduct(t, m.db, m.seller)
+		m.onChain(7, testMetadataBaseURL+"/metadata/"+product.ID)
+
+		productID, err := m.service.ResolveProductID("7")
+		require.NoError(t, err)
+		assert.Equal(t, product.ID, productID)
+
+		// Served from the cache once resolved
+		m.offline()
+		productID, err = m.service.ResolveProductID("7")
+		require.NoError(t, err)
+		assert.Equal(t, product.ID, productID)
+	})
+
+	t.Run("listed with another URI", func(t *testing.T) {
+		m := newMetadataTest(t)
+		m.onChain(7, "ipfs://QmToken7")
+
+		_, err := m.service.ResolveProductID("7")
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		m := newMetadataTest(t)
+		product := createProduct(t, m.db, m.seller)
+		m.onChain(7, "")
+
+		_, err := m.service.ResolveProductID("7")
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		// Listed since, but the miss is remembered until it expires
+		m.onChain(7, testMetadataBaseURL+"/metadata/"+product.ID)
+		_, err = m.service.ResolveProductID("7")
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+		m.service.lookups["7"] = tokenLookup{expiresAt: time.Now().Add(-time.Second)}
+		productID, err := m.service.ResolveProductID("7")
+		require.NoError(t, err)
+		assert.Equal(t, product.ID, productID)
+	})
+
+	t.Run("chain unavailable", func(t *testing.T) {
+		m := newMetadataTest(t)
+		product := createProduct(t, m.db, m.seller)
+
+		// The mock contract reverts calls it has no answer for
+		_, err := m.service.ResolveProductID("7")
+		assert.ErrorIs(t, err, ErrChainUnavailable)
+
+		// Failures are not cached
+		m.onChain(7, testMetadataBaseURL+"/metadata/"+product.ID)
+		productID, err := m.service.ResolveProductID("7")
+		require.NoError(t, err)
+		assert.Equal(t, product.ID, productID)
+	})
+}
+
+func TestTokenLookupCacheIsBounded(t *testing.T) {
+	service := &MetadataService{}
+	for i := 0; i < tokenLookupCacheSize; i++ {
+		service.cacheLookup(big.NewInt(int64(i)).String(), tokenLookup{productID: "p"})
+	}
+	assert.Len(t, service.lookups, tokenLookupCacheSize)
+
+	service.cacheLookup("next", tokenLookup{productID: "p"})
+	assert.Len(t, service.lookups, 1)
+	_, ok := service.cachedLookup("next")
+	assert.True(t, ok)
+}
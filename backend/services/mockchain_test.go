@@ -0,0 +1,363 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/program"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/contracts"
+)
+
+// mockReVibeAddress is where the mock contract sits in the genesis block
+var mockReVibeAddress = common.HexToAddress("0x000000000000000000000000000000000000beef")
+
+// mockProgramSelector is the selector of the mock's call that stores a
+// response to give to some other calldata
+const mockProgramSelector = 0xffffffff
+
+// Jump targets in the mock contract's code
+const (
+	mockEmit = iota
+	mockEmitLoop
+	mockEmitEnd
+	mockLog1
+	mockLog2
+	mockLog3
+	mockLog4
+	mockProgram
+	mockProgramLoop
+	mockProgramDone
+	mockRespond
+	mockRespondLoop
+	mockRespondDone
+	mockRevert
+	mockTargets
+)
+
+func dup(n int) vm.OpCode {
+	return vm.DUP1 + vm.OpCode(n-1)
+}
+
+// mockReVibeCode returns the runtime code of a contract that stands in for
+// ReVibe in tests that drive the backend from contract events rather than
+// from the contract's logic:
+//
+//   - a call with selector 0 emits the logs packed in the rest of its
+//     calldata, as records of [topic count][topics][data length][data]
+//   - a call with selector 0xffffffff stores a response for some other
+//     calldata, as [keccak256 of the calldata][length][words]
+//   - any other call returns the response stored for its calldata, or
+//     reverts if there is none
+//
+// Responses live in storage, so calls at a past block see the responses
+// stored by then.
+func mockReVibeCode() []byte {
+	// Jump targets are only known once the code is laid out, so it is built
+	// twice. Targets are always pushed with PUSH2, so both passes lay the
+	// code out the same.
+	var targets [mockTargets]uint64
+	var code []byte
+	for pass := 0; pass < 2; pass++ {
+		var found [mockTargets]uint64
+		p := program.New()
+		mark := func(target int) {
+			_, found[target] = p.Jumpdest()
+		}
+		pushTarget := func(target int) {
+			p.Op(vm.PUSH2).Append([]byte{byte(targets[target] >> 8), byte(targets[target])})
+		}
+		jump := func(target int) {
+			pushTarget(target)
+			p.Op(vm.JUMP)
+		}
+		jumpIf := func(target int) {
+			pushTarget(target)
+			p.Op(vm.JUMPI)
+		}
+
+		// Dispatch on the selector
+		p.Push(0).Op(vm.CALLDATALOAD).Push(224).Op(vm.SHR)
+		p.Op(vm.DUP1, vm.ISZERO)
+		jumpIf(mockEmit)
+		p.Push(uint64(mockProgramSelector)).Op(vm.EQ)
+		jumpIf(mockProgram)
+		jump(mockRespond)
+
+		// Emit each record's log, keeping a pointer to the record on the stack
+		mark(mockEmit)
+		p.Op(vm.POP, vm.CALLDATASIZE).Push(0).Push(0).Op(vm.CALLDATACOPY)
+		p.Push(4)
+		mark(mockEmitLoop)
+		p.Op(vm.DUP1, vm.CALLDATASIZE, vm.GT, vm.ISZERO)
+		jumpIf(mockEmitEnd)
+		for k := 1; k <= 4; k++ {
+			p.Op(vm.DUP1, vm.MLOAD).Push(k).Op(vm.EQ)
+			jumpIf(mockLog1 + k - 1)
+		}
+		p.Push(0).Op(vm.DUP1, vm.REVERT)
+		for k := 1; k <= 4; k++ {
+			mark(mockLog1 + k - 1)
+			for j := 0; j < k; j++ {
+				p.Op(dup(1+j)).Push(32+32*(k-1-j)).Op(vm.ADD, vm.MLOAD)
+			}
+			p.Op(dup(k+1)).Push(32+32*k).Op(vm.ADD, vm.MLOAD)
+			p.Op(dup(k + 2)).Push(64 + 32*k).Op(vm.ADD)
+			p.Op(vm.LOG0 + vm.OpCode(k))
+			p.Op(vm.DUP1).Push(32+32*k).Op(vm.ADD, vm.MLOAD, vm.ADD).Push(64 + 32*k).Op(vm.ADD)
+			jump(mockEmitLoop)
+		}
+		mark(mockEmitEnd)
+		p.Op(vm.STOP)
+
+		// Store a response: its length at the key, then its words
+		mark(mockProgram)
+		p.Push(36).Op(vm.CALLDATALOAD).Push(4).Op(vm.CALLDATALOAD, vm.SSTORE)
+		p.Push(0)
+		mark(mockProgramLoop)
+		p.Op(vm.DUP1).Push(32).Op(vm.MUL).Push(36).Op(vm.CALLDATALOAD, vm.GT, vm.ISZERO)
+		jumpIf(mockProgramDone)
+		p.Op(vm.DUP1).Push(32).Op(vm.MUL).Push(68).Op(vm.ADD, vm.CALLDATALOAD)
+		p.Op(vm.DUP2).Push(4).Op(vm.CALLDATALOAD, vm.ADD).Push(1).Op(vm.ADD, vm.SSTORE)
+		p.Push(1).Op(vm.ADD)
+		jump(mockProgramLoop)
+		mark(mockProgramDone)
+		p.Op(vm.STOP)
+
+		// Return the response stored for the calldata
+		mark(mockRespond)
+		p.Op(vm.CALLDATASIZE).Push(0).Push(0).Op(vm.CALLDATACOPY)
+		p.Op(vm.CALLDATASIZE).Push(0).Op(vm.KECCAK256)
+		p.Op(vm.DUP1, vm.SLOAD)
+		p.Op(vm.DUP1, vm.ISZERO)
+		jumpIf(mockRevert)
+		p.Push(0)
+		mark(mockRespondLoop)
+		p.Op(vm.DUP1).Push(32).Op(vm.MUL, vm.DUP3, vm.GT, vm.ISZERO)
+		jumpIf(mockRespondDone)
+		p.Op(vm.DUP1, vm.DUP4, vm.ADD).Push(1).Op(vm.ADD, vm.SLOAD)
+		p.Op(vm.DUP2).Push(32).Op(vm.MUL, vm.MSTORE)
+		p.Push(1).Op(vm.ADD)
+		jump(mockRespondLoop)
+		mark(mockRespondDone)
+		p.Op(vm.POP).Push(0).Op(vm.RETURN)
+		mark(mockRevert)
+		p.Push(0).Op(vm.DUP1, vm.REVERT)
+
+		targets = found
+		code = p.Bytes()
+	}
+	return code
+}
+
+// mockResponseSlots lays out a stored response the way the mock contract
+// reads it
+func mockResponseSlots(calldata, response []byte) map[common.Hash]common.Hash {
+	key := new(big.Int).SetBytes(crypto.Keccak256(calldata))
+	slots := map[common.Hash]common.Hash{
+		common.BigToHash(key): common.BigToHash(big.NewInt(int64(len(response)))),
+	}
+	for i := 0; i*32 < len(response); i++ {
+		slot := new(big.Int).Add(key, big.NewInt(int64(i+1)))
+		slots[common.BigToHash(slot)] = common.BytesToHash(common.RightPadBytes(response[i*32:], 32)[:32])
+	}
+	return slots
+}
+
+// mockLog is a log for the mock contract to emit: a ReVibe event and its
+// arguments in the ABI's order
+type mockLog struct {
+	event string
+	args  []interface{}
+}
+
+// mockChain is the mock contract on go-ethereum's in-memory chain, with a
+// funded account to send its calls and a Web3Service talking to it
+type mockChain struct {
+	t       *testing.T
+	backend *simulated.Backend
+	client  simulated.Client
+	web3    *Web3Service
+	abi     *abi.ABI
+	chainID *big.Int
+	key     *ecdsa.PrivateKey
+	from    common.Address
+}
+
+// newMockChain starts a simulated chain with the mock contract charging the
+// default 2.5% platform fee
+func newMockChain(t *testing.T) *mockChain {
+	contractABI, err := contracts.ReVibeContractMetaData.GetAbi()
+	require.NoError(t, err)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	feeCall, err := contractABI.Pack("platformFee")
+	require.NoError(t, err)
+	fee, err := contractABI.Methods["platformFee"].Outputs.Pack(big.NewInt(25))
+	require.NoError(t, err)
+
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		from: {Balance: new(big.Int).Mul(big.NewInt(100), oneEther)},
+		mockReVibeAddress: {
+			Code:    mockReVibeCode(),
+			Storage: mockResponseSlots(feeCall, fee),
+		},
+	})
+	t.Cleanup(func() { backend.Close() })
+	client := backend.Client()
+
+	chainID, err := client.ChainID(context.Background())
+	require.NoError(t, err)
+	web3, err := NewWeb3ServiceWithBackend(client, chainID, mockReVibeAddress)
+	require.NoError(t, err)
+
+	return &mockChain{
+		t:       t,
+		backend: backend,
+		client:  client,
+		web3:    web3,
+		abi:     contractABI,
+		chainID: chainID,
+		key:     key,
+		from:    from,
+	}
+}
+
+// send sends a call to the mock contract without mining it
+func (c *mockChain) send(data []byte) common.Hash {
+	ctx := context.Background()
+	nonce, err := c.client.PendingNonceAt(ctx, c.from)
+	require.NoError(c.t, err)
+
+	to := mockReVibeAddress
+	tx, err := types.SignNewTx(c.key, types.LatestSignerForChainID(c.chainID), &types.DynamicFeeTx{
+		ChainID:   c.chainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1e9),
+		GasFeeCap: big.NewInt(100e9),
+		Gas:       5000000,
+		To:        &to,
+		Data:      data,
+	})
+	require.NoError(c.t, err)
+	require.NoError(c.t, c.client.SendTransaction(ctx, tx))
+	return tx.Hash()
+}
+
+// commit mines the pending calls and returns the new block's number
+func (c *mockChain) commit() uint64 {
+	c.backend.Commit()
+	head, err := c.client.BlockNumber(context.Background())
+	require.NoError(c.t, err)
+	return head
+}
+
+// respond has the contract answer a view call with results from now on
+func (c *mockChain) respond(method string, args []interface{}, results ...interface{}) common.Hash {
+	calldata, err := c.abi.Pack(method, args...)
+	require.NoError(c.t, err)
+	response, err := c.abi.Methods[method].Outputs.Pack(results...)
+	require.NoError(c.t, err)
+
+	data := make([]byte, 4, 68+len(response))
+	copy(data, common.FromHex("0xffffffff"))
+	data = append(data, crypto.Keccak256(calldata)...)
+	data = append(data, common.BigToHash(big.NewInt(int64(len(response)))).Bytes()...)
+	data = append(data, common.RightPadBytes(response, (len(response)+31)/32*32)...)
+	return c.send(data)
+}
+
+// emit has the contract emit logs in one transaction
+func (c *mockChain) emit(logs ...mockLog) common.Hash {
+	data := make([]byte, 4)
+	for _, l := range logs {
+		event, ok := c.abi.Events[l.event]
+		require.True(c.t, ok, l.event)
+
+		topics := []common.Hash{event.ID}
+		var values []interface{}
+		for i, input := range event.Inputs {
+			if !input.Indexed {
+				values = append(values, l.args[i])
+				continue
+			}
+			topic, err := abi.MakeTopics([]interface{}{l.args[i]})
+			require.NoError(c.t, err)
+			topics = append(topics, topic[0][0])
+		}
+		logData, err := event.Inputs.NonIndexed().Pack(values...)
+		require.NoError(c.t, err)
+
+		data = append(data, common.BigToHash(big.NewInt(int64(len(topics)))).Bytes()...)
+		for _, topic := range topics {
+			data = append(data, topic.Bytes()...)
+		}
+		data = append(data, common.BigToHash(big.NewInt(int64(len(logData)))).Bytes()...)
+		data = append(data, logData...)
+	}
+	return c.send(data)
+}
+
+// The logs the ReVibe contract emits for each of its calls
+
+func (c *mockChain) list(tokenID int64, seller common.Address, price *big.Int) common.Hash {
+	token := big.NewInt(tokenID)
+	return c.emit(
+		mockLog{EventTransfer, []interface{}{common.Address{}, seller, token}},
+		mockLog{EventProductListed, []interface{}{token, seller, price}},
+	)
+}
+
+func (c *mockChain) sell(tokenID int64, seller, buyer common.Address, price *big.Int) common.Hash {
+	token := big.NewInt(tokenID)
+	return c.emit(
+		mockLog{EventTransfer, []interface{}{seller, buyer, token}},
+		mockLog{EventProductSold, []interface{}{token, seller, buyer, price}},
+	)
+}
+
+func (c *mockChain) authenticate(tokenID int64, authenticated bool) common.Hash {
+	return c.emit(mockLog{EventProductAuthenticated, []interface{}{big.NewInt(tokenID), authenticated}})
+}
+
+func (c *mockChain) updatePrice(tokenID int64, price *big.Int) common.Hash {
+	return c.emit(mockLog{EventPriceUpdated, []interface{}{big.NewInt(tokenID), price}})
+}
+
+func (c *mockChain) transfer(tokenID int64, from, to common.Address) common.Hash {
+	return c.emit(mockLog{EventTransfer, []interface{}{from, to, big.NewInt(tokenID)}})
+}
+
+// setFee changes the platform fee the contract reports
+func (c *mockChain) setFee(fee int64) common.Hash {
+	return c.respond("platformFee", nil, big.NewInt(fee))
+}
+
+// hash returns the hash of a canonical block
+func (c *mockChain) hash(number uint64) common.Hash {
+	header, err := c.client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(number))
+	require.NoError(c.t, err)
+	return header.Hash()
+}
+
+// reorg replaces the blocks after number with a longer side chain. The
+// transactions of the dropped blocks go back to the pool, so the side chain
+// mines them again in new blocks.
+func (c *mockChain) reorg(number uint64, blocks int) uint64 {
+	require.NoError(c.t, c.backend.Fork(c.hash(number)))
+	var head uint64
+	for i := 0; i < blocks; i++ {
+		head = c.commit()
+	}
+	return head
+}
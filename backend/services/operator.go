@@ -0,0 +1,334 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/yourusername/revibe/backend/models"
+	"github.com/yourusername/revibe/backend/utils"
+	"gorm.io/gorm"
+)
+
+const operatorPollInterval = 30 * time.Second
+
+// operatorMaxBackoff is the longest a queued entry waits to be retried
+const operatorMaxBackoff = time.Hour
+
+// maxPlatformFee is the highest fee the contract accepts, in parts per thousand
+const maxPlatformFee = 100
+
+var (
+	ErrNoOperator         = errors.New("operator signer not configured")
+	ErrInvalidPlatformFee = errors.New("platform fee must be between 0 and 100 parts per thousand")
+)
+
+// OperatorService queues transactions from the platform's operator wallet,
+// submits them through the transaction manager and follows them until they
+// are mined
+type OperatorService struct {
+	db        *gorm.DB
+	registry  *DeploymentRegistry
+	txManager *TransactionManager
+	signer    Signer
+}
+
+// NewOperatorService creates a new OperatorService instance and registers
+// the operator's signer, if there is one, with the transaction manager
+func NewOperatorService(db *gorm.DB, registry *DeploymentRegistry, txManager *TransactionManager, signer Signer) *OperatorService {
+	if signer != nil {
+		txManager.RegisterSigner(signer)
+	}
+
+	return &OperatorService{
+		db:        db,
+		registry:  registry,
+		txManager: txManager,
+		signer:    signer,
+	}
+}
+
+// Enqueue adds a transaction to the queue. It takes the caller's database
+// transaction so the queue entry is only created if the caller commits.
+func (s *OperatorService) Enqueue(tx *gorm.DB, op *models.OperatorTransaction) error {
+	op.Status = models.OperatorTxStatusQueued
+	if err := tx.Create(op).Error; err != nil {
+		return fmt.Errorf("failed to queue operator transaction: %v", err)
+	}
+	return nil
+}
+
+// QueuePlatformFeeUpdate queues an updatePlatformFee call on the active
+// deployment. fee is in parts per thousand.
+func (s *OperatorService) QueuePlatformFeeUpdate(fee int64) (*models.OperatorTransaction, error) {
+	if s.signer == nil {
+		return nil, ErrNoOperator
+	}
+	if fee < 0 || fee > maxPlatformFee {
+		return nil, ErrInvalidPlatformFee
+	}
+
+	op := models.OperatorTransaction{
+		Kind:        models.OperatorTxKindUpdatePlatformFee,
+		PlatformFee: fee,
+	}
+	if err := s.Enqueue(s.db, &op); err != nil {
+		return nil, err
+	}
+	return &op, nil
+}
+
+// QueueWithdrawal queues a withdraw call on the active deployment, which
+// sends the contract's balance to its owner
+func (s *OperatorService) QueueWithdrawal() (*models.OperatorTransaction, error) {
+	if s.signer == nil {
+		return nil, ErrNoOperator
+	}
+	op := models.OperatorTransaction{Kind: models.OperatorTxKindWithdraw}
+	if err := s.Enqueue(s.db, &op); err != nil {
+		return nil, err
+	}
+	return &op, nil
+}
+
+// GetOperatorTransaction retrieves a queued operator transaction
+func (s *OperatorService) GetOperatorTransaction(id string) (*models.OperatorTransaction, error) {
+	var op models.OperatorTransaction
+	if err := s.db.First(&op, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &op, nil
+}
+
+// StartOperatorQueue processes the queue until ctx is cancelled
+func (s *OperatorService) StartOperatorQueue(ctx context.Context) {
+	ticker := time.NewTicker(operatorPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.ProcessQueue(ctx); err != nil {
+				utils.LogError(err, map[string]interface{}{
+					"component": "operator_queue",
+				})
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ProcessQueue submits queued transactions that are due and updates sent
+// ones from the transaction manager
+func (s *OperatorService) ProcessQueue(ctx context.Context) error {
+	var ops []models.OperatorTransaction
+	if err := s.db.Where("status = ? OR (status = ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?))",
+		models.OperatorTxStatusSent, models.OperatorTxStatusQueued, time.Now()).
+		Order("created_at asc").
+		Find(&ops).Error; err != nil {
+		return fmt.Errorf("failed to fetch operator transactions: %v", err)
+	}
+
+	for i := range ops {
+		var err error
+		if ops[i].Status == models.OperatorTxStatusQueued {
+			err = s.send(ctx, &ops[i])
+		} else {
+			err = s.checkTransaction(ctx, &ops[i])
+		}
+		if err != nil {
+			utils.LogError(err, map[string]interface{}{
+				"component":   "operator_queue",
+				"operator_tx": ops[i].ID,
+				"kind":        ops[i].Kind,
+			})
+		}
+	}
+
+	return nil
+}
+
+// send submits an operator transaction. The queue entry's ID is the
+// submission's reference, so an entry whose status was not saved after a
+// previous submission gets the same transaction back instead of a second one.
+// Entries that cannot be built or whose call would revert fail; any other
+// error, such as an RPC timeout, leaves the entry queued to be retried.
+func (s *OperatorService) send(ctx context.Context, op *models.OperatorTransaction) error {
+	if s.signer == nil {
+		return ErrNoOperator
+	}
+
+	req, err := s.request(op)
+	if err != nil {
+		return s.fail(op, err)
+	}
+
+	mtx, err := s.txManager.Submit(ctx, req)
+	if errors.Is(err, ErrWouldRevert) {
+		return s.fail(op, err)
+	}
+	if err != nil {
+		return s.retry(op, err)
+	}
+
+	now := time.Now()
+	op.Status = models.OperatorTxStatusSent
+	op.TransactionID = &mtx.ID
+	op.TxHash = mtx.TxHash
+	op.Error = ""
+	op.NextAttemptAt = nil
+	op.SentAt = &now
+	return s.db.Save(op).Error
+}
+
+func (s *OperatorService) fail(op *models.OperatorTransaction, err error) error {
+	op.Status = models.OperatorTxStatusFailed
+	op.Error = err.Error()
+	return s.db.Save(op).Error
+}
+
+// retry leaves an entry queued until its backoff has passed, doubling the
+// wait with each failed attempt. The error is returned to be logged.
+func (s *OperatorService) retry(op *models.OperatorTransaction, err error) error {
+	op.Attempts++
+	op.Error = err.Error()
+	nextAttemptAt := time.Now().Add(operatorBackoff(op.Attempts))
+	op.NextAttemptAt = &nextAttemptAt
+	if saveErr := s.db.Save(op).Error; saveErr != nil {
+		return fmt.Errorf("failed to save operator transaction: %v", saveErr)
+	}
+	return err
+}
+
+// operatorBackoff is the wait before the next attempt after the given number
+// of failed ones
+func operatorBackoff(attempts int) time.Duration {
+	if attempts > 20 {
+		return operatorMaxBackoff
+	}
+	if backoff := operatorPollInterval << (attempts - 1); backoff < operatorMaxBackoff {
+		return backoff
+	}
+	return operatorMaxBackoff
+}
+
+// deployment returns the deployment an operator queue entry goes to. Entries
+// without a chain go to the active deployment, and entries without a
+// contract, such as refunds, to their chain's.
+func (s *OperatorService) deployment(op *models.OperatorTransaction) (*Web3Service, error) {
+	if op.ChainID == 0 {
+		return s.registry.Active(), nil
+	}
+	if op.ContractAddress == "" {
+		return s.registry.Chain(op.ChainID)
+	}
+	return s.registry.Get(op.ChainID, op.ContractAddress)
+}
+
+// request builds the transaction for an operator queue entry. Only refunds,
+// which do not call the contract, may go to a legacy deployment's chain.
+func (s *OperatorService) request(op *models.OperatorTransaction) (TxRequest, error) {
+	req := TxRequest{From: s.signer.Address(), Reference: "operator:" + op.ID}
+	web3Service, err := s.deployment(op)
+	if err != nil {
+		return req, err
+	}
+	if web3Service.ReadOnly() && op.Kind != models.OperatorTxKindRefund {
+		return req, ErrDeploymentReadOnly
+	}
+	req.ChainID = web3Service.ChainID().Int64()
+	contract := web3Service.ContractAddress()
+
+	switch op.Kind {
+	case models.OperatorTxKindRefund:
+		value, ok := new(big.Int).SetString(op.ValueWei, 10)
+		if !ok || value.Sign() <= 0 {
+			return req, fmt.Errorf("invalid refund amount: %s", op.ValueWei)
+		}
+		if !common.IsHexAddress(op.ToAddress) {
+			return req, fmt.Errorf("invalid refund address: %s", op.ToAddress)
+		}
+		to := common.HexToAddress(op.ToAddress)
+		req.To = &to
+		req.Value = value
+	case models.OperatorTxKindAuthenticate:
+		tokenID, ok := new(big.Int).SetString(op.TokenID, 10)
+		if !ok {
+			return req, fmt.Errorf("invalid token ID: %s", op.TokenID)
+		}
+		data, err := web3Service.AuthenticateProductData(tokenID, op.Authenticated)
+		if err != nil {
+			return req, err
+		}
+		req.To = &contract
+		req.Data = data
+	case models.OperatorTxKindUpdatePlatformFee:
+		data, err := web3Service.UpdatePlatformFeeData(big.NewInt(op.PlatformFee))
+		if err != nil {
+			return req, err
+		}
+		req.To = &contract
+		req.Data = data
+	case models.OperatorTxKindWithdraw:
+		data, err := web3Service.WithdrawData()
+		if err != nil {
+			return req, err
+		}
+		req.To = &contract
+		req.Data = data
+	default:
+		return req, fmt.Errorf("unknown operator transaction kind: %s", op.Kind)
+	}
+
+	return req, nil
+}
+
+// checkTransaction copies the managed transaction's progress to the queue entry
+func (s *OperatorService) checkTransaction(ctx context.Context, op *models.OperatorTransaction) error {
+	if op.TransactionID == nil {
+		return s.checkReceipt(ctx, op)
+	}
+
+	mtx, err := s.txManager.GetTransaction(*op.TransactionID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch transaction: %v", err)
+	}
+
+	op.TxHash = mtx.TxHash
+	switch mtx.Status {
+	case models.TxStatusConfirmed:
+		op.Status = models.OperatorTxStatusConfirmed
+	case models.TxStatusFailed:
+		op.Status = models.OperatorTxStatusFailed
+		op.Error = mtx.Error
+	}
+	return s.db.Save(op).Error
+}
+
+// checkReceipt follows entries sent before the transaction manager existed
+func (s *OperatorService) checkReceipt(ctx context.Context, op *models.OperatorTransaction) error {
+	web3Service, err := s.deployment(op)
+	if err != nil {
+		return err
+	}
+	receipt, err := web3Service.GetTransactionReceipt(ctx, op.TxHash)
+	if err == ethereum.NotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get receipt: %v", err)
+	}
+
+	op.Status = models.OperatorTxStatusConfirmed
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		op.Status = models.OperatorTxStatusFailed
+		op.Error = "transaction reverted"
+	}
+	return s.db.Save(op).Error
+}
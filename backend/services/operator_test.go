@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/models"
+)
+
+// operatorTest is an operator queue sending from the mock chain's funded
+// account
+type operatorTest struct {
+	*txManagerTest
+	operator *OperatorService
+}
+
+func newOperatorTest(t *testing.T) *operatorTest {
+	x := newTxManagerTest(t)
+	return &operatorTest{
+		txManagerTest: x,
+		operator:      NewOperatorService(x.db, x.manager.registry, x.manager, x.signer),
+	}
+}
+
+func (o *operatorTest) processQueue(ctx context.Context) *operatorTest {
+	require.NoError(o.t, o.operator.ProcessQueue(ctx))
+	return o
+}
+
+// refund queues a refund of 1 wei, a plain transfer the mock chain needs no
+// answer for
+func (o *operatorTest) refund() *models.OperatorTransaction {
+	op := models.OperatorTransaction{Kind: models.OperatorTxKindRefund, ToAddress: "0x0000000000000000000000000000000000000b0b", ValueWei: "1"}
+	require.NoError(o.t, o.operator.Enqueue(o.db, &op))
+	return &op
+}
+
+func (o *operatorTest) entry(id string) *models.OperatorTransaction {
+	op, err := o.operator.GetOperatorTransaction(id)
+	require.NoError(o.t, err)
+	return op
+}
+
+func TestOperatorQueue(t *testing.T) {
+	o := newOperatorTest(t)
+	op := o.refund()
+	o.processQueue(o.ctx)
+	sent := o.entry(op.ID)
+	assert.Equal(t, models.OperatorTxStatusSent, sent.Status)
+	require.NotNil(t, sent.TransactionID)
+	assert.NotEmpty(t, sent.TxHash)
+	assert.NotNil(t, sent.SentAt)
+
+	o.chain.commit()
+	o.process()
+	o.processQueue(o.ctx)
+	assert.Equal(t, models.OperatorTxStatusConfirmed, o.entry(op.ID).Status)
+}
+
+func TestOperatorQueueFailures(t *testing.T) {
+	t.Run("reverts", func(t *testing.T) {
+		// The mock contract reverts calls it has no answer for
+		o := newOperatorTest(t)
+		op, err := o.operator.QueueWithdrawal()
+		require.NoError(t, err)
+
+		o.processQueue(o.ctx)
+		failed := o.entry(op.ID)
+		assert.Equal(t, models.OperatorTxStatusFailed, failed.Status)
+		assert.Equal(t, ErrWouldRevert.Error(), failed.Error)
+		assert.Nil(t, failed.TransactionID)
+	})
+
+	t.Run("invalid entry", func(t *testing.T) {
+		o := newOperatorTest(t)
+		op := models.OperatorTransaction{Kind: models.OperatorTxKindRefund, ToAddress: "0xb0b", ValueWei: "-1"}
+		require.NoError(t, o.operator.Enqueue(o.db, &op))
+
+		o.processQueue(o.ctx)
+		failed := o.entry(op.ID)
+		assert.Equal(t, models.OperatorTxStatusFailed, failed.Status)
+		assert.Equal(t, "invalid refund amount: -1", failed.Error)
+	})
+}
+
+func TestOperatorQueueRetriesTransientErrors(t *testing.T) {
+	o := newOperatorTest(t)
+	op := o.refund()
+
+	// The provider times out
+	timedOut, cancel := context.WithTimeout(o.ctx, 0)
+	defer cancel()
+	o.processQueue(timedOut)
+	queued := o.entry(op.ID)
+	assert.Equal(t, models.OperatorTxStatusQueued, queued.Status)
+	assert.Equal(t, 1, queued.Attempts)
+	assert.Contains(t, queued.Error, "failed to estimate gas")
+	require.NotNil(t, queued.NextAttemptAt)
+	assert.WithinDuration(t, time.Now().Add(operatorPollInterval), *queued.NextAttemptAt, 5*time.Second)
+
+	// It waits out its backoff
+	o.processQueue(o.ctx)
+	assert.Equal(t, models.OperatorTxStatusQueued, o.entry(op.ID).Status)
+
+	require.NoError(t, o.db.Model(queued).Update("next_attempt_at", time.Now().Add(-time.Second)).Error)
+	o.processQueue(o.ctx)
+	sent := o.entry(op.ID)
+	assert.Equal(t, models.OperatorTxStatusSent, sent.Status)
+	assert.Empty(t, sent.Error)
+	assert.Nil(t, sent.NextAttemptAt)
+	require.NotNil(t, sent.TransactionID)
+}
+
+func TestOperatorBackoff(t *testing.T) {
+	assert.Equal(t, operatorPollInterval, operatorBackoff(1))
+	assert.Equal(t, 2*operatorPollInterval, operatorBackoff(2))
+	assert.Equal(t, 16*operatorPollInterval, operatorBackoff(5))
+	assert.Equal(t, operatorMaxBackoff, operatorBackoff(8))
+	assert.Equal(t, operatorMaxBackoff, operatorBackoff(100))
+}
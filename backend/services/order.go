@@ -0,0 +1,328 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/yourusername/revibe/backend/contracts"
+	"github.com/yourusername/revibe/backend/models"
+	"github.com/yourusername/revibe/backend/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	orderPollInterval = 15 * time.Second
+	orderTimeout      = 30 * time.Minute
+)
+
+// Order roles used when listing a user's orders
+const (
+	OrderRoleBuyer  = "buyer"
+	OrderRoleSeller = "seller"
+)
+
+var (
+	ErrProductNotAvailable = errors.New("product is not available for purchase")
+	ErrProductNotMinted    = errors.New("product has not been listed on chain")
+	ErrOwnProduct          = errors.New("cannot buy your own product")
+	ErrOrderExists         = errors.New("an order for this transaction already exists")
+	ErrInvalidTxHash       = errors.New("invalid transaction hash")
+)
+
+// OrderService manages purchase orders and follows their transactions on chain
+type OrderService struct {
+	db                 *gorm.DB
+	registry           *DeploymentRegistry
+	reservationService *ReservationService
+	ledgerService      *LedgerService
+}
+
+// NewOrderService creates a new OrderService instance
+func NewOrderService(db *gorm.DB, registry *DeploymentRegistry, reservationService *ReservationService, ledgerService *LedgerService) *OrderService {
+	return &OrderService{
+		db:                 db,
+		registry:           registry,
+		reservationService: reservationService,
+		ledgerService:      ledgerService,
+	}
+}
+
+// CreateOrder records a pending order for a buyProduct transaction sent by
+// the buyer. Products minted on a legacy deployment cannot be checked out.
+// The buyer may already have paid on chain, so an order for a product held
+// by another buyer or no longer on sale is still recorded, flagged as
+// contested; its transaction settles it like any other order.
+func (s *OrderService) CreateOrder(ctx context.Context, productID, buyerID, txHash string) (*models.Order, error) {
+	if len(txHash) != 66 || !strings.HasPrefix(txHash, "0x") {
+		return nil, ErrInvalidTxHash
+	}
+
+	var contested string
+	if err := s.reservationService.CheckAvailable(ctx, productID, buyerID); err != nil {
+		if !errors.Is(err, ErrProductReserved) {
+			utils.LogError(err, map[string]interface{}{
+				"component":  "orders",
+				"product_id": productID,
+			})
+			err = errors.New("checkout hold could not be checked")
+		}
+		contested = err.Error()
+	}
+
+	var order models.Order
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var product models.Product
+		if err := tx.First(&product, "id = ?", productID).Error; err != nil {
+			return err
+		}
+		if product.TokenID == nil {
+			return ErrProductNotMinted
+		}
+		if product.SellerID == buyerID {
+			return ErrOwnProduct
+		}
+		if product.Status != models.ProductStatusActive || product.ModerationStatus != models.ModerationStatusApproved {
+			contested = ErrProductNotAvailable.Error()
+		}
+		web3Service, err := s.registry.ForProduct(&product)
+		if err != nil {
+			return err
+		}
+		if web3Service.ReadOnly() {
+			return ErrDeploymentReadOnly
+		}
+
+		hash := common.HexToHash(txHash).Hex()
+		var count int64
+		if err := tx.Model(&models.Order{}).Where("tx_hash = ?", hash).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrOrderExists
+		}
+
+		order = models.Order{
+			ProductID:       product.ID,
+			BuyerID:         buyerID,
+			SellerID:        product.SellerID,
+			Price:           product.Price,
+			TokenID:         *product.TokenID,
+			Status:          models.OrderStatusPending,
+			TxHash:          hash,
+			Contested:       contested != "",
+			ContestedReason: contested,
+		}
+		return tx.Create(&order).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// GetOrder retrieves an order with its product and parties
+func (s *OrderService) GetOrder(orderID string) (*models.Order, error) {
+	var order models.Order
+	err := s.db.Preload("Product").Preload("Buyer").Preload("Seller").
+		First(&order, "id = ?", orderID).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// ListOrders returns a page of a user's orders as buyer or seller, optionally
+// filtered by status, together with the total number of matching orders
+func (s *OrderService) ListOrders(userID, role, status string, page, limit int) ([]models.Order, int64, error) {
+	query := s.db.Model(&models.Order{})
+	switch role {
+	case OrderRoleSeller:
+		query = query.Where("seller_id = ?", userID)
+	case OrderRoleBuyer:
+		query = query.Where("buyer_id = ?", userID)
+	default:
+		query = query.Where("buyer_id = ? OR seller_id = ?", userID, userID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count orders: %v", err)
+	}
+
+	var orders []models.Order
+	err := query.Preload("Product.Images").Preload("Buyer").Preload("Seller").
+		Order("created_at desc").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&orders).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch orders: %v", err)
+	}
+
+	return orders, total, nil
+}
+
+// StartOrderTracker polls receipts of pending orders until ctx is cancelled
+func (s *OrderService) StartOrderTracker(ctx context.Context) {
+	ticker := time.NewTicker(orderPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.ProcessPendingOrders(ctx); err != nil {
+				utils.LogError(err, map[string]interface{}{
+					"component": "order_tracker",
+				})
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ProcessPendingOrders checks the transaction of every pending order once
+func (s *OrderService) ProcessPendingOrders(ctx context.Context) error {
+	var orders []models.Order
+	if err := s.db.Where("status = ?", models.OrderStatusPending).Find(&orders).Error; err != nil {
+		return fmt.Errorf("failed to fetch pending orders: %v", err)
+	}
+
+	for i := range orders {
+		if err := s.processOrder(ctx, &orders[i]); err != nil {
+			utils.LogError(err, map[string]interface{}{
+				"component": "order_tracker",
+				"order_id":  orders[i].ID,
+				"tx_hash":   orders[i].TxHash,
+			})
+		}
+	}
+
+	return nil
+}
+
+// productDeployment returns the deployment that minted a product's token
+func (s *OrderService) productDeployment(productID string) (*Web3Service, error) {
+	var product models.Product
+	if err := s.db.Unscoped().First(&product, "id = ?", productID).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch product: %v", err)
+	}
+	return s.registry.ForProduct(&product)
+}
+
+func (s *OrderService) processOrder(ctx context.Context, order *models.Order) error {
+	web3Service, err := s.productDeployment(order.ProductID)
+	if err != nil {
+		return err
+	}
+
+	receipt, err := web3Service.GetTransactionReceipt(ctx, order.TxHash)
+	if err == ethereum.NotFound {
+		if time.Since(order.CreatedAt) > orderTimeout {
+			return s.FailOrder(order, models.OrderStatusExpired, "transaction not mined in time")
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get receipt: %v", err)
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return s.FailOrder(order, models.OrderStatusFailed, "transaction reverted")
+	}
+
+	event := web3Service.FindProductSold(receipt)
+	if event == nil || event.TokenId.String() != order.TokenID {
+		return s.FailOrder(order, models.OrderStatusFailed, "no matching ProductSold event in receipt")
+	}
+
+	return s.completeOrder(order, web3Service, event)
+}
+
+// CompleteOrder marks an order completed from its ProductSold event, marks
+// the product sold, posts the sale to the ledger and releases any checkout
+// hold on it
+func (s *OrderService) CompleteOrder(order *models.Order, event *contracts.ReVibeContractProductSold) error {
+	web3Service, err := s.productDeployment(order.ProductID)
+	if err != nil {
+		return err
+	}
+	return s.completeOrder(order, web3Service, event)
+}
+
+func (s *OrderService) completeOrder(order *models.Order, web3Service *Web3Service, event *contracts.ReVibeContractProductSold) error {
+	// Read the fee in force when the sale was mined, before opening the transaction
+	feeRate, err := s.ledgerService.FeeRateAt(web3Service.ChainID().Int64(), web3Service.ContractAddress().Hex(), event.Raw.BlockNumber)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		return s.CompleteOrderTx(tx, order, event, feeRate)
+	})
+	if err != nil {
+		return err
+	}
+
+	if order.Status == models.OrderStatusCompleted {
+		if err := s.reservationService.Release(context.Background(), order.ProductID); err != nil {
+			utils.LogError(err, map[string]interface{}{
+				"component":  "order_tracker",
+				"product_id": order.ProductID,
+			})
+		}
+	}
+
+	return nil
+}
+
+// CompleteOrderTx marks an order completed from its ProductSold event in the
+// caller's database transaction, marks the product sold and posts the sale to
+// the ledger at the given fee rate. An order placed from another wallet is
+// failed instead.
+func (s *OrderService) CompleteOrderTx(tx *gorm.DB, order *models.Order, event *contracts.ReVibeContractProductSold, feeRate int64) error {
+	var buyer models.User
+	if err := tx.First(&buyer, "id = ?", order.BuyerID).Error; err != nil {
+		return fmt.Errorf("failed to fetch buyer: %v", err)
+	}
+	if !strings.EqualFold(buyer.WalletAddress, event.Buyer.Hex()) {
+		order.Status = models.OrderStatusFailed
+		order.FailureReason = "purchase was made by a different wallet"
+		return tx.Save(order).Error
+	}
+
+	now := time.Now()
+	order.Status = models.OrderStatusCompleted
+	order.PriceWei = event.Price.String()
+	order.BlockNumber = event.Raw.BlockNumber
+	order.CompletedAt = &now
+	if err := tx.Save(order).Error; err != nil {
+		return fmt.Errorf("failed to update order: %v", err)
+	}
+
+	if err := tx.Model(&models.Product{}).
+		Where("id = ?", order.ProductID).
+		Update("status", models.ProductStatusSold).Error; err != nil {
+		return fmt.Errorf("failed to mark product sold: %v", err)
+	}
+
+	return s.ledgerService.RecordSale(tx, order, feeRate)
+}
+
+// FailOrder moves an order to a terminal failure status
+func (s *OrderService) FailOrder(order *models.Order, status, reason string) error {
+	order.Status = status
+	order.FailureReason = reason
+	return s.db.Save(order).Error
+}
@@ -0,0 +1,277 @@
+package services
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/models"
+)
+
+func TestCreateOrder(t *testing.T) {
+	tests := []struct {
+		name          string
+		setup         func(f *chainFixture, product *models.Product) (buyerID, txHash string)
+		wantErr       error
+		wantContested string
+	}{
+		{
+			name: "minted product",
+			setup: func(f *chainFixture, product *models.Product) (string, string) {
+				return f.buyer.ID, txHash(1)
+			},
+		},
+		{
+			name: "malformed hash",
+			setup: func(f *chainFixture, product *models.Product) (string, string) {
+				return f.buyer.ID, "0x1234"
+			},
+			wantErr: ErrInvalidTxHash,
+		},
+		{
+			name: "sold product",
+			setup: func(f *chainFixture, product *models.Product) (string, string) {
+				require.NoError(t, f.db.Model(product).Update("status", models.ProductStatusSold).Error)
+				return f.buyer.ID, txHash(1)
+			},
+			wantContested: ErrProductNotAvailable.Error(),
+		},
+		{
+			name: "product awaiting moderation",
+			setup: func(f *chainFixture, product *models.Product) (string, string) {
+				require.NoError(t, f.db.Model(product).Update("moderation_status", models.ModerationStatusPendingReview).Error)
+				return f.buyer.ID, txHash(1)
+			},
+			wantContested: ErrProductNotAvailable.Error(),
+		},
+		{
+			name: "unminted product",
+			setup: func(f *chainFixture, product *models.Product) (string, string) {
+				require.NoError(t, f.db.Model(product).Update("token_id", nil).Error)
+				return f.buyer.ID, txHash(1)
+			},
+			wantErr: ErrProductNotMinted,
+		},
+		{
+			name: "own product",
+			setup: func(f *chainFixture, product *models.Product) (string, string) {
+				return f.seller.ID, txHash(1)
+			},
+			wantErr: ErrOwnProduct,
+		},
+		{
+			name: "held by the buyer",
+			setup: func(f *chainFixture, product *models.Product) (string, string) {
+				_, err := f.reservations.Reserve(context.Background(), product.ID, f.buyer.ID)
+				require.NoError(t, err)
+				return f.buyer.ID, txHash(1)
+			},
+		},
+		{
+			name: "held by another buyer",
+			setup: func(f *chainFixture, product *models.Product) (string, string) {
+				other := createUser(t, f.db, common.HexToAddress("0xca7").Hex())
+				_, err := f.reservations.Reserve(context.Background(), product.ID, other.ID)
+				require.NoError(t, err)
+				return f.buyer.ID, txHash(1)
+			},
+			wantContested: ErrProductReserved.Error(),
+		},
+		{
+			name: "holds unavailable",
+			setup: func(f *chainFixture, product *models.Product) (string, string) {
+				f.redis.Close()
+				return f.buyer.ID, txHash(1)
+			},
+			wantContested: "checkout hold could not be checked",
+		},
+		{
+			name: "transaction already ordered",
+			setup: func(f *chainFixture, product *models.Product) (string, string) {
+				_, err := f.orders.CreateOrder(context.Background(), product.ID, f.buyer.ID, txHash(1))
+				require.NoError(t, err)
+				return f.buyer.ID, txHash(1)
+			},
+			wantErr: ErrOrderExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newChainFixture(t)
+			product := f.mintedProduct("1")
+			buyerID, hash := tt.setup(f, product)
+
+			order, err := f.orders.CreateOrder(context.Background(), product.ID, buyerID, hash)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			f.reload(order, order.ID)
+			assert.Equal(t, models.OrderStatusPending, order.Status)
+			assert.Equal(t, "1", order.TokenID)
+			assert.Equal(t, f.seller.ID, order.SellerID)
+			assert.Equal(t, 0.1, order.Price)
+			assert.Equal(t, tt.wantContested != "", order.Contested)
+			assert.Equal(t, tt.wantContested, order.ContestedReason)
+		})
+	}
+}
+
+// A contested order is settled by its transaction like any other
+func TestContestedOrderCompletes(t *testing.T) {
+	f := newChainFixture(t)
+	product := f.mintedProduct("1")
+	other := createUser(t, f.db, common.HexToAddress("0xca7").Hex())
+	_, err := f.reservations.Reserve(context.Background(), product.ID, other.ID)
+	require.NoError(t, err)
+
+	hash := f.chain.sell(1, f.sellerWallet, f.buyerWallet, big.NewInt(1e17))
+	order, err := f.orders.CreateOrder(context.Background(), product.ID, f.buyer.ID, hash.Hex())
+	require.NoError(t, err)
+	require.True(t, order.Contested)
+	f.chain.commit()
+
+	require.NoError(t, f.orders.ProcessPendingOrders(context.Background()))
+	f.reload(order, order.ID)
+	assert.Equal(t, models.OrderStatusCompleted, order.Status)
+	assert.True(t, order.Contested)
+}
+
+func TestProcessPendingOrders(t *testing.T) {
+	sale := big.NewInt(1e17)
+	tests := []struct {
+		name       string
+		send       func(f *chainFixture) string
+		createdAgo time.Duration
+		wantStatus string
+		wantReason string
+	}{
+		{
+			name: "sale mined",
+			send: func(f *chainFixture) string {
+				return f.chain.sell(1, f.sellerWallet, f.buyerWallet, sale).Hex()
+			},
+			wantStatus: models.OrderStatusCompleted,
+		},
+		{
+			name: "bought from another wallet",
+			send: func(f *chainFixture) string {
+				return f.chain.sell(1, f.sellerWallet, common.HexToAddress("0xca7"), sale).Hex()
+			},
+			wantStatus: models.OrderStatusFailed,
+			wantReason: "purchase was made by a different wallet",
+		},
+		{
+			name: "transaction reverted",
+			send: func(f *chainFixture) string {
+				return f.chain.send(common.FromHex("0x12345678")).Hex()
+			},
+			wantStatus: models.OrderStatusFailed,
+			wantReason: "transaction reverted",
+		},
+		{
+			name: "no sale in receipt",
+			send: func(f *chainFixture) string {
+				return f.chain.transfer(1, f.sellerWallet, f.buyerWallet).Hex()
+			},
+			wantStatus: models.OrderStatusFailed,
+			wantReason: "no matching ProductSold event in receipt",
+		},
+		{
+			name: "another token sold",
+			send: func(f *chainFixture) string {
+				return f.chain.sell(2, f.sellerWallet, f.buyerWallet, sale).Hex()
+			},
+			wantStatus: models.OrderStatusFailed,
+			wantReason: "no matching ProductSold event in receipt",
+		},
+		{
+			name: "not mined yet",
+			send: func(f *chainFixture) string {
+				return txHash(999)
+			},
+			wantStatus: models.OrderStatusPending,
+		},
+		{
+			name: "not mined in time",
+			send: func(f *chainFixture) string {
+				return txHash(999)
+			},
+			createdAgo: orderTimeout + time.Minute,
+			wantStatus: models.OrderStatusExpired,
+			wantReason: "transaction not mined in time",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := newChainFixture(t)
+			product := f.mintedProduct("1")
+			_, err := f.reservations.Reserve(ctx, product.ID, f.buyer.ID)
+			require.NoError(t, err)
+
+			hash := tt.send(f)
+			block := f.chain.commit()
+			order, err := f.orders.CreateOrder(ctx, product.ID, f.buyer.ID, hash)
+			require.NoError(t, err)
+			if tt.createdAgo > 0 {
+				require.NoError(t, f.db.Model(order).UpdateColumn("created_at", time.Now().Add(-tt.createdAgo)).Error)
+			}
+
+			require.NoError(t, f.orders.ProcessPendingOrders(ctx))
+			f.reload(order, order.ID)
+			assert.Equal(t, tt.wantStatus, order.Status)
+			assert.Equal(t, tt.wantReason, order.FailureReason)
+
+			f.reload(product, product.ID)
+			_, held := f.reservations.Get(ctx, product.ID)
+			if tt.wantStatus != models.OrderStatusCompleted {
+				assert.Equal(t, models.ProductStatusActive, product.Status)
+				assert.Equal(t, int64(0), f.count(&models.LedgerJournal{}, "order_id = ?", order.ID))
+				assert.NoError(t, held)
+				return
+			}
+			assert.Equal(t, models.ProductStatusSold, product.Status)
+			assert.Equal(t, "100000000000000000", order.PriceWei)
+			assert.Equal(t, block, order.BlockNumber)
+			assert.NotNil(t, order.CompletedAt)
+			assert.ErrorIs(t, held, ErrReservationNotFound)
+		})
+	}
+}
+
+func TestProcessPendingOrdersLeavesSettledOrders(t *testing.T) {
+	ctx := context.Background()
+	f := newChainFixture(t)
+	product := f.mintedProduct("1")
+
+	// A failed order stays failed even if its transaction turns out to be a
+	// sale, and a completed order is not completed again
+	failed, err := f.orders.CreateOrder(ctx, product.ID, f.buyer.ID, f.chain.sell(1, f.sellerWallet, f.buyerWallet, big.NewInt(1e17)).Hex())
+	require.NoError(t, err)
+	require.NoError(t, f.orders.FailOrder(failed, models.OrderStatusFailed, "cancelled"))
+	f.chain.commit()
+
+	completed, err := f.orders.CreateOrder(ctx, product.ID, f.buyer.ID, f.chain.sell(1, f.sellerWallet, f.buyerWallet, big.NewInt(1e17)).Hex())
+	require.NoError(t, err)
+	f.chain.commit()
+	require.NoError(t, f.orders.ProcessPendingOrders(ctx))
+	f.reload(completed, completed.ID)
+	require.Equal(t, models.OrderStatusCompleted, completed.Status)
+	completedAt := *completed.CompletedAt
+
+	require.NoError(t, f.orders.ProcessPendingOrders(ctx))
+	f.reload(failed, failed.ID)
+	assert.Equal(t, models.OrderStatusFailed, failed.Status)
+	assert.Equal(t, "cancelled", failed.FailureReason)
+	f.reload(completed, completed.ID)
+	assert.Equal(t, completedAt, *completed.CompletedAt)
+	assert.Equal(t, int64(1), f.count(&models.LedgerJournal{}, "order_id = ?", completed.ID))
+}
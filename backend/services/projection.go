@@ -0,0 +1,583 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/yourusername/revibe/backend/contracts"
+	"github.com/yourusername/revibe/backend/models"
+	"gorm.io/gorm"
+)
+
+// Placeholder details for products first seen on chain
+const (
+	onChainProductCategory  = "uncategorized"
+	onChainProductCondition = "unknown"
+)
+
+// eventProjection records what projecting an event changed, so a reorg can
+// undo it
+type eventProjection struct {
+	ProductCreated        bool     `json:"productCreated,omitempty"`
+	ListingID             string   `json:"listingId,omitempty"`
+	OrderID               string   `json:"orderId,omitempty"`
+	OrderCreated          bool     `json:"orderCreated,omitempty"`
+	PreviousOrderStatus   string   `json:"previousOrderStatus,omitempty"`
+	PreviousFailureReason string   `json:"previousFailureReason,omitempty"`
+	AuthenticationID      string   `json:"authenticationId,omitempty"`
+	PreviousPrice         *float64 `json:"previousPrice,omitempty"`
+	PreviousPriceWei      *string  `json:"previousPriceWei,omitempty"`
+	PreviousOwner         *string  `json:"previousOwner,omitempty"`
+	TransferID            string   `json:"transferId,omitempty"`
+}
+
+// OnChainAuthenticationDetails are the details of an authentication recorded
+// from a ProductAuthenticated event rather than by the backend
+type OnChainAuthenticationDetails struct {
+	TxHash      string `json:"txHash"`
+	BlockNumber uint64 `json:"blockNumber"`
+}
+
+// ProjectionService keeps products, orders, authentications and token
+// transfers in step with the contract's events. It runs as an indexer
+// handler, so every projection shares the indexer's database transaction.
+type ProjectionService struct {
+	db                 *gorm.DB
+	orderService       *OrderService
+	ledgerService      *LedgerService
+	certificateService *CertificateService
+	authExpiryService  *AuthExpiryService
+}
+
+// NewProjectionService creates a new ProjectionService instance
+func NewProjectionService(db *gorm.DB, orderService *OrderService, ledgerService *LedgerService, certificateService *CertificateService, authExpiryService *AuthExpiryService) *ProjectionService {
+	return &ProjectionService{
+		db:                 db,
+		orderService:       orderService,
+		ledgerService:      ledgerService,
+		certificateService: certificateService,
+		authExpiryService:  authExpiryService,
+	}
+}
+
+// preparedSale is a ProductSold event with the platform fee in effect at
+// its block
+type preparedSale struct {
+	*contracts.ReVibeContractProductSold
+	FeeRate int64
+}
+
+// weiToPrice converts a wei amount to the ETH price stored on products
+func weiToPrice(wei *big.Int) float64 {
+	price, _ := WeiToEther(wei).Float64()
+	return price
+}
+
+// productByToken finds the product linked to an event's token, or nil
+func productByToken(tx *gorm.DB, event *models.ChainEvent) (*models.Product, error) {
+	var product models.Product
+	err := tx.Where("token_id = ? AND chain_id = ? AND contract_address = ?",
+		event.TokenID, event.ChainID, event.ContractAddress).
+		First(&product).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch product for token %s: %v", event.TokenID, err)
+	}
+	return &product, nil
+}
+
+// userByWallet finds the account of a wallet, or nil
+func userByWallet(tx *gorm.DB, address common.Address) (*models.User, error) {
+	var user models.User
+	err := tx.Where("LOWER(wallet_address) = ?", strings.ToLower(address.Hex())).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user for %s: %v", address.Hex(), err)
+	}
+	return &user, nil
+}
+
+// tokenOwner returns the recipient of a token's latest recorded transfer, or
+// fallback if none is recorded
+func tokenOwner(tx *gorm.DB, chainID int64, contractAddress, tokenID, fallback string) (string, error) {
+	var transfer models.TokenTransfer
+	err := tx.Where("chain_id = ? AND contract_address = ? AND token_id = ?", chainID, contractAddress, tokenID).
+		Order("block_number desc, log_index desc").
+		First(&transfer).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fallback, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch owner of token %s: %v", tokenID, err)
+	}
+	return transfer.ToAddress, nil
+}
+
+// PrepareEvent reads the platform fee a sale was charged before the
+// indexer's database transaction opens, so projecting it makes no RPC call
+func (s *ProjectionService) PrepareEvent(ctx context.Context, event *models.ChainEvent, decoded interface{}) (interface{}, error) {
+	e, ok := decoded.(*contracts.ReVibeContractProductSold)
+	if !ok {
+		return decoded, nil
+	}
+	feeRate, err := s.ledgerService.FeeRateAt(event.ChainID, event.ContractAddress, event.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &preparedSale{ReVibeContractProductSold: e, FeeRate: feeRate}, nil
+}
+
+// HandleEvent projects an indexed event into the database. Sales must have
+// been through PrepareEvent.
+func (s *ProjectionService) HandleEvent(tx *gorm.DB, event *models.ChainEvent, decoded interface{}) error {
+	var projection eventProjection
+	var productID *string
+	var err error
+	switch e := decoded.(type) {
+	case *contracts.ReVibeContractProductListed:
+		productID, err = s.projectListed(tx, event, e, &projection)
+	case *preparedSale:
+		productID, err = s.projectSold(tx, event, e.ReVibeContractProductSold, e.FeeRate, &projection)
+	case *contracts.ReVibeContractProductSold:
+		return fmt.Errorf("sale in %s was not prepared", event.TxHash)
+	case *contracts.ReVibeContractProductAuthenticated:
+		productID, err = s.projectAuthenticated(tx, event, e, &projection)
+	case *contracts.ReVibeContractPriceUpdated:
+		productID, err = s.projectPriceUpdated(tx, event, e, &projection)
+	case *contracts.ReVibeContractTransfer:
+		productID, err = s.projectTransfer(tx, event, e, &projection)
+	}
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(projection)
+	if err != nil {
+		return err
+	}
+	event.ProductID = productID
+	event.Projection = string(data)
+	return tx.Model(event).Select("product_id", "projection").Updates(event).Error
+}
+
+// projectListed links the token to the product whose listing transaction
+// minted it, or creates a product for a token listed outside the app by a
+// seller with an account. New products await moderation.
+func (s *ProjectionService) projectListed(tx *gorm.DB, event *models.ChainEvent, e *contracts.ReVibeContractProductListed, projection *eventProjection) (*string, error) {
+	product, err := productByToken(tx, event)
+	if err != nil || product != nil {
+		return productIDOf(product), err
+	}
+
+	var listing models.ListingTransaction
+	err = tx.Where("tx_hash = ? AND chain_id = ? AND contract_address = ?",
+		event.TxHash, event.ChainID, event.ContractAddress).
+		First(&listing).Error
+	if err == nil {
+		return s.linkListing(tx, event, e, &listing, projection)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to fetch listing transaction: %v", err)
+	}
+
+	seller, err := userByWallet(tx, e.Seller)
+	if err != nil || seller == nil {
+		return nil, err
+	}
+	owner, err := tokenOwner(tx, event.ChainID, event.ContractAddress, event.TokenID, e.Seller.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	tokenID := event.TokenID
+	created := models.Product{
+		Name:             fmt.Sprintf("ReVibe #%s", tokenID),
+		Description:      "Listed directly on chain",
+		Price:            weiToPrice(e.Price),
+		PriceWei:         e.Price.String(),
+		Category:         onChainProductCategory,
+		Condition:        onChainProductCondition,
+		SellerID:         seller.ID,
+		ModerationStatus: models.ModerationStatusPendingReview,
+		TokenID:          &tokenID,
+		ChainID:          event.ChainID,
+		ContractAddress:  event.ContractAddress,
+		DeploymentID:     event.DeploymentID,
+		OwnerAddress:     owner,
+		Status:           models.ProductStatusActive,
+	}
+	if err := tx.Create(&created).Error; err != nil {
+		return nil, fmt.Errorf("failed to create product for token %s: %v", tokenID, err)
+	}
+	projection.ProductCreated = true
+	return &created.ID, nil
+}
+
+// linkListing confirms a listing transaction and links its product to the
+// token, as the listing tracker does from the receipt
+func (s *ProjectionService) linkListing(tx *gorm.DB, event *models.ChainEvent, e *contracts.ReVibeContractProductListed, listing *models.ListingTransaction, projection *eventProjection) (*string, error) {
+	var product models.Product
+	if err := tx.Preload("Seller").First(&product, "id = ?", listing.ProductID).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch product: %v", err)
+	}
+	// Only the product's seller may link a token to it
+	if !strings.EqualFold(product.Seller.WalletAddress, e.Seller.Hex()) || product.TokenID != nil {
+		return nil, nil
+	}
+
+	owner, err := tokenOwner(tx, event.ChainID, event.ContractAddress, event.TokenID, e.Seller.Hex())
+	if err != nil {
+		return nil, err
+	}
+
+	tokenID := event.TokenID
+	now := time.Now()
+	listing.Status = models.ListingStatusConfirmed
+	listing.TokenID = &tokenID
+	listing.BlockNumber = event.BlockNumber
+	listing.Error = ""
+	listing.ConfirmedAt = &now
+	if err := tx.Save(listing).Error; err != nil {
+		return nil, fmt.Errorf("failed to update listing transaction: %v", err)
+	}
+
+	// Updates writes the new values into product, so keep copies
+	previousPriceWei := product.PriceWei
+	projection.ListingID = listing.ID
+	projection.PreviousPriceWei = &previousPriceWei
+	if err := tx.Model(&product).Updates(map[string]interface{}{
+		"token_id":         tokenID,
+		"chain_id":         event.ChainID,
+		"contract_address": event.ContractAddress,
+		"deployment_id":    event.DeploymentID,
+		"owner_address":    owner,
+		"price_wei":        e.Price.String(),
+	}).Error; err != nil {
+		return nil, fmt.Errorf("failed to link product: %v", err)
+	}
+	return &product.ID, nil
+}
+
+// projectSold marks the product sold and completes the sale's order,
+// creating it when the buyer has an account but never submitted one. The
+// sale's price and order are recorded on its transfer, and the order's
+// journal is posted at feeRate.
+func (s *ProjectionService) projectSold(tx *gorm.DB, event *models.ChainEvent, e *contracts.ReVibeContractProductSold, feeRate int64, projection *eventProjection) (*string, error) {
+	product, err := productByToken(tx, event)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, s.recordSale(tx, event, e, "", projection)
+	}
+
+	var order models.Order
+	err = tx.Where("tx_hash = ?", event.TxHash).First(&order).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		buyer, err := userByWallet(tx, e.Buyer)
+		if err != nil {
+			return nil, err
+		}
+		if buyer != nil {
+			order = models.Order{
+				ProductID: product.ID,
+				BuyerID:   buyer.ID,
+				SellerID:  product.SellerID,
+				Price:     product.Price,
+				TokenID:   event.TokenID,
+				Status:    models.OrderStatusPending,
+				TxHash:    event.TxHash,
+			}
+			if err := tx.Create(&order).Error; err != nil {
+				return nil, fmt.Errorf("failed to create order: %v", err)
+			}
+			projection.OrderCreated = true
+		}
+	case err != nil:
+		return nil, fmt.Errorf("failed to fetch order for %s: %v", event.TxHash, err)
+	}
+
+	if order.ID != "" {
+		projection.OrderID = order.ID
+		// An order the tracker already completed goes back to pending on a
+		// reorg, so the tracker checks its receipt again
+		projection.PreviousOrderStatus = models.OrderStatusPending
+		if order.Status != models.OrderStatusCompleted {
+			projection.PreviousOrderStatus = order.Status
+			projection.PreviousFailureReason = order.FailureReason
+			if err := s.orderService.CompleteOrderTx(tx, &order, e, feeRate); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := s.recordSale(tx, event, e, order.ID, projection); err != nil {
+		return nil, err
+	}
+
+	// The token has sold whether or not an order matches
+	if err := tx.Model(product).Update("status", models.ProductStatusSold).Error; err != nil {
+		return nil, fmt.Errorf("failed to mark product sold: %v", err)
+	}
+	return &product.ID, nil
+}
+
+// recordSale sets the price, and the order if there is one, on the transfer
+// to the buyer that the contract logs just before ProductSold
+func (s *ProjectionService) recordSale(tx *gorm.DB, event *models.ChainEvent, e *contracts.ReVibeContractProductSold, orderID string, projection *eventProjection) error {
+	var transfer models.TokenTransfer
+	err := tx.Where("chain_id = ? AND contract_address = ? AND token_id = ? AND tx_hash = ? AND to_address = ?",
+		event.ChainID, event.ContractAddress, event.TokenID, event.TxHash, e.Buyer.Hex()).
+		Order("log_index desc").
+		First(&transfer).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch transfer for %s: %v", event.TxHash, err)
+	}
+
+	updates := map[string]interface{}{"price_wei": e.Price.String()}
+	if orderID != "" {
+		updates["order_id"] = orderID
+	}
+	if err := tx.Model(&transfer).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to record sale on transfer: %v", err)
+	}
+	projection.TransferID = transfer.ID
+	return nil
+}
+
+// projectAuthenticated records an authentication when the product's flag is
+// changed on chain other than by the backend. Flags the backend set itself
+// already match the product's latest authentication.
+func (s *ProjectionService) projectAuthenticated(tx *gorm.DB, event *models.ChainEvent, e *contracts.ReVibeContractProductAuthenticated, projection *eventProjection) (*string, error) {
+	product, err := productByToken(tx, event)
+	if err != nil || product == nil {
+		return nil, err
+	}
+
+	var latest models.Authentication
+	err = tx.Where("product_id = ?", product.ID).Order("created_at desc").First(&latest).Error
+	switch {
+	case err == nil:
+		if (latest.CurrentStatus(time.Now()) == models.AuthStatusActive) == e.Authenticated {
+			return &product.ID, nil
+		}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		if !e.Authenticated {
+			return &product.ID, nil
+		}
+	default:
+		return nil, fmt.Errorf("failed to fetch authentication: %v", err)
+	}
+
+	details, err := json.Marshal(OnChainAuthenticationDetails{TxHash: event.TxHash, BlockNumber: event.BlockNumber})
+	if err != nil {
+		return nil, err
+	}
+	auth := models.Authentication{
+		ProductID: product.ID,
+		Result:    e.Authenticated,
+		Verdict:   models.AuthVerdictFail,
+		Method:    models.AuthMethodOnChain,
+		Details:   string(details),
+	}
+	if e.Authenticated {
+		auth.Verdict = models.AuthVerdictPass
+		s.authExpiryService.SetExpiry(&auth, product.Category, time.Now())
+	}
+	if err := tx.Create(&auth).Error; err != nil {
+		return nil, fmt.Errorf("failed to save authentication: %v", err)
+	}
+
+	// No certificate is issued for a flag set outside the app, but a cleared
+	// flag still revokes the product's certificates
+	if !e.Authenticated {
+		if err := s.certificateService.RecordAuthentication(tx, &auth); err != nil {
+			return nil, err
+		}
+	}
+
+	projection.AuthenticationID = auth.ID
+	return &product.ID, nil
+}
+
+// projectPriceUpdated updates the product's price
+func (s *ProjectionService) projectPriceUpdated(tx *gorm.DB, event *models.ChainEvent, e *contracts.ReVibeContractPriceUpdated, projection *eventProjection) (*string, error) {
+	product, err := productByToken(tx, event)
+	if err != nil || product == nil {
+		return nil, err
+	}
+
+	previousPrice, previousPriceWei := product.Price, product.PriceWei
+	projection.PreviousPrice = &previousPrice
+	projection.PreviousPriceWei = &previousPriceWei
+	if err := tx.Model(product).Updates(map[string]interface{}{
+		"price":     weiToPrice(e.NewPrice),
+		"price_wei": e.NewPrice.String(),
+	}).Error; err != nil {
+		return nil, fmt.Errorf("failed to update price: %v", err)
+	}
+	return &product.ID, nil
+}
+
+// projectTransfer records a token transfer and moves the product to its new
+// owner. A mint's transfer is logged before ProductListed, so it is recorded
+// before the token has a product.
+func (s *ProjectionService) projectTransfer(tx *gorm.DB, event *models.ChainEvent, e *contracts.ReVibeContractTransfer, projection *eventProjection) (*string, error) {
+	transfer := models.TokenTransfer{
+		ChainEventID:    event.ID,
+		ChainID:         event.ChainID,
+		ContractAddress: event.ContractAddress,
+		TokenID:         event.TokenID,
+		FromAddress:     e.From.Hex(),
+		ToAddress:       e.To.Hex(),
+		BlockNumber:     event.BlockNumber,
+		BlockTime:       event.BlockTime,
+		TxHash:          event.TxHash,
+		LogIndex:        event.LogIndex,
+	}
+	if err := tx.Create(&transfer).Error; err != nil {
+		return nil, fmt.Errorf("failed to record transfer: %v", err)
+	}
+
+	product, err := productByToken(tx, event)
+	if err != nil || product == nil {
+		return nil, err
+	}
+
+	previousOwner := product.OwnerAddress
+	projection.PreviousOwner = &previousOwner
+	if err := tx.Model(product).Update("owner_address", transfer.ToAddress).Error; err != nil {
+		return nil, fmt.Errorf("failed to update owner: %v", err)
+	}
+	return &product.ID, nil
+}
+
+// RevertEvent undoes an event's projection after a reorg removed it
+func (s *ProjectionService) RevertEvent(tx *gorm.DB, event *models.ChainEvent) error {
+	if event.Projection == "" {
+		return nil
+	}
+	var projection eventProjection
+	if err := json.Unmarshal([]byte(event.Projection), &projection); err != nil {
+		return fmt.Errorf("invalid projection for %s: %v", event.TxHash, err)
+	}
+
+	// Transfers are recorded whether or not their token has a product
+	switch {
+	case event.Name == EventTransfer:
+		if err := tx.Where("chain_event_id = ?", event.ID).Delete(&models.TokenTransfer{}).Error; err != nil {
+			return err
+		}
+	case projection.TransferID != "":
+		if err := tx.Model(&models.TokenTransfer{}).
+			Where("id = ?", projection.TransferID).
+			Updates(map[string]interface{}{"price_wei": "", "order_id": nil}).Error; err != nil {
+			return err
+		}
+	}
+
+	if event.ProductID == nil {
+		return nil
+	}
+	productID := *event.ProductID
+
+	switch event.Name {
+	case EventProductListed:
+		if projection.ProductCreated {
+			return tx.Unscoped().Delete(&models.Product{}, "id = ?", productID).Error
+		}
+		if projection.ListingID != "" {
+			if err := tx.Model(&models.ListingTransaction{}).
+				Where("id = ?", projection.ListingID).
+				Updates(map[string]interface{}{
+					"status":       models.ListingStatusPending,
+					"token_id":     nil,
+					"block_number": 0,
+					"confirmed_at": nil,
+				}).Error; err != nil {
+				return err
+			}
+			return tx.Model(&models.Product{}).Where("id = ?", productID).Updates(map[string]interface{}{
+				"token_id":         nil,
+				"chain_id":         0,
+				"contract_address": "",
+				"deployment_id":    nil,
+				"owner_address":    "",
+				"price_wei":        derefString(projection.PreviousPriceWei),
+			}).Error
+		}
+
+	case EventProductSold:
+		if projection.OrderID != "" {
+			if err := s.ledgerService.RevertSale(tx, projection.OrderID); err != nil {
+				return err
+			}
+			if projection.OrderCreated {
+				if err := tx.Unscoped().Delete(&models.Order{}, "id = ?", projection.OrderID).Error; err != nil {
+					return err
+				}
+			} else if err := tx.Model(&models.Order{}).
+				Where("id = ?", projection.OrderID).
+				Updates(map[string]interface{}{
+					"status":         projection.PreviousOrderStatus,
+					"failure_reason": projection.PreviousFailureReason,
+					"price_wei":      "",
+					"block_number":   0,
+					"completed_at":   nil,
+				}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&models.Product{}).Where("id = ?", productID).
+			Update("status", models.ProductStatusActive).Error
+
+	case EventProductAuthenticated:
+		// Certificates revoked by a cleared flag stay revoked
+		if projection.AuthenticationID != "" {
+			return tx.Unscoped().Delete(&models.Authentication{}, "id = ?", projection.AuthenticationID).Error
+		}
+
+	case EventPriceUpdated:
+		if projection.PreviousPrice != nil {
+			return tx.Model(&models.Product{}).Where("id = ?", productID).Updates(map[string]interface{}{
+				"price":     *projection.PreviousPrice,
+				"price_wei": derefString(projection.PreviousPriceWei),
+			}).Error
+		}
+
+	case EventTransfer:
+		if projection.PreviousOwner != nil {
+			return tx.Model(&models.Product{}).Where("id = ?", productID).
+				Update("owner_address", *projection.PreviousOwner).Error
+		}
+	}
+	return nil
+}
+
+func productIDOf(product *models.Product) *string {
+	if product == nil {
+		return nil
+	}
+	return &product.ID
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
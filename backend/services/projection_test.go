@@ -0,0 +1,319 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/contracts"
+	"github.com/yourusername/revibe/backend/models"
+	"gorm.io/gorm"
+)
+
+func TestWeiToPrice(t *testing.T) {
+	wei, _ := new(big.Int).SetString("1250000000000000000", 10)
+	assert.Equal(t, 1.25, weiToPrice(wei))
+	assert.Equal(t, 0.0, weiToPrice(big.NewInt(0)))
+}
+
+// projectionTest projects hand-built events for tokens of the mock contract
+type projectionTest struct {
+	*chainFixture
+	logIndex uint
+}
+
+func newProjectionTest(t *testing.T) *projectionTest {
+	p := &projectionTest{chainFixture: newChainFixture(t)}
+	p.projection.authExpiryService.validity = 365 * 24 * time.Hour
+	return p
+}
+
+// apply records an event of a transaction and projects it
+func (p *projectionTest) apply(txHash, name, tokenID string, decoded interface{}) *models.ChainEvent {
+	p.logIndex++
+	event := models.ChainEvent{
+		ChainID:         p.chainID,
+		ContractAddress: p.contract,
+		BlockNumber:     10,
+		BlockHash:       common.HexToHash("0x10").Hex(),
+		BlockTime:       time.Now(),
+		TxHash:          txHash,
+		LogIndex:        p.logIndex,
+		Name:            name,
+		TokenID:         tokenID,
+	}
+	require.NoError(p.t, p.db.Create(&event).Error)
+	require.NoError(p.t, p.db.Transaction(func(tx *gorm.DB) error {
+		return p.projection.HandleEvent(tx, &event, decoded)
+	}))
+	return &event
+}
+
+// revert undoes an event's projection as a reorg would, from the stored
+// event
+func (p *projectionTest) revert(event *models.ChainEvent) {
+	var stored models.ChainEvent
+	p.reload(&stored, event.ID)
+	require.NoError(p.t, p.db.Transaction(func(tx *gorm.DB) error {
+		return p.projection.RevertEvent(tx, &stored)
+	}))
+}
+
+func TestProjectListedLinksListing(t *testing.T) {
+	p := newProjectionTest(t)
+	product := createProduct(t, p.db, p.seller)
+	listing := models.ListingTransaction{ProductID: product.ID, TxHash: txHash(1), ChainID: p.chainID, ContractAddress: p.contract}
+	require.NoError(t, p.db.Create(&listing).Error)
+
+	event := p.apply(txHash(1), EventProductListed, "7", &contracts.ReVibeContractProductListed{
+		TokenId: big.NewInt(7), Seller: p.sellerWallet, Price: big.NewInt(1e17),
+	})
+	require.NotNil(t, event.ProductID)
+	assert.Equal(t, product.ID, *event.ProductID)
+
+	p.reload(&listing, listing.ID)
+	assert.Equal(t, models.ListingStatusConfirmed, listing.Status)
+	require.NotNil(t, listing.TokenID)
+	assert.Equal(t, "7", *listing.TokenID)
+	assert.Equal(t, uint64(10), listing.BlockNumber)
+	p.reload(product, product.ID)
+	require.NotNil(t, product.TokenID)
+	assert.Equal(t, "7", *product.TokenID)
+	assert.Equal(t, p.contract, product.ContractAddress)
+	assert.Equal(t, p.sellerWallet.Hex(), product.OwnerAddress)
+	assert.Equal(t, "100000000000000000", product.PriceWei)
+
+	p.revert(event)
+	p.reload(&listing, listing.ID)
+	assert.Equal(t, models.ListingStatusPending, listing.Status)
+	assert.Nil(t, listing.TokenID)
+	assert.Nil(t, listing.ConfirmedAt)
+	p.reload(product, product.ID)
+	assert.Nil(t, product.TokenID)
+	assert.Empty(t, product.ContractAddress)
+	assert.Empty(t, product.OwnerAddress)
+	assert.Empty(t, product.PriceWei)
+}
+
+func TestProjectListedCreatesProduct(t *testing.T) {
+	p := newProjectionTest(t)
+
+	event := p.apply(txHash(1), EventProductListed, "8", &contracts.ReVibeContractProductListed{
+		TokenId: big.NewInt(8), Seller: p.sellerWallet, Price: big.NewInt(2e17),
+	})
+	require.NotNil(t, event.ProductID)
+	var product models.Product
+	p.reload(&product, *event.ProductID)
+	assert.Equal(t, p.seller.ID, product.SellerID)
+	assert.Equal(t, models.ModerationStatusPendingReview, product.ModerationStatus)
+	assert.Equal(t, onChainProductCategory, product.Category)
+	assert.Equal(t, 0.2, product.Price)
+	assert.Equal(t, p.sellerWallet.Hex(), product.OwnerAddress)
+
+	p.revert(event)
+	assert.Equal(t, int64(0), p.count(&models.Product{}, "id = ?", product.ID))
+
+	// A seller without an account gets no product
+	event = p.apply(txHash(2), EventProductListed, "9", &contracts.ReVibeContractProductListed{
+		TokenId: big.NewInt(9), Seller: common.HexToAddress("0xdead"), Price: big.NewInt(2e17),
+	})
+	assert.Nil(t, event.ProductID)
+	p.revert(event)
+	assert.Equal(t, int64(0), p.count(&models.Product{}, "token_id = ?", "9"))
+}
+
+func TestProjectSold(t *testing.T) {
+	tests := []struct {
+		name          string
+		order         *models.Order
+		wantCreated   bool
+		revertsStatus string
+		revertsReason string
+	}{
+		{
+			name:          "pending order",
+			order:         &models.Order{Status: models.OrderStatusPending},
+			revertsStatus: models.OrderStatusPending,
+		},
+		{
+			name:          "failed order",
+			order:         &models.Order{Status: models.OrderStatusFailed, FailureReason: "transaction not found"},
+			revertsStatus: models.OrderStatusFailed,
+			revertsReason: "transaction not found",
+		},
+		{
+			name:        "no order",
+			wantCreated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newProjectionTest(t)
+			product := p.mintedProduct("3")
+			if tt.order != nil {
+				tt.order.ProductID = product.ID
+				tt.order.BuyerID = p.buyer.ID
+				tt.order.SellerID = p.seller.ID
+				tt.order.Price = product.Price
+				tt.order.TokenID = "3"
+				tt.order.TxHash = txHash(1)
+				require.NoError(t, p.db.Create(tt.order).Error)
+			}
+
+			transfer := p.apply(txHash(1), EventTransfer, "3", &contracts.ReVibeContractTransfer{
+				From: p.sellerWallet, To: p.buyerWallet, TokenId: big.NewInt(3),
+			})
+			sale := &contracts.ReVibeContractProductSold{
+				TokenId: big.NewInt(3), Seller: p.sellerWallet, Buyer: p.buyerWallet, Price: big.NewInt(1e17),
+				Raw: types.Log{BlockNumber: 10},
+			}
+			sold := p.apply(txHash(1), EventProductSold, "3", &preparedSale{ReVibeContractProductSold: sale, FeeRate: 25})
+
+			var order models.Order
+			require.NoError(t, p.db.First(&order, "tx_hash = ?", txHash(1)).Error)
+			assert.Equal(t, models.OrderStatusCompleted, order.Status)
+			assert.Equal(t, "100000000000000000", order.PriceWei)
+			assert.Equal(t, uint64(10), order.BlockNumber)
+			var journal models.LedgerJournal
+			require.NoError(t, p.db.First(&journal, "order_id = ?", order.ID).Error)
+			assert.Equal(t, "2500000000000000", journal.FeeWei)
+			p.reload(product, product.ID)
+			assert.Equal(t, models.ProductStatusSold, product.Status)
+			assert.Equal(t, p.buyerWallet.Hex(), product.OwnerAddress)
+			var recorded models.TokenTransfer
+			require.NoError(t, p.db.First(&recorded, "chain_event_id = ?", transfer.ID).Error)
+			assert.Equal(t, "100000000000000000", recorded.PriceWei)
+			require.NotNil(t, recorded.OrderID)
+			assert.Equal(t, order.ID, *recorded.OrderID)
+
+			// Reverted newest first, as the indexer does
+			p.revert(sold)
+			assert.Equal(t, int64(0), p.count(&models.LedgerJournal{}, "order_id = ?", order.ID))
+			if tt.wantCreated {
+				assert.Equal(t, int64(0), p.count(&models.Order{}, "id = ?", order.ID))
+			} else {
+				p.reload(&order, order.ID)
+				assert.Equal(t, tt.revertsStatus, order.Status)
+				assert.Equal(t, tt.revertsReason, order.FailureReason)
+				assert.Empty(t, order.PriceWei)
+				assert.Zero(t, order.BlockNumber)
+				assert.Nil(t, order.CompletedAt)
+			}
+			p.reload(product, product.ID)
+			assert.Equal(t, models.ProductStatusActive, product.Status)
+			p.reload(&recorded, recorded.ID)
+			assert.Empty(t, recorded.PriceWei)
+			assert.Nil(t, recorded.OrderID)
+
+			p.revert(transfer)
+			assert.Equal(t, int64(0), p.count(&models.TokenTransfer{}, "id = ?", recorded.ID))
+			p.reload(product, product.ID)
+			assert.Equal(t, p.sellerWallet.Hex(), product.OwnerAddress)
+		})
+	}
+}
+
+func TestProjectSoldRequiresPreparation(t *testing.T) {
+	p := newProjectionTest(t)
+	p.mintedProduct("3")
+	event := models.ChainEvent{
+		ChainID: p.chainID, ContractAddress: p.contract, TxHash: txHash(1), Name: EventProductSold, TokenID: "3",
+	}
+	require.NoError(t, p.db.Create(&event).Error)
+
+	err := p.projection.HandleEvent(p.db, &event, &contracts.ReVibeContractProductSold{
+		TokenId: big.NewInt(3), Seller: p.sellerWallet, Buyer: p.buyerWallet, Price: big.NewInt(1e17),
+	})
+	assert.Error(t, err)
+}
+
+func TestProjectAuthenticated(t *testing.T) {
+	tests := []struct {
+		name          string
+		active        bool
+		authenticated bool
+		wantVerdict   string
+	}{
+		{name: "set outside the app", authenticated: true, wantVerdict: models.AuthVerdictPass},
+		{name: "cleared outside the app", active: true, authenticated: false, wantVerdict: models.AuthVerdictFail},
+		{name: "set by the backend", active: true, authenticated: true},
+		{name: "never set", authenticated: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newProjectionTest(t)
+			product := p.mintedProduct("4")
+			if tt.active {
+				require.NoError(t, p.db.Create(&models.Authentication{
+					ProductID: product.ID, Result: true, Verdict: models.AuthVerdictPass, Method: models.AuthMethodReview,
+				}).Error)
+			}
+			before := p.count(&models.Authentication{}, "product_id = ?", product.ID)
+
+			event := p.apply(txHash(1), EventProductAuthenticated, "4", &contracts.ReVibeContractProductAuthenticated{
+				TokenId: big.NewInt(4), Authenticated: tt.authenticated,
+			})
+			var recorded []models.Authentication
+			require.NoError(t, p.db.Find(&recorded, "product_id = ? AND method = ?", product.ID, models.AuthMethodOnChain).Error)
+			if tt.wantVerdict == "" {
+				assert.Empty(t, recorded)
+			} else {
+				require.Len(t, recorded, 1)
+				assert.Equal(t, tt.wantVerdict, recorded[0].Verdict)
+				assert.Equal(t, tt.authenticated, recorded[0].Result)
+				assert.Equal(t, tt.authenticated, recorded[0].ExpiresAt != nil)
+			}
+
+			p.revert(event)
+			assert.Equal(t, before, p.count(&models.Authentication{}, "product_id = ?", product.ID))
+		})
+	}
+}
+
+func TestProjectPriceUpdated(t *testing.T) {
+	p := newProjectionTest(t)
+	product := p.mintedProduct("5")
+
+	event := p.apply(txHash(1), EventPriceUpdated, "5", &contracts.ReVibeContractPriceUpdated{
+		TokenId: big.NewInt(5), NewPrice: big.NewInt(9e16),
+	})
+	p.reload(product, product.ID)
+	assert.Equal(t, 0.09, product.Price)
+	assert.Equal(t, "90000000000000000", product.PriceWei)
+
+	p.revert(event)
+	p.reload(product, product.ID)
+	assert.Equal(t, 0.1, product.Price)
+	assert.Equal(t, "100000000000000000", product.PriceWei)
+}
+
+func TestProjectTransfer(t *testing.T) {
+	p := newProjectionTest(t)
+
+	// A mint is recorded before the token has a product
+	mint := p.apply(txHash(1), EventTransfer, "6", &contracts.ReVibeContractTransfer{
+		From: common.Address{}, To: p.sellerWallet, TokenId: big.NewInt(6),
+	})
+	assert.Nil(t, mint.ProductID)
+	assert.Equal(t, int64(1), p.count(&models.TokenTransfer{}, "chain_event_id = ?", mint.ID))
+	p.revert(mint)
+	assert.Equal(t, int64(0), p.count(&models.TokenTransfer{}, "chain_event_id = ?", mint.ID))
+
+	product := p.mintedProduct("6")
+	gift := p.apply(txHash(2), EventTransfer, "6", &contracts.ReVibeContractTransfer{
+		From: p.sellerWallet, To: p.buyerWallet, TokenId: big.NewInt(6),
+	})
+	p.reload(product, product.ID)
+	assert.Equal(t, p.buyerWallet.Hex(), product.OwnerAddress)
+
+	p.revert(gift)
+	p.reload(product, product.ID)
+	assert.Equal(t, p.sellerWallet.Hex(), product.OwnerAddress)
+	assert.Equal(t, int64(0), p.count(&models.TokenTransfer{}, "chain_event_id = ?", gift.ID))
+}
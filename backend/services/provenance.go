@@ -0,0 +1,187 @@
+package services
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/yourusername/revibe/backend/config"
+	"github.com/yourusername/revibe/backend/models"
+	"gorm.io/gorm"
+)
+
+// Custody entry kinds
+const (
+	CustodyKindMint     = "mint"
+	CustodyKindSale     = "sale"
+	CustodyKindTransfer = "transfer"
+)
+
+// CustodyEntry is one owner in a token's chain of custody: the transfer that
+// gave them the token and the authentications made while they held it. Until
+// is when the next transfer took the token, and is unset for the current
+// owner. Sales have a price; plain transfers outside the marketplace do not.
+type CustodyEntry struct {
+	Kind              string     `json:"kind"`
+	Owner             string     `json:"owner"`
+	From              string     `json:"from"`
+	TxHash            string     `json:"txHash"`
+	BlockNumber       uint64     `json:"blockNumber"`
+	Since             time.Time  `json:"since"`
+	Until             *time.Time `json:"until,omitempty"`
+	Price             float64    `json:"price,omitempty"`
+	PriceWei          string     `json:"priceWei,omitempty"`
+	OrderID           *string    `json:"orderId,omitempty"`
+	AuthenticationIDs []string   `json:"authenticationIds"`
+}
+
+// ProvenanceAuthentication is an authentication of a product with links to
+// its certificate and evidence bundle
+type ProvenanceAuthentication struct {
+	ID             string     `json:"id"`
+	Verdict        string     `json:"verdict"`
+	Method         string     `json:"method"`
+	Status         string     `json:"status"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	ExpiresAt      *time.Time `json:"expiresAt,omitempty"`
+	RevokedAt      *time.Time `json:"revokedAt,omitempty"`
+	CertificateURL string     `json:"certificateUrl,omitempty"`
+	EvidenceURL    string     `json:"evidenceUrl,omitempty"`
+}
+
+// Provenance is a product's chain of custody, oldest owner first, and its
+// authentications, oldest first
+type Provenance struct {
+	ProductID       string                     `json:"productId"`
+	TokenID         *string                    `json:"tokenId"`
+	ChainID         int64                      `json:"chainId,omitempty"`
+	ContractAddress string                     `json:"contractAddress,omitempty"`
+	CurrentOwner    string                     `json:"currentOwner,omitempty"`
+	Custody         []CustodyEntry             `json:"custody"`
+	Authentications []ProvenanceAuthentication `json:"authentications"`
+}
+
+// ProvenanceService builds products' ownership histories from the token
+// transfers recorded by the indexer
+type ProvenanceService struct {
+	db      *gorm.DB
+	baseURL string
+}
+
+// NewProvenanceService creates a new ProvenanceService instance
+func NewProvenanceService(db *gorm.DB) *ProvenanceService {
+	return &ProvenanceService{
+		db:      db,
+		baseURL: strings.TrimSuffix(config.AppConfig.BaseURL, "/"),
+	}
+}
+
+// GetProvenance returns a product's chain of custody. A product that is not
+// minted yet has no custody entries.
+func (s *ProvenanceService) GetProvenance(productID string) (*Provenance, error) {
+	var product models.Product
+	if err := s.db.First(&product, "id = ?", productID).Error; err != nil {
+		return nil, err
+	}
+
+	var auths []models.Authentication
+	if err := s.db.Preload("Certificate").
+		Preload("Evidence").
+		Where("product_id = ?", product.ID).
+		Order("created_at asc").
+		Find(&auths).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch authentications: %v", err)
+	}
+
+	var transfers []models.TokenTransfer
+	if product.TokenID != nil {
+		if err := s.db.Where("chain_id = ? AND contract_address = ? AND token_id = ?",
+			product.ChainID, product.ContractAddress, *product.TokenID).
+			Order("block_number asc, log_index asc").
+			Find(&transfers).Error; err != nil {
+			return nil, fmt.Errorf("failed to fetch transfers: %v", err)
+		}
+	}
+
+	return buildProvenance(&product, transfers, WithStatus(auths), s.baseURL), nil
+}
+
+// buildProvenance turns a token's transfers, oldest first, into custody
+// entries and files each authentication under the owner who held the token
+// when it was made. Authentications from before the mint go to the first
+// owner.
+func buildProvenance(product *models.Product, transfers []models.TokenTransfer, auths []models.Authentication, baseURL string) *Provenance {
+	provenance := &Provenance{
+		ProductID:       product.ID,
+		TokenID:         product.TokenID,
+		ChainID:         product.ChainID,
+		ContractAddress: product.ContractAddress,
+		CurrentOwner:    product.OwnerAddress,
+		Custody:         make([]CustodyEntry, 0, len(transfers)),
+		Authentications: make([]ProvenanceAuthentication, 0, len(auths)),
+	}
+
+	for i, transfer := range transfers {
+		entry := CustodyEntry{
+			Kind:              CustodyKindTransfer,
+			Owner:             transfer.ToAddress,
+			From:              transfer.FromAddress,
+			TxHash:            transfer.TxHash,
+			BlockNumber:       transfer.BlockNumber,
+			Since:             transfer.BlockTime,
+			PriceWei:          transfer.PriceWei,
+			OrderID:           transfer.OrderID,
+			AuthenticationIDs: []string{},
+		}
+		switch {
+		case common.HexToAddress(transfer.FromAddress) == (common.Address{}):
+			entry.Kind = CustodyKindMint
+		case transfer.PriceWei != "":
+			entry.Kind = CustodyKindSale
+			if wei, ok := new(big.Int).SetString(transfer.PriceWei, 10); ok {
+				entry.Price = weiToPrice(wei)
+			}
+		}
+		if i+1 < len(transfers) {
+			until := transfers[i+1].BlockTime
+			entry.Until = &until
+		}
+		provenance.Custody = append(provenance.Custody, entry)
+	}
+
+	custody := provenance.Custody
+	for _, auth := range auths {
+		entry := ProvenanceAuthentication{
+			ID:        auth.ID,
+			Verdict:   auth.Verdict,
+			Method:    auth.Method,
+			Status:    auth.Status,
+			CreatedAt: auth.CreatedAt,
+			ExpiresAt: auth.ExpiresAt,
+			RevokedAt: auth.RevokedAt,
+		}
+		if auth.Certificate != nil {
+			entry.CertificateURL = baseURL + "/certificates/" + auth.Certificate.ID
+		}
+		if auth.Evidence != nil {
+			entry.EvidenceURL = baseURL + "/api/authentications/" + auth.ID + "/evidence"
+		}
+		provenance.Authentications = append(provenance.Authentications, entry)
+
+		if len(custody) == 0 {
+			continue
+		}
+		holder := sort.Search(len(custody), func(i int) bool {
+			return custody[i].Since.After(auth.CreatedAt)
+		}) - 1
+		if holder < 0 {
+			holder = 0
+		}
+		custody[holder].AuthenticationIDs = append(custody[holder].AuthenticationIDs, auth.ID)
+	}
+
+	return provenance
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/models"
+)
+
+func TestBuildProvenance(t *testing.T) {
+	seller := common.HexToAddress("0x01").Hex()
+	buyer := common.HexToAddress("0x02").Hex()
+	collector := common.HexToAddress("0x03").Hex()
+	minted := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	sold := minted.Add(48 * time.Hour)
+	gifted := sold.Add(30 * 24 * time.Hour)
+
+	tokenID := "7"
+	orderID := "order-1"
+	product := &models.Product{ID: "product-1", TokenID: &tokenID, ChainID: 1, OwnerAddress: collector}
+	transfers := []models.TokenTransfer{
+		{FromAddress: (common.Address{}).Hex(), ToAddress: seller, BlockNumber: 10, BlockTime: minted, TxHash: "0xmint"},
+		{FromAddress: seller, ToAddress: buyer, BlockNumber: 20, BlockTime: sold, TxHash: "0xsale", PriceWei: "500000000000000000", OrderID: &orderID},
+		{FromAddress: buyer, ToAddress: collector, BlockNumber: 30, BlockTime: gifted, TxHash: "0xgift"},
+	}
+	auths := []models.Authentication{
+		{ID: "before-mint", CreatedAt: minted.Add(-time.Hour), Certificate: &models.Certificate{ID: "cert-1"}},
+		{ID: "while-listed", CreatedAt: minted.Add(time.Hour), Evidence: &models.EvidenceBundle{}},
+		{ID: "after-gift", CreatedAt: gifted.Add(time.Hour)},
+	}
+
+	provenance := buildProvenance(product, transfers, auths, "https://revibe.example")
+	assert.Equal(t, collector, provenance.CurrentOwner)
+	require.Len(t, provenance.Custody, 3)
+
+	mint := provenance.Custody[0]
+	assert.Equal(t, CustodyKindMint, mint.Kind)
+	assert.Equal(t, seller, mint.Owner)
+	assert.Equal(t, minted, mint.Since)
+	require.NotNil(t, mint.Until)
+	assert.Equal(t, sold, *mint.Until)
+	assert.Equal(t, []string{"before-mint", "while-listed"}, mint.AuthenticationIDs)
+
+	sale := provenance.Custody[1]
+	assert.Equal(t, CustodyKindSale, sale.Kind)
+	assert.Equal(t, 0.5, sale.Price)
+	assert.Equal(t, &orderID, sale.OrderID)
+	assert.Empty(t, sale.AuthenticationIDs)
+
+	gift := provenance.Custody[2]
+	assert.Equal(t, CustodyKindTransfer, gift.Kind)
+	assert.Equal(t, buyer, gift.From)
+	assert.Nil(t, gift.Until)
+	assert.Zero(t, gift.Price)
+	assert.Equal(t, []string{"after-gift"}, gift.AuthenticationIDs)
+
+	require.Len(t, provenance.Authentications, 3)
+	assert.Equal(t, "https://revibe.example/certificates/cert-1", provenance.Authentications[0].CertificateURL)
+	assert.Equal(t, "https://revibe.example/api/authentications/while-listed/evidence", provenance.Authentications[1].EvidenceURL)
+	assert.Empty(t, provenance.Authentications[2].CertificateURL)
+
+	// An unminted product has no custody but keeps its authentications
+	provenance = buildProvenance(&models.Product{ID: "product-2"}, nil, auths[:1], "")
+	assert.Empty(t, provenance.Custody)
+	assert.Len(t, provenance.Authentications, 1)
+}
@@ -0,0 +1,479 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/yourusername/revibe/backend/config"
+	"github.com/yourusername/revibe/backend/contracts"
+	"github.com/yourusername/revibe/backend/models"
+	"github.com/yourusername/revibe/backend/utils"
+	"gorm.io/gorm"
+)
+
+// reconcileLookback is how many blocks the first run scans when no earlier
+// run has recorded where it stopped
+const reconcileLookback = 50000
+
+var (
+	ErrReconciliationRunning = errors.New("a reconciliation run is already in progress")
+	ErrInvalidBlockRange     = errors.New("invalid block range")
+)
+
+// ReconcileOptions controls a reconciliation run. Nil blocks continue from
+// the previous run and stop at the latest block.
+type ReconcileOptions struct {
+	FromBlock     *uint64
+	ToBlock       *uint64
+	Repair        bool
+	TriggeredByID *string
+}
+
+// ReconciliationService compares contract state and events against orders and
+// products, recording discrepancies and optionally repairing missing orders.
+// It reconciles the active deployment; sales on legacy deployments are still
+// projected by their indexers.
+type ReconciliationService struct {
+	db             *gorm.DB
+	web3Service    *Web3Service
+	listingService *ListingService
+	orderService   *OrderService
+	interval       time.Duration
+	autoRepair     bool
+	blockRange     uint64
+	mu             sync.Mutex
+}
+
+// NewReconciliationService creates a new ReconciliationService instance
+func NewReconciliationService(db *gorm.DB, web3Service *Web3Service, listingService *ListingService, orderService *OrderService) *ReconciliationService {
+	blockRange := config.AppConfig.ReconcileBlockRange
+	if blockRange <= 0 {
+		blockRange = 5000
+	}
+
+	return &ReconciliationService{
+		db:             db,
+		web3Service:    web3Service,
+		listingService: listingService,
+		orderService:   orderService,
+		interval:       config.AppConfig.ReconcileInterval,
+		autoRepair:     config.AppConfig.ReconcileAutoRepair,
+		blockRange:     uint64(blockRange),
+	}
+}
+
+// StartReconciler runs reconciliation on the configured interval until ctx is cancelled
+func (s *ReconciliationService) StartReconciler(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.Run(ctx, ReconcileOptions{Repair: s.autoRepair}); err != nil && err != ErrReconciliationRunning {
+				utils.LogError(err, map[string]interface{}{
+					"component": "reconciler",
+				})
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Run performs one reconciliation and returns its report. Only one run may
+// be in progress at a time.
+func (s *ReconciliationService) Run(ctx context.Context, opts ReconcileOptions) (*models.ReconciliationRun, error) {
+	if !s.mu.TryLock() {
+		return nil, ErrReconciliationRunning
+	}
+	defer s.mu.Unlock()
+
+	fromBlock, toBlock, err := s.blockRangeFor(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	run := models.ReconciliationRun{
+		FromBlock:     fromBlock,
+		ToBlock:       toBlock,
+		Repair:        opts.Repair,
+		Status:        models.ReconciliationStatusRunning,
+		TriggeredByID: opts.TriggeredByID,
+		StartedAt:     time.Now(),
+	}
+	if err := s.db.Create(&run).Error; err != nil {
+		return nil, fmt.Errorf("failed to create reconciliation run: %v", err)
+	}
+
+	runErr := s.reconcileSales(ctx, &run)
+	if runErr == nil {
+		runErr = s.reconcileUsers(ctx, &run)
+	}
+
+	now := time.Now()
+	run.FinishedAt = &now
+	run.Status = models.ReconciliationStatusCompleted
+	if runErr != nil {
+		run.Status = models.ReconciliationStatusFailed
+		run.Error = runErr.Error()
+	}
+	if err := s.db.Omit("Discrepancies").Save(&run).Error; err != nil {
+		return nil, fmt.Errorf("failed to update reconciliation run: %v", err)
+	}
+
+	return &run, nil
+}
+
+// blockRangeFor resolves the blocks a run covers
+func (s *ReconciliationService) blockRangeFor(ctx context.Context, opts ReconcileOptions) (uint64, uint64, error) {
+	latest, err := s.web3Service.BlockNumber(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get latest block: %v", err)
+	}
+
+	toBlock := latest
+	if opts.ToBlock != nil {
+		toBlock = *opts.ToBlock
+	}
+
+	var fromBlock uint64
+	if opts.FromBlock != nil {
+		fromBlock = *opts.FromBlock
+	} else {
+		var last models.ReconciliationRun
+		err := s.db.Where("status = ?", models.ReconciliationStatusCompleted).
+			Order("to_block desc").
+			First(&last).Error
+		switch {
+		case err == nil:
+			fromBlock = last.ToBlock + 1
+		case err == gorm.ErrRecordNotFound:
+			if toBlock > reconcileLookback {
+				fromBlock = toBlock - reconcileLookback
+			}
+		default:
+			return 0, 0, fmt.Errorf("failed to fetch last reconciliation run: %v", err)
+		}
+	}
+
+	if toBlock > latest || fromBlock > toBlock+1 {
+		return 0, 0, ErrInvalidBlockRange
+	}
+
+	return fromBlock, toBlock, nil
+}
+
+func (s *ReconciliationService) record(run *models.ReconciliationRun, d models.ReconciliationDiscrepancy) {
+	d.RunID = run.ID
+	run.Found++
+	if d.Repaired {
+		run.Repaired++
+	}
+	if err := s.db.Create(&d).Error; err != nil {
+		utils.LogError(err, map[string]interface{}{
+			"component": "reconciler",
+			"run_id":    run.ID,
+			"kind":      d.Kind,
+		})
+	}
+}
+
+// reconcileSales checks every ProductSold event in the run's block range
+// against the order for its transaction
+func (s *ReconciliationService) reconcileSales(ctx context.Context, run *models.ReconciliationRun) error {
+	for start := run.FromBlock; start <= run.ToBlock; start += s.blockRange {
+		end := start + s.blockRange - 1
+		if end > run.ToBlock {
+			end = run.ToBlock
+		}
+
+		events, err := s.web3Service.GetProductSoldEvents(ctx,
+			new(big.Int).SetUint64(start), new(big.Int).SetUint64(end))
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			if err := s.reconcileSale(run, event); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *ReconciliationService) reconcileSale(run *models.ReconciliationRun, event *contracts.ReVibeContractProductSold) error {
+	tokenID := event.TokenId.String()
+	txHash := event.Raw.TxHash.Hex()
+
+	product, err := s.listingService.GetProductByToken(tokenID)
+	if err == gorm.ErrRecordNotFound {
+		s.record(run, models.ReconciliationDiscrepancy{
+			Kind:    models.DiscrepancyUnknownToken,
+			TokenID: tokenID,
+			TxHash:  txHash,
+			Detail:  "sold token is not linked to any product",
+		})
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch product for token %s: %v", tokenID, err)
+	}
+
+	var order models.Order
+	err = s.db.Where("tx_hash = ?", txHash).First(&order).Error
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		d := models.ReconciliationDiscrepancy{
+			Kind:      models.DiscrepancyMissingOrder,
+			TokenID:   tokenID,
+			TxHash:    txHash,
+			Address:   event.Buyer.Hex(),
+			ProductID: &product.ID,
+			Detail:    "sale has no order",
+		}
+		if run.Repair {
+			recovered, repairErr := s.recoverOrder(product, event)
+			if repairErr != nil {
+				d.Detail += "; repair failed: " + repairErr.Error()
+			} else {
+				d.OrderID = &recovered.ID
+				d.Repaired = recovered.Status == models.OrderStatusCompleted
+			}
+		}
+		s.record(run, d)
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to fetch order for %s: %v", txHash, err)
+	}
+
+	if order.Status != models.OrderStatusCompleted {
+		d := models.ReconciliationDiscrepancy{
+			Kind:      models.DiscrepancyOrderNotCompleted,
+			TokenID:   tokenID,
+			TxHash:    txHash,
+			ProductID: &product.ID,
+			OrderID:   &order.ID,
+			Detail:    fmt.Sprintf("order is %s but the sale succeeded on chain", order.Status),
+		}
+		if run.Repair {
+			if repairErr := s.orderService.CompleteOrder(&order, event); repairErr != nil {
+				d.Detail += "; repair failed: " + repairErr.Error()
+			} else {
+				d.Repaired = order.Status == models.OrderStatusCompleted
+			}
+		}
+		s.record(run, d)
+		return nil
+	}
+
+	if product.Status != models.ProductStatusSold {
+		d := models.ReconciliationDiscrepancy{
+			Kind:      models.DiscrepancyProductNotSold,
+			TokenID:   tokenID,
+			TxHash:    txHash,
+			ProductID: &product.ID,
+			OrderID:   &order.ID,
+			Detail:    fmt.Sprintf("product is %s but its token was sold", product.Status),
+		}
+		if run.Repair {
+			if repairErr := s.db.Model(product).Update("status", models.ProductStatusSold).Error; repairErr != nil {
+				d.Detail += "; repair failed: " + repairErr.Error()
+			} else {
+				d.Repaired = true
+			}
+		}
+		s.record(run, d)
+	}
+
+	return nil
+}
+
+// recoverOrder creates the order a missed sale should have produced and
+// completes it from the event
+func (s *ReconciliationService) recoverOrder(product *models.Product, event *contracts.ReVibeContractProductSold) (*models.Order, error) {
+	var buyer models.User
+	if err := s.db.Where("LOWER(wallet_address) = ?", strings.ToLower(event.Buyer.Hex())).
+		First(&buyer).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("buyer wallet has no account")
+		}
+		return nil, err
+	}
+
+	order := models.Order{
+		ProductID: product.ID,
+		BuyerID:   buyer.ID,
+		SellerID:  product.SellerID,
+		Price:     product.Price,
+		TokenID:   event.TokenId.String(),
+		Status:    models.OrderStatusPending,
+		TxHash:    event.Raw.TxHash.Hex(),
+	}
+	if err := s.db.Create(&order).Error; err != nil {
+		return nil, fmt.Errorf("failed to create order: %v", err)
+	}
+
+	if err := s.orderService.CompleteOrder(&order, event); err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// reconcileUsers compares each user's on-chain purchases and listings with
+// their completed orders and linked products
+func (s *ReconciliationService) reconcileUsers(ctx context.Context, run *models.ReconciliationRun) error {
+	var users []models.User
+	return s.db.Where("wallet_address <> ''").FindInBatches(&users, 100, func(tx *gorm.DB, batch int) error {
+		for i := range users {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if !common.IsHexAddress(users[i].WalletAddress) {
+				continue
+			}
+			if err := s.reconcileUser(run, &users[i]); err != nil {
+				utils.LogError(err, map[string]interface{}{
+					"component": "reconciler",
+					"user_id":   users[i].ID,
+				})
+			}
+		}
+		return nil
+	}).Error
+}
+
+func (s *ReconciliationService) reconcileUser(run *models.ReconciliationRun, user *models.User) error {
+	address := common.HexToAddress(user.WalletAddress)
+	chainID := s.web3Service.ChainID().Int64()
+	contract := s.web3Service.ContractAddress().Hex()
+
+	purchases, err := s.web3Service.GetUserPurchases(address)
+	if err != nil {
+		return err
+	}
+
+	var orderTokens []string
+	if err := s.db.Model(&models.Order{}).
+		Joins("JOIN products ON products.id = orders.product_id").
+		Where("orders.buyer_id = ? AND orders.status = ?", user.ID, models.OrderStatusCompleted).
+		Where("products.chain_id = ? AND products.contract_address = ?", chainID, contract).
+		Pluck("orders.token_id", &orderTokens).Error; err != nil {
+		return fmt.Errorf("failed to fetch orders: %v", err)
+	}
+
+	onChain, inDB := tokenSet(purchases), stringSet(orderTokens)
+	for tokenID := range onChain {
+		if !inDB[tokenID] {
+			s.record(run, models.ReconciliationDiscrepancy{
+				Kind:    models.DiscrepancyPurchaseNotRecorded,
+				TokenID: tokenID,
+				Address: address.Hex(),
+				Detail:  "token bought on chain has no completed order",
+			})
+		}
+	}
+	for tokenID := range inDB {
+		if !onChain[tokenID] {
+			s.record(run, models.ReconciliationDiscrepancy{
+				Kind:    models.DiscrepancyOrderNotOnChain,
+				TokenID: tokenID,
+				Address: address.Hex(),
+				Detail:  "completed order is not among the buyer's purchases on chain",
+			})
+		}
+	}
+
+	listed, err := s.web3Service.GetUserProducts(address)
+	if err != nil {
+		return err
+	}
+
+	var productTokens []string
+	if err := s.db.Model(&models.Product{}).
+		Where("seller_id = ? AND token_id IS NOT NULL AND chain_id = ? AND contract_address = ?", user.ID, chainID, contract).
+		Pluck("token_id", &productTokens).Error; err != nil {
+		return fmt.Errorf("failed to fetch products: %v", err)
+	}
+
+	onChain, inDB = tokenSet(listed), stringSet(productTokens)
+	for tokenID := range onChain {
+		if !inDB[tokenID] {
+			s.record(run, models.ReconciliationDiscrepancy{
+				Kind:    models.DiscrepancyListingNotRecorded,
+				TokenID: tokenID,
+				Address: address.Hex(),
+				Detail:  "token listed on chain is not linked to any of the seller's products",
+			})
+		}
+	}
+	for tokenID := range inDB {
+		if !onChain[tokenID] {
+			s.record(run, models.ReconciliationDiscrepancy{
+				Kind:    models.DiscrepancyProductNotOnChain,
+				TokenID: tokenID,
+				Address: address.Hex(),
+				Detail:  "product's token is not among the seller's listings on chain",
+			})
+		}
+	}
+
+	return nil
+}
+
+// GetRun retrieves a reconciliation run with its discrepancies
+func (s *ReconciliationService) GetRun(runID string) (*models.ReconciliationRun, error) {
+	var run models.ReconciliationRun
+	err := s.db.Preload("Discrepancies", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at asc")
+	}).First(&run, "id = ?", runID).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &run, nil
+}
+
+// ListRuns returns a page of reconciliation runs, newest first
+func (s *ReconciliationService) ListRuns(page, limit int) ([]models.ReconciliationRun, int64, error) {
+	var total int64
+	if err := s.db.Model(&models.ReconciliationRun{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count reconciliation runs: %v", err)
+	}
+
+	var runs []models.ReconciliationRun
+	err := s.db.Order("started_at desc").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&runs).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch reconciliation runs: %v", err)
+	}
+
+	return runs, total, nil
+}
+
+func tokenSet(tokenIDs []*big.Int) map[string]bool {
+	set := make(map[string]bool, len(tokenIDs))
+	for _, id := range tokenIDs {
+		set[id.String()] = true
+	}
+	return set
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
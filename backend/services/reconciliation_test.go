@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/models"
+)
+
+func newTestReconciler(f *chainFixture) *ReconciliationService {
+	return &ReconciliationService{
+		db:             f.db,
+		web3Service:    f.web3,
+		listingService: NewListingService(f.db, f.registry),
+		orderService:   f.orders,
+		blockRange:     100,
+	}
+}
+
+// saleOrder creates an order for a sale transaction in status
+func saleOrder(f *chainFixture, product *models.Product, saleTx, status string) *models.Order {
+	order := models.Order{
+		ProductID: product.ID,
+		BuyerID:   f.buyer.ID,
+		SellerID:  f.seller.ID,
+		Price:     product.Price,
+		TokenID:   *product.TokenID,
+		Status:    status,
+		TxHash:    saleTx,
+	}
+	if status == models.OrderStatusCompleted {
+		order.PriceWei = "100000000000000000"
+	}
+	require.NoError(f.t, f.db.Create(&order).Error)
+	return &order
+}
+
+func TestReconcileSales(t *testing.T) {
+	tests := []struct {
+		name         string
+		tokenID      int64
+		buyer        common.Address
+		setup        func(f *chainFixture, product *models.Product, saleTx string)
+		wantKind     string
+		wantRepaired bool
+		wantDetail   string
+	}{
+		{
+			name: "recorded sale",
+			setup: func(f *chainFixture, product *models.Product, saleTx string) {
+				saleOrder(f, product, saleTx, models.OrderStatusCompleted)
+				require.NoError(t, f.db.Model(product).Update("status", models.ProductStatusSold).Error)
+			},
+		},
+		{
+			name:         "sale without an order",
+			wantKind:     models.DiscrepancyMissingOrder,
+			wantRepaired: true,
+		},
+		{
+			name:       "sale to a wallet without an account",
+			buyer:      common.HexToAddress("0xca7"),
+			wantKind:   models.DiscrepancyMissingOrder,
+			wantDetail: "buyer wallet has no account",
+		},
+		{
+			name: "pending order",
+			setup: func(f *chainFixture, product *models.Product, saleTx string) {
+				saleOrder(f, product, saleTx, models.OrderStatusPending)
+			},
+			wantKind:     models.DiscrepancyOrderNotCompleted,
+			wantRepaired: true,
+		},
+		{
+			name: "expired order",
+			setup: func(f *chainFixture, product *models.Product, saleTx string) {
+				saleOrder(f, product, saleTx, models.OrderStatusExpired)
+			},
+			wantKind:     models.DiscrepancyOrderNotCompleted,
+			wantRepaired: true,
+		},
+		{
+			name: "product still for sale",
+			setup: func(f *chainFixture, product *models.Product, saleTx string) {
+				saleOrder(f, product, saleTx, models.OrderStatusCompleted)
+			},
+			wantKind:     models.DiscrepancyProductNotSold,
+			wantRepaired: true,
+		},
+		{
+			name:     "token without a product",
+			tokenID:  9,
+			wantKind: models.DiscrepancyUnknownToken,
+		},
+	}
+
+	for _, tt := range tests {
+		for _, repair := range []bool{false, true} {
+			name := tt.name
+			if repair {
+				name += " with repair"
+			}
+			t.Run(name, func(t *testing.T) {
+				f := newChainFixture(t)
+				product := f.mintedProduct("1")
+				tokenID, buyer := tt.tokenID, tt.buyer
+				if tokenID == 0 {
+					tokenID = 1
+				}
+				if buyer == (common.Address{}) {
+					buyer = f.buyerWallet
+				}
+				saleTx := f.chain.sell(tokenID, f.sellerWallet, buyer, big.NewInt(1e17)).Hex()
+				f.chain.commit()
+				if tt.setup != nil {
+					tt.setup(f, product, saleTx)
+				}
+
+				run, err := newTestReconciler(f).Run(context.Background(), ReconcileOptions{Repair: repair})
+				require.NoError(t, err)
+				assert.Equal(t, models.ReconciliationStatusCompleted, run.Status)
+
+				var found []models.ReconciliationDiscrepancy
+				require.NoError(t, f.db.Find(&found, "run_id = ? AND tx_hash = ?", run.ID, saleTx).Error)
+				if tt.wantKind == "" {
+					assert.Empty(t, found)
+					return
+				}
+				require.Len(t, found, 1)
+				assert.Equal(t, tt.wantKind, found[0].Kind)
+				assert.Equal(t, repair && tt.wantRepaired, found[0].Repaired)
+				if repair && tt.wantDetail != "" {
+					assert.Contains(t, found[0].Detail, tt.wantDetail)
+				}
+				if found[0].Repaired {
+					assert.Equal(t, 1, run.Repaired)
+				}
+
+				var order models.Order
+				orderErr := f.db.First(&order, "tx_hash = ?", saleTx).Error
+				f.reload(product, product.ID)
+				if !found[0].Repaired {
+					// Without a repair the database is left as it was
+					if tt.setup == nil {
+						assert.Error(t, orderErr)
+					}
+					if tt.wantKind != models.DiscrepancyProductNotSold && orderErr == nil {
+						assert.NotEqual(t, models.OrderStatusCompleted, order.Status)
+					}
+					assert.Equal(t, models.ProductStatusActive, product.Status)
+					return
+				}
+				require.NoError(t, orderErr)
+				assert.Equal(t, models.OrderStatusCompleted, order.Status)
+				assert.Equal(t, "100000000000000000", order.PriceWei)
+				assert.Equal(t, models.ProductStatusSold, product.Status)
+				if tt.wantKind != models.DiscrepancyProductNotSold {
+					// Orders completed by a repair are posted to the ledger
+					assert.Equal(t, int64(1), f.count(&models.LedgerJournal{}, "order_id = ?", order.ID))
+				}
+			})
+		}
+	}
+}
+
+func TestReconcileUsers(t *testing.T) {
+	f := newChainFixture(t)
+	tokens := func(ids ...int64) []*big.Int {
+		out := make([]*big.Int, len(ids))
+		for i, id := range ids {
+			out[i] = big.NewInt(id)
+		}
+		return out
+	}
+
+	// The seller has products for tokens 1, 2 and 5 but listed 2, 3 and 5;
+	// the buyer completed an order for token 5 but bought 6
+	f.mintedProduct("1")
+	f.mintedProduct("2")
+	saleOrder(f, f.mintedProduct("5"), txHash(1), models.OrderStatusCompleted)
+	f.chain.respond("getUserProducts", []interface{}{f.sellerWallet}, tokens(2, 3, 5))
+	f.chain.respond("getUserPurchases", []interface{}{f.sellerWallet}, tokens())
+	f.chain.respond("getUserProducts", []interface{}{f.buyerWallet}, tokens())
+	f.chain.respond("getUserPurchases", []interface{}{f.buyerWallet}, tokens(6))
+	f.chain.commit()
+
+	run, err := newTestReconciler(f).Run(context.Background(), ReconcileOptions{Repair: true})
+	require.NoError(t, err)
+	assert.Equal(t, 4, run.Found)
+	assert.Equal(t, 0, run.Repaired)
+
+	stored, err := newTestReconciler(f).GetRun(run.ID)
+	require.NoError(t, err)
+	found := make(map[string]string)
+	for _, d := range stored.Discrepancies {
+		found[d.Kind] = d.TokenID
+		assert.False(t, d.Repaired)
+	}
+	assert.Equal(t, map[string]string{
+		models.DiscrepancyListingNotRecorded:  "3",
+		models.DiscrepancyProductNotOnChain:   "1",
+		models.DiscrepancyPurchaseNotRecorded: "6",
+		models.DiscrepancyOrderNotOnChain:     "5",
+	}, found)
+}
+
+func TestReconcileBlockRange(t *testing.T) {
+	ctx := context.Background()
+	f := newChainFixture(t)
+	reconciler := newTestReconciler(f)
+	product := f.mintedProduct("1")
+	saleTx := f.chain.sell(1, f.sellerWallet, f.buyerWallet, big.NewInt(1e17)).Hex()
+	head := f.chain.commit()
+
+	first, err := reconciler.Run(ctx, ReconcileOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), first.FromBlock)
+	assert.Equal(t, head, first.ToBlock)
+	assert.Equal(t, int64(1), f.count(&models.ReconciliationDiscrepancy{}, "run_id = ? AND tx_hash = ?", first.ID, saleTx))
+
+	// The next run carries on after the last one, so the sale is not found
+	// again
+	f.chain.commit()
+	second, err := reconciler.Run(ctx, ReconcileOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, head+1, second.FromBlock)
+	assert.Equal(t, int64(0), f.count(&models.ReconciliationDiscrepancy{}, "run_id = ? AND tx_hash = ?", second.ID, saleTx))
+
+	beyond := head + 10
+	_, err = reconciler.Run(ctx, ReconcileOptions{ToBlock: &beyond})
+	assert.ErrorIs(t, err, ErrInvalidBlockRange)
+
+	runs, total, err := reconciler.ListRuns(1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, runs, 2)
+	f.reload(product, product.ID)
+	assert.Equal(t, models.ProductStatusActive, product.Status)
+}
@@ -0,0 +1,308 @@
+package services
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/revibe/backend/config"
+	"github.com/yourusername/revibe/backend/models"
+	"gorm.io/gorm"
+)
+
+const reportMonthLayout = "2006-01"
+
+// SaleRecord is a completed sale with its ledger amounts
+type SaleRecord struct {
+	OrderID         string    `json:"orderId"`
+	ProductID       string    `json:"productId"`
+	ProductName     string    `json:"productName"`
+	TokenID         string    `json:"tokenId"`
+	TxHash          string    `json:"txHash"`
+	CompletedAt     time.Time `json:"completedAt"`
+	GrossWei        string    `json:"priceWei"`
+	FeeWei          string    `json:"feeWei"`
+	NetWei          string    `json:"netWei"`
+	FeeRatePerMille int64     `json:"feeRatePerMille"`
+}
+
+// SalesReportLine is a sale with amounts in ether and fiat
+type SalesReportLine struct {
+	SaleRecord
+	Price     string `json:"price"`
+	Fee       string `json:"fee"`
+	Net       string `json:"net"`
+	FiatRate  string `json:"fiatRate,omitempty"`
+	PriceFiat string `json:"priceFiat,omitempty"`
+	FeeFiat   string `json:"feeFiat,omitempty"`
+	NetFiat   string `json:"netFiat,omitempty"`
+}
+
+// SalesReportTotals sums a group of sales. Fiat totals only include sales
+// with a known rate.
+type SalesReportTotals struct {
+	Sales     int    `json:"sales"`
+	PriceWei  string `json:"priceWei"`
+	FeeWei    string `json:"feeWei"`
+	NetWei    string `json:"netWei"`
+	Price     string `json:"price"`
+	Fee       string `json:"fee"`
+	Net       string `json:"net"`
+	PriceFiat string `json:"priceFiat,omitempty"`
+	FeeFiat   string `json:"feeFiat,omitempty"`
+	NetFiat   string `json:"netFiat,omitempty"`
+}
+
+// SalesReportMonth groups a seller's sales in one calendar month (UTC)
+type SalesReportMonth struct {
+	Month  string            `json:"month"`
+	Sales  []SalesReportLine `json:"sales"`
+	Totals SalesReportTotals `json:"totals"`
+}
+
+// SalesReport is a seller's sales statement for a period
+type SalesReport struct {
+	SellerID     string             `json:"sellerId"`
+	Currency     string             `json:"currency,omitempty"`
+	From         time.Time          `json:"from"`
+	To           time.Time          `json:"to"`
+	Months       []SalesReportMonth `json:"months"`
+	Totals       SalesReportTotals  `json:"totals"`
+	MissingRates bool               `json:"missingRates"`
+}
+
+// salesAccumulator sums wei and fiat amounts for report totals
+type salesAccumulator struct {
+	sales                       int
+	priceWei, feeWei, netWei    *big.Int
+	priceFiat, feeFiat, netFiat *big.Rat
+	hasFiat                     bool
+}
+
+func newSalesAccumulator() *salesAccumulator {
+	return &salesAccumulator{
+		priceWei:  new(big.Int),
+		feeWei:    new(big.Int),
+		netWei:    new(big.Int),
+		priceFiat: new(big.Rat),
+		feeFiat:   new(big.Rat),
+		netFiat:   new(big.Rat),
+	}
+}
+
+func (a *salesAccumulator) add(gross, fee, net *big.Int, rate *big.Rat) {
+	a.sales++
+	a.priceWei.Add(a.priceWei, gross)
+	a.feeWei.Add(a.feeWei, fee)
+	a.netWei.Add(a.netWei, net)
+	if rate != nil {
+		a.hasFiat = true
+		a.priceFiat.Add(a.priceFiat, toFiat(gross, rate))
+		a.feeFiat.Add(a.feeFiat, toFiat(fee, rate))
+		a.netFiat.Add(a.netFiat, toFiat(net, rate))
+	}
+}
+
+func (a *salesAccumulator) totals() SalesReportTotals {
+	totals := SalesReportTotals{
+		Sales:    a.sales,
+		PriceWei: a.priceWei.String(),
+		FeeWei:   a.feeWei.String(),
+		NetWei:   a.netWei.String(),
+		Price:    FormatEther(a.priceWei),
+		Fee:      FormatEther(a.feeWei),
+		Net:      FormatEther(a.netWei),
+	}
+	if a.hasFiat {
+		totals.PriceFiat = a.priceFiat.FloatString(2)
+		totals.FeeFiat = a.feeFiat.FloatString(2)
+		totals.NetFiat = a.netFiat.FloatString(2)
+	}
+	return totals
+}
+
+func toFiat(wei *big.Int, rate *big.Rat) *big.Rat {
+	return new(big.Rat).Mul(WeiToEther(wei), rate)
+}
+
+func parseWei(value string) *big.Int {
+	wei, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return new(big.Int)
+	}
+	return wei
+}
+
+// BuildSalesReport groups sales by month and converts their amounts to fiat
+// at the rate of the day each sale completed. sales must be ordered by
+// completion time.
+func BuildSalesReport(sellerID string, from, to time.Time, sales []SaleRecord, rates *FiatRateTable) *SalesReport {
+	report := &SalesReport{
+		SellerID: sellerID,
+		From:     from,
+		To:       to,
+		Months:   []SalesReportMonth{},
+	}
+	if rates != nil {
+		report.Currency = rates.Currency
+	}
+
+	total := newSalesAccumulator()
+	var month *salesAccumulator
+	for _, sale := range sales {
+		key := sale.CompletedAt.UTC().Format(reportMonthLayout)
+		if len(report.Months) == 0 || report.Months[len(report.Months)-1].Month != key {
+			if month != nil {
+				report.Months[len(report.Months)-1].Totals = month.totals()
+			}
+			report.Months = append(report.Months, SalesReportMonth{Month: key})
+			month = newSalesAccumulator()
+		}
+
+		gross, fee, net := parseWei(sale.GrossWei), parseWei(sale.FeeWei), parseWei(sale.NetWei)
+		line := SalesReportLine{
+			SaleRecord: sale,
+			Price:      FormatEther(gross),
+			Fee:        FormatEther(fee),
+			Net:        FormatEther(net),
+		}
+
+		rate := rates.RateAt(sale.CompletedAt)
+		if rate != nil {
+			line.FiatRate = rate.FloatString(2)
+			line.PriceFiat = toFiat(gross, rate).FloatString(2)
+			line.FeeFiat = toFiat(fee, rate).FloatString(2)
+			line.NetFiat = toFiat(net, rate).FloatString(2)
+		} else if rates != nil {
+			report.MissingRates = true
+		}
+
+		current := &report.Months[len(report.Months)-1]
+		current.Sales = append(current.Sales, line)
+		month.add(gross, fee, net, rate)
+		total.add(gross, fee, net, rate)
+	}
+	if month != nil {
+		report.Months[len(report.Months)-1].Totals = month.totals()
+	}
+	report.Totals = total.totals()
+
+	return report
+}
+
+// WriteCSV writes the report as CSV with one row per sale, a total row
+// after each month and a grand total row
+func (r *SalesReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	fiatHeader := func(name string) string {
+		if r.Currency == "" {
+			return name
+		}
+		return fmt.Sprintf("%s (%s)", name, r.Currency)
+	}
+	header := []string{
+		"month", "completed_at", "order_id", "product", "token_id", "tx_hash",
+		"price_eth", "fee_eth", "net_eth", "fee_rate_per_mille",
+		fiatHeader("rate"), fiatHeader("price"), fiatHeader("fee"), fiatHeader("net"),
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	totalRow := func(label string, t SalesReportTotals) []string {
+		return []string{
+			label, "", "", fmt.Sprintf("%d sales", t.Sales), "", "",
+			t.Price, t.Fee, t.Net, "",
+			"", t.PriceFiat, t.FeeFiat, t.NetFiat,
+		}
+	}
+
+	for _, month := range r.Months {
+		for _, sale := range month.Sales {
+			row := []string{
+				month.Month,
+				sale.CompletedAt.UTC().Format(time.RFC3339),
+				sale.OrderID,
+				csvSafe(sale.ProductName),
+				sale.TokenID,
+				sale.TxHash,
+				sale.Price,
+				sale.Fee,
+				sale.Net,
+				strconv.FormatInt(sale.FeeRatePerMille, 10),
+				sale.FiatRate,
+				sale.PriceFiat,
+				sale.FeeFiat,
+				sale.NetFiat,
+			}
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+		if err := writer.Write(totalRow(month.Month+" total", month.Totals)); err != nil {
+			return err
+		}
+	}
+	if err := writer.Write(totalRow("total", r.Totals)); err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvSafe stops user-supplied text being read as a formula by spreadsheets
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// ReportService builds seller reports from completed orders and the ledger
+type ReportService struct {
+	db    *gorm.DB
+	rates *FiatRateTable
+}
+
+// NewReportService creates a new ReportService instance. Fiat values are
+// omitted when no rate table is configured.
+func NewReportService(db *gorm.DB) (*ReportService, error) {
+	service := &ReportService{db: db}
+	if path := config.AppConfig.FiatRatesFile; path != "" {
+		rates, err := LoadFiatRateTable(path)
+		if err != nil {
+			return nil, err
+		}
+		service.rates = rates
+	}
+
+	return service, nil
+}
+
+// GetSalesReport builds a seller's sales report for completed orders between
+// from and to
+func (s *ReportService) GetSalesReport(sellerID string, from, to time.Time) (*SalesReport, error) {
+	var sales []SaleRecord
+	err := s.db.Table("orders").
+		Select("orders.id AS order_id, orders.product_id, products.name AS product_name, "+
+			"orders.token_id, orders.tx_hash, orders.completed_at, "+
+			"ledger_journals.gross_wei::text AS gross_wei, ledger_journals.fee_wei::text AS fee_wei, "+
+			"ledger_journals.net_wei::text AS net_wei, ledger_journals.fee_rate_per_mille").
+		Joins("JOIN ledger_journals ON ledger_journals.order_id = orders.id AND ledger_journals.type = ?", models.JournalTypeSale).
+		Joins("JOIN products ON products.id = orders.product_id").
+		Where("orders.seller_id = ? AND orders.status = ?", sellerID, models.OrderStatusCompleted).
+		Where("orders.completed_at >= ? AND orders.completed_at < ?", from, to).
+		Order("orders.completed_at asc").
+		Scan(&sales).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sales: %v", err)
+	}
+
+	return BuildSalesReport(sellerID, from, to, sales, s.rates), nil
+}
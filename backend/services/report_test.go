@@ -0,0 +1,151 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yourusername/revibe/backend/test"
+)
+
+func TestFiatRateTable(t *testing.T) {
+	// Setup test environment
+	env := test.SetupTestEnv(t)
+	defer env.CleanupTestEnv()
+
+	path := env.CreateTestFile(t, "rates.json", `{
+		"currency": "usd",
+		"rates": [
+			{"date": "2024-03-02", "rate": "3500"},
+			{"date": "2024-03-01", "rate": "3400.50"}
+		]
+	}`)
+
+	rates, err := LoadFiatRateTable(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", rates.Currency)
+
+	t.Run("Same Day", func(t *testing.T) {
+		rate := rates.RateAt(time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC))
+		assert.Equal(t, "3400.50", rate.FloatString(2))
+	})
+
+	t.Run("Falls Back To Earlier Day", func(t *testing.T) {
+		rate := rates.RateAt(time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC))
+		assert.Equal(t, "3500.00", rate.FloatString(2))
+	})
+
+	t.Run("Before First Rate", func(t *testing.T) {
+		assert.Nil(t, rates.RateAt(time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("Invalid Rate", func(t *testing.T) {
+		bad := env.CreateTestFile(t, "bad.json", `{"currency": "USD", "rates": [{"date": "2024-03-01", "rate": "abc"}]}`)
+		_, err := LoadFiatRateTable(bad)
+		assert.Error(t, err)
+	})
+}
+
+func TestFormatEther(t *testing.T) {
+	wei, _ := new(big.Int).SetString("1500000000000000000", 10)
+	assert.Equal(t, "1.5", FormatEther(wei))
+	assert.Equal(t, "0", FormatEther(big.NewInt(0)))
+	assert.Equal(t, "0.000000000000000001", FormatEther(big.NewInt(1)))
+}
+
+func TestBuildSalesReport(t *testing.T) {
+	// Setup test environment
+	env := test.SetupTestEnv(t)
+	defer env.CleanupTestEnv()
+
+	path := env.CreateTestFile(t, "rates.json", `{
+		"currency": "EUR",
+		"rates": [{"date": "2024-03-01", "rate": "3000"}]
+	}`)
+	rates, err := LoadFiatRateTable(path)
+	assert.NoError(t, err)
+
+	sales := []SaleRecord{
+		{
+			OrderID:         "order-1",
+			ProductName:     "=HYPERLINK(\"x\")",
+			TxHash:          "0x01",
+			CompletedAt:     time.Date(2024, 2, 20, 12, 0, 0, 0, time.UTC),
+			GrossWei:        "1000000000000000000",
+			FeeWei:          "25000000000000000",
+			NetWei:          "975000000000000000",
+			FeeRatePerMille: 25,
+		},
+		{
+			OrderID:         "order-2",
+			ProductName:     "Sneaker",
+			TxHash:          "0x02",
+			CompletedAt:     time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC),
+			GrossWei:        "2000000000000000000",
+			FeeWei:          "50000000000000000",
+			NetWei:          "1950000000000000000",
+			FeeRatePerMille: 25,
+		},
+		{
+			OrderID:         "order-3",
+			ProductName:     "Jacket",
+			TxHash:          "0x03",
+			CompletedAt:     time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC),
+			GrossWei:        "1000000000000000000",
+			FeeWei:          "25000000000000000",
+			NetWei:          "975000000000000000",
+			FeeRatePerMille: 25,
+		},
+	}
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	report := BuildSalesReport("seller-1", from, to, sales, rates)
+
+	t.Run("Groups By Month", func(t *testing.T) {
+		assert.Len(t, report.Months, 2)
+		assert.Equal(t, "2024-02", report.Months[0].Month)
+		assert.Equal(t, 1, report.Months[0].Totals.Sales)
+		assert.Equal(t, "2024-03", report.Months[1].Month)
+		assert.Equal(t, 2, report.Months[1].Totals.Sales)
+		assert.Equal(t, "3", report.Months[1].Totals.Price)
+	})
+
+	t.Run("Fiat Values", func(t *testing.T) {
+		// No rate before March
+		assert.True(t, report.MissingRates)
+		assert.Empty(t, report.Months[0].Sales[0].PriceFiat)
+
+		sale := report.Months[1].Sales[0]
+		assert.Equal(t, "6000.00", sale.PriceFiat)
+		assert.Equal(t, "150.00", sale.FeeFiat)
+		assert.Equal(t, "5850.00", sale.NetFiat)
+		assert.Equal(t, "9000.00", report.Months[1].Totals.PriceFiat)
+	})
+
+	t.Run("Totals", func(t *testing.T) {
+		assert.Equal(t, 3, report.Totals.Sales)
+		assert.Equal(t, "4000000000000000000", report.Totals.PriceWei)
+		assert.Equal(t, "0.1", report.Totals.Fee)
+		assert.Equal(t, "3.9", report.Totals.Net)
+		assert.Equal(t, "EUR", report.Currency)
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, report.WriteCSV(&buf))
+
+		rows, err := csv.NewReader(&buf).ReadAll()
+		assert.NoError(t, err)
+		// Header, three sales, two month totals and a grand total
+		assert.Len(t, rows, 7)
+		assert.Equal(t, "price (EUR)", rows[0][11])
+		assert.Equal(t, "'=HYPERLINK(\"x\")", rows[1][3])
+		assert.Equal(t, "2024-02 total", rows[2][0])
+		assert.Equal(t, "total", rows[6][0])
+		assert.Equal(t, "4", rows[6][6])
+	})
+}
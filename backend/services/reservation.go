@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yourusername/revibe/backend/config"
+	"github.com/yourusername/revibe/backend/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrProductReserved     = errors.New("product is reserved by another buyer")
+	ErrReservationNotFound = errors.New("reservation not found")
+)
+
+// releaseReservationScript deletes a reservation only if it still holds the
+// value the caller read, so a buyer cannot cancel someone else's hold
+var releaseReservationScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Reservation is a time-limited exclusive hold on a product during checkout
+type Reservation struct {
+	ProductID        string    `json:"productId"`
+	BuyerID          string    `json:"buyerId"`
+	ExpiresAt        time.Time `json:"expiresAt"`
+	RemainingSeconds int64     `json:"remainingSeconds"`
+}
+
+// ReservationService manages checkout holds in Redis. Holds expire through
+// the key TTL, so no sweeper is needed.
+type ReservationService struct {
+	db    *gorm.DB
+	redis *redis.Client
+	ttl   time.Duration
+}
+
+// NewReservationService creates a new ReservationService instance
+func NewReservationService(db *gorm.DB, redisClient *redis.Client) *ReservationService {
+	return &ReservationService{
+		db:    db,
+		redis: redisClient,
+		ttl:   config.AppConfig.ReservationTTL,
+	}
+}
+
+func reservationKey(productID string) string {
+	return "reservation:product:" + productID
+}
+
+func decodeReservation(value string) (*Reservation, error) {
+	var reservation Reservation
+	if err := json.Unmarshal([]byte(value), &reservation); err != nil {
+		return nil, fmt.Errorf("invalid reservation: %v", err)
+	}
+
+	reservation.RemainingSeconds = int64(time.Until(reservation.ExpiresAt).Seconds())
+	if reservation.RemainingSeconds < 0 {
+		reservation.RemainingSeconds = 0
+	}
+	return &reservation, nil
+}
+
+// Reserve places a hold on a product for a buyer. Reserving a product the
+// buyer already holds returns the existing hold unchanged.
+func (s *ReservationService) Reserve(ctx context.Context, productID, buyerID string) (*Reservation, error) {
+	var product models.Product
+	if err := s.db.First(&product, "id = ?", productID).Error; err != nil {
+		return nil, err
+	}
+	if product.Status != models.ProductStatusActive || product.ModerationStatus != models.ModerationStatusApproved {
+		return nil, ErrProductNotAvailable
+	}
+	if product.TokenID == nil {
+		return nil, ErrProductNotMinted
+	}
+	if product.SellerID == buyerID {
+		return nil, ErrOwnProduct
+	}
+	required, err := ReauthenticationRequired(s.db, product.ID)
+	if err != nil {
+		return nil, err
+	}
+	if required {
+		return nil, ErrReauthenticationRequired
+	}
+
+	reservation := Reservation{
+		ProductID: productID,
+		BuyerID:   buyerID,
+		ExpiresAt: time.Now().Add(s.ttl).UTC(),
+	}
+	value, err := json.Marshal(reservation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode reservation: %v", err)
+	}
+
+	ok, err := s.redis.SetNX(ctx, reservationKey(productID), value, s.ttl).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve product: %v", err)
+	}
+	if !ok {
+		existing, err := s.Get(ctx, productID)
+		if err != nil {
+			return nil, err
+		}
+		if existing.BuyerID != buyerID {
+			return nil, ErrProductReserved
+		}
+		return existing, nil
+	}
+
+	reservation.RemainingSeconds = int64(s.ttl.Seconds())
+	return &reservation, nil
+}
+
+// Get returns the active hold on a product
+func (s *ReservationService) Get(ctx context.Context, productID string) (*Reservation, error) {
+	value, err := s.redis.Get(ctx, reservationKey(productID)).Result()
+	if err == redis.Nil {
+		return nil, ErrReservationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reservation: %v", err)
+	}
+
+	return decodeReservation(value)
+}
+
+// GetMany returns the active holds for a set of products, keyed by product ID
+func (s *ReservationService) GetMany(ctx context.Context, productIDs []string) (map[string]*Reservation, error) {
+	reservations := make(map[string]*Reservation)
+	if len(productIDs) == 0 {
+		return reservations, nil
+	}
+
+	keys := make([]string, len(productIDs))
+	for i, id := range productIDs {
+		keys[i] = reservationKey(id)
+	}
+
+	values, err := s.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reservations: %v", err)
+	}
+
+	for i, value := range values {
+		str, ok := value.(string)
+		if !ok {
+			continue
+		}
+		if reservation, err := decodeReservation(str); err == nil {
+			reservations[productIDs[i]] = reservation
+		}
+	}
+
+	return reservations, nil
+}
+
+// CheckAvailable returns ErrProductReserved if another buyer holds the product
+func (s *ReservationService) CheckAvailable(ctx context.Context, productID, buyerID string) error {
+	reservation, err := s.Get(ctx, productID)
+	if err == ErrReservationNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if reservation.BuyerID != buyerID {
+		return ErrProductReserved
+	}
+	return nil
+}
+
+// Cancel releases a buyer's own hold on a product
+func (s *ReservationService) Cancel(ctx context.Context, productID, buyerID string) error {
+	key := reservationKey(productID)
+	value, err := s.redis.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return ErrReservationNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch reservation: %v", err)
+	}
+
+	reservation, err := decodeReservation(value)
+	if err != nil {
+		return err
+	}
+	if reservation.BuyerID != buyerID {
+		return ErrReservationNotFound
+	}
+
+	if err := releaseReservationScript.Run(ctx, s.redis, []string{key}, value).Err(); err != nil {
+		return fmt.Errorf("failed to cancel reservation: %v", err)
+	}
+	return nil
+}
+
+// Release removes any hold on a product, e.g. once it has been sold
+func (s *ReservationService) Release(ctx context.Context, productID string) error {
+	if err := s.redis.Del(ctx, reservationKey(productID)).Err(); err != nil {
+		return fmt.Errorf("failed to release reservation: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/models"
+)
+
+func TestReserve(t *testing.T) {
+	expired := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name    string
+		setup   func(f *chainFixture, product *models.Product) string
+		wantErr error
+	}{
+		{
+			name: "available product",
+			setup: func(f *chainFixture, product *models.Product) string {
+				return f.buyer.ID
+			},
+		},
+		{
+			name: "sold product",
+			setup: func(f *chainFixture, product *models.Product) string {
+				require.NoError(t, f.db.Model(product).Update("status", models.ProductStatusSold).Error)
+				return f.buyer.ID
+			},
+			wantErr: ErrProductNotAvailable,
+		},
+		{
+			name: "rejected product",
+			setup: func(f *chainFixture, product *models.Product) string {
+				require.NoError(t, f.db.Model(product).Update("moderation_status", models.ModerationStatusRejected).Error)
+				return f.buyer.ID
+			},
+			wantErr: ErrProductNotAvailable,
+		},
+		{
+			name: "unminted product",
+			setup: func(f *chainFixture, product *models.Product) string {
+				require.NoError(t, f.db.Model(product).Update("token_id", nil).Error)
+				return f.buyer.ID
+			},
+			wantErr: ErrProductNotMinted,
+		},
+		{
+			name: "own product",
+			setup: func(f *chainFixture, product *models.Product) string {
+				return f.seller.ID
+			},
+			wantErr: ErrOwnProduct,
+		},
+		{
+			name: "authentication expired",
+			setup: func(f *chainFixture, product *models.Product) string {
+				require.NoError(t, f.db.Create(&models.Authentication{
+					ProductID: product.ID, Result: true, Verdict: models.AuthVerdictPass, ExpiresAt: &expired,
+				}).Error)
+				return f.buyer.ID
+			},
+			wantErr: ErrReauthenticationRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newChainFixture(t)
+			product := f.mintedProduct("1")
+			buyerID := tt.setup(f, product)
+
+			reservation, err := f.reservations.Reserve(context.Background(), product.ID, buyerID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.False(t, f.redis.Exists(reservationKey(product.ID)))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, buyerID, reservation.BuyerID)
+			assert.Equal(t, int64(f.reservations.ttl.Seconds()), reservation.RemainingSeconds)
+			assert.Equal(t, f.reservations.ttl, f.redis.TTL(reservationKey(product.ID)))
+		})
+	}
+}
+
+func TestReservationHold(t *testing.T) {
+	ctx := context.Background()
+	f := newChainFixture(t)
+	product := f.mintedProduct("1")
+	other := createUser(t, f.db, common.HexToAddress("0xca7").Hex())
+
+	held, err := f.reservations.Reserve(ctx, product.ID, f.buyer.ID)
+	require.NoError(t, err)
+
+	// The holder reserving again keeps the hold it has; anyone else is
+	// turned away until it lapses
+	f.redis.FastForward(5 * time.Minute)
+	again, err := f.reservations.Reserve(ctx, product.ID, f.buyer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, held.ExpiresAt, again.ExpiresAt)
+	assert.Equal(t, 10*time.Minute, f.redis.TTL(reservationKey(product.ID)))
+
+	_, err = f.reservations.Reserve(ctx, product.ID, other.ID)
+	assert.ErrorIs(t, err, ErrProductReserved)
+	assert.ErrorIs(t, f.reservations.CheckAvailable(ctx, product.ID, other.ID), ErrProductReserved)
+	assert.NoError(t, f.reservations.CheckAvailable(ctx, product.ID, f.buyer.ID))
+	assert.ErrorIs(t, f.reservations.Cancel(ctx, product.ID, other.ID), ErrReservationNotFound)
+	holds, err := f.reservations.GetMany(ctx, []string{product.ID})
+	require.NoError(t, err)
+	assert.Contains(t, holds, product.ID)
+
+	// Once the hold lapses the product is free for anyone
+	f.redis.FastForward(10 * time.Minute)
+	_, err = f.reservations.Get(ctx, product.ID)
+	assert.ErrorIs(t, err, ErrReservationNotFound)
+	assert.NoError(t, f.reservations.CheckAvailable(ctx, product.ID, other.ID))
+	holds, err = f.reservations.GetMany(ctx, []string{product.ID})
+	require.NoError(t, err)
+	assert.Empty(t, holds)
+	assert.ErrorIs(t, f.reservations.Cancel(ctx, product.ID, f.buyer.ID), ErrReservationNotFound)
+
+	taken, err := f.reservations.Reserve(ctx, product.ID, other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, other.ID, taken.BuyerID)
+}
+
+func TestReservationCancelAndRelease(t *testing.T) {
+	ctx := context.Background()
+	f := newChainFixture(t)
+	product := f.mintedProduct("1")
+
+	_, err := f.reservations.Reserve(ctx, product.ID, f.buyer.ID)
+	require.NoError(t, err)
+	require.NoError(t, f.reservations.Cancel(ctx, product.ID, f.buyer.ID))
+	_, err = f.reservations.Get(ctx, product.ID)
+	assert.ErrorIs(t, err, ErrReservationNotFound)
+
+	_, err = f.reservations.Reserve(ctx, product.ID, f.buyer.ID)
+	require.NoError(t, err)
+	require.NoError(t, f.reservations.Release(ctx, product.ID))
+	_, err = f.reservations.Get(ctx, product.ID)
+	assert.ErrorIs(t, err, ErrReservationNotFound)
+
+	// Releasing a product with no hold is not an error
+	assert.NoError(t, f.reservations.Release(ctx, product.ID))
+}
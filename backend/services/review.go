@@ -0,0 +1,523 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/revibe/backend/config"
+	"github.com/yourusername/revibe/backend/models"
+	"github.com/yourusername/revibe/backend/utils"
+	"gorm.io/gorm"
+)
+
+const reviewMonitorInterval = 15 * time.Minute
+
+var (
+	ErrReviewRequestExists = errors.New("product already has an open authentication request")
+	ErrRequestNotClaimable = errors.New("authentication request is not available to claim")
+	ErrNotAssignedReviewer = errors.New("authentication request is not assigned to you")
+	ErrAlreadyReviewed     = errors.New("a second opinion must come from a different reviewer")
+	ErrNotReviewer         = errors.New("user is not an authenticator")
+	ErrInvalidReview       = errors.New("score must be 0-100 and verdict pass or fail")
+	ErrRequestClosed       = errors.New("authentication request is already closed")
+	ErrOwnProductReview    = errors.New("reviewers cannot authenticate their own products")
+)
+
+// openRequestStatuses are the statuses of requests still awaiting a verdict
+var openRequestStatuses = []string{
+	models.AuthRequestStatusQueued,
+	models.AuthRequestStatusInReview,
+	models.AuthRequestStatusAwaitingSecondOpinion,
+}
+
+// ReviewDetails is stored as JSON in models.Authentication.Details for
+// verdicts reached by expert review
+type ReviewDetails struct {
+	Verdict   string                        `json:"verdict"`
+	RequestID string                        `json:"requestId"`
+	Reviews   []models.AuthenticationReview `json:"reviews"`
+}
+
+// ReviewSubmission is a reviewer's findings on a product. Photos are upload
+// URLs and become part of the authentication's evidence bundle.
+type ReviewSubmission struct {
+	Findings     string
+	Score        float64
+	Verdict      string
+	Photos       []string
+	Measurements []models.Measurement
+}
+
+// ReviewService runs the expert authentication queue. Final verdicts are
+// stored as authentications and set on chain through the operator queue.
+type ReviewService struct {
+	db                 *gorm.DB
+	operatorService    *OperatorService
+	certificateService *CertificateService
+	evidenceService    *EvidenceService
+	expiryService      *AuthExpiryService
+	sla                time.Duration
+	secondOpinionPrice float64
+}
+
+// NewReviewService creates a new ReviewService instance
+func NewReviewService(db *gorm.DB, operatorService *OperatorService, certificateService *CertificateService, evidenceService *EvidenceService, expiryService *AuthExpiryService) *ReviewService {
+	return &ReviewService{
+		db:                 db,
+		operatorService:    operatorService,
+		certificateService: certificateService,
+		evidenceService:    evidenceService,
+		expiryService:      expiryService,
+		sla:                config.AppConfig.AuthReviewSLA,
+		secondOpinionPrice: config.AppConfig.SecondOpinionPrice,
+	}
+}
+
+// RequestReview queues a listed product for expert authentication on
+// behalf of its seller. Products priced at or above the second-opinion
+// threshold need two independent reviews.
+func (s *ReviewService) RequestReview(productID, sellerID string) (*models.AuthenticationRequest, error) {
+	var request models.AuthenticationRequest
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var product models.Product
+		if err := tx.First(&product, "id = ?", productID).Error; err != nil {
+			return err
+		}
+		if product.SellerID != sellerID {
+			return ErrNotProductSeller
+		}
+		if product.TokenID == nil {
+			return ErrProductNotMinted
+		}
+
+		var open int64
+		if err := tx.Model(&models.AuthenticationRequest{}).
+			Where("product_id = ? AND status IN ?", product.ID, openRequestStatuses).
+			Count(&open).Error; err != nil {
+			return err
+		}
+		if open > 0 {
+			return ErrReviewRequestExists
+		}
+
+		request = models.AuthenticationRequest{
+			ProductID:             product.ID,
+			SellerID:              sellerID,
+			Category:              product.Category,
+			Status:                models.AuthRequestStatusQueued,
+			RequiresSecondOpinion: product.Price >= s.secondOpinionPrice,
+			DueAt:                 time.Now().Add(s.sla),
+		}
+		if err := tx.Omit("Product").Create(&request).Error; err != nil {
+			return fmt.Errorf("failed to create authentication request: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+// GetRequest retrieves an authentication request with its reviews
+func (s *ReviewService) GetRequest(requestID string) (*models.AuthenticationRequest, error) {
+	var request models.AuthenticationRequest
+	err := s.db.Preload("Product.Images").
+		Preload("OnChainTx").
+		Preload("Reviews", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at asc")
+		}).
+		First(&request, "id = ?", requestID).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+// ListQueue returns the requests a reviewer can work on: unclaimed requests
+// for other sellers' products they have not already reviewed and requests
+// assigned to them, most urgent first
+func (s *ReviewService) ListQueue(reviewerID, category string, page, limit int) ([]models.AuthenticationRequest, int64, error) {
+	query := s.db.Model(&models.AuthenticationRequest{}).
+		Where("(assignee_id IS NULL AND status IN ? AND seller_id <> ? AND NOT EXISTS ("+
+			"SELECT 1 FROM authentication_reviews WHERE authentication_reviews.request_id = authentication_requests.id "+
+			"AND authentication_reviews.reviewer_id = ?)) OR (assignee_id = ? AND status = ?)",
+			[]string{models.AuthRequestStatusQueued, models.AuthRequestStatusAwaitingSecondOpinion},
+			reviewerID, reviewerID, reviewerID, models.AuthRequestStatusInReview)
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+
+	return s.page(query, "due_at asc", page, limit)
+}
+
+// ListRequests returns a page of requests for staff, optionally filtered by
+// status and to overdue requests only
+func (s *ReviewService) ListRequests(status string, overdueOnly bool, page, limit int) ([]models.AuthenticationRequest, int64, error) {
+	query := s.db.Model(&models.AuthenticationRequest{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if overdueOnly {
+		query = query.Where("overdue = ?", true)
+	}
+
+	return s.page(query, "due_at asc", page, limit)
+}
+
+func (s *ReviewService) page(query *gorm.DB, order string, page, limit int) ([]models.AuthenticationRequest, int64, error) {
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count authentication requests: %v", err)
+	}
+
+	var requests []models.AuthenticationRequest
+	err := query.Preload("Product").
+		Order(order).
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&requests).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch authentication requests: %v", err)
+	}
+
+	return requests, total, nil
+}
+
+func hasReviewed(tx *gorm.DB, requestID, reviewerID string) (bool, error) {
+	var count int64
+	err := tx.Model(&models.AuthenticationReview{}).
+		Where("request_id = ? AND reviewer_id = ?", requestID, reviewerID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Claim assigns an unclaimed request to a reviewer
+func (s *ReviewService) Claim(requestID, reviewerID string) (*models.AuthenticationRequest, error) {
+	var request models.AuthenticationRequest
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&request, "id = ?", requestID).Error; err != nil {
+			return err
+		}
+		if request.SellerID == reviewerID {
+			return ErrOwnProductReview
+		}
+
+		reviewed, err := hasReviewed(tx, request.ID, reviewerID)
+		if err != nil {
+			return err
+		}
+		if reviewed {
+			return ErrAlreadyReviewed
+		}
+
+		// Only one reviewer can win a claim
+		now := time.Now()
+		result := tx.Model(&models.AuthenticationRequest{}).
+			Where("id = ? AND assignee_id IS NULL AND status IN ?", request.ID,
+				[]string{models.AuthRequestStatusQueued, models.AuthRequestStatusAwaitingSecondOpinion}).
+			Updates(map[string]interface{}{
+				"assignee_id": reviewerID,
+				"claimed_at":  now,
+				"status":      models.AuthRequestStatusInReview,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to claim authentication request: %v", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrRequestNotClaimable
+		}
+
+		request.AssigneeID = &reviewerID
+		request.ClaimedAt = &now
+		request.Status = models.AuthRequestStatusInReview
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+// unassignedStatus is the status a request returns to when its reviewer is removed
+func unassignedStatus(tx *gorm.DB, requestID string) (string, error) {
+	var reviews int64
+	if err := tx.Model(&models.AuthenticationReview{}).Where("request_id = ?", requestID).Count(&reviews).Error; err != nil {
+		return "", err
+	}
+	if reviews > 0 {
+		return models.AuthRequestStatusAwaitingSecondOpinion, nil
+	}
+	return models.AuthRequestStatusQueued, nil
+}
+
+// Release returns a claimed request to the queue
+func (s *ReviewService) Release(requestID, reviewerID string) (*models.AuthenticationRequest, error) {
+	var request models.AuthenticationRequest
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&request, "id = ?", requestID).Error; err != nil {
+			return err
+		}
+		if request.Status != models.AuthRequestStatusInReview || request.AssigneeID == nil || *request.AssigneeID != reviewerID {
+			return ErrNotAssignedReviewer
+		}
+
+		status, err := unassignedStatus(tx, request.ID)
+		if err != nil {
+			return err
+		}
+		request.Status = status
+		request.AssigneeID = nil
+		request.ClaimedAt = nil
+		return tx.Model(&request).Select("status", "assignee_id", "claimed_at").Updates(&request).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+// Reassign hands an open request to another reviewer on behalf of staff. An
+// empty reviewerID returns it to the queue.
+func (s *ReviewService) Reassign(requestID, reviewerID string) (*models.AuthenticationRequest, error) {
+	var request models.AuthenticationRequest
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&request, "id = ?", requestID).Error; err != nil {
+			return err
+		}
+		if request.Status == models.AuthRequestStatusCompleted || request.Status == models.AuthRequestStatusCancelled {
+			return ErrRequestClosed
+		}
+
+		if reviewerID == "" {
+			status, err := unassignedStatus(tx, request.ID)
+			if err != nil {
+				return err
+			}
+			request.Status = status
+			request.AssigneeID = nil
+			request.ClaimedAt = nil
+		} else {
+			var reviewer models.User
+			if err := tx.First(&reviewer, "id = ?", reviewerID).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return ErrNotReviewer
+				}
+				return err
+			}
+			if reviewer.Role != models.RoleAuthenticator && reviewer.Role != models.RoleAdmin {
+				return ErrNotReviewer
+			}
+			if request.SellerID == reviewerID {
+				return ErrOwnProductReview
+			}
+
+			reviewed, err := hasReviewed(tx, request.ID, reviewerID)
+			if err != nil {
+				return err
+			}
+			if reviewed {
+				return ErrAlreadyReviewed
+			}
+
+			now := time.Now()
+			request.Status = models.AuthRequestStatusInReview
+			request.AssigneeID = &reviewerID
+			request.ClaimedAt = &now
+		}
+
+		return tx.Model(&request).Select("status", "assignee_id", "claimed_at").Updates(&request).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+// SubmitReview records the assigned reviewer's findings. The request then
+// waits for a second opinion if it needs one, or is finalised.
+func (s *ReviewService) SubmitReview(requestID, reviewerID string, submission ReviewSubmission) (*models.AuthenticationRequest, error) {
+	if submission.Score < 0 || submission.Score > 100 ||
+		(submission.Verdict != models.AuthVerdictPass && submission.Verdict != models.AuthVerdictFail) {
+		return nil, ErrInvalidReview
+	}
+	for _, measurement := range submission.Measurements {
+		if measurement.Name == "" {
+			return nil, ErrInvalidReview
+		}
+	}
+	if err := s.evidenceService.CheckPhotos(submission.Photos); err != nil {
+		return nil, err
+	}
+
+	var request models.AuthenticationRequest
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Preload("Product.Images").First(&request, "id = ?", requestID).Error; err != nil {
+			return err
+		}
+		if request.Status != models.AuthRequestStatusInReview || request.AssigneeID == nil || *request.AssigneeID != reviewerID {
+			return ErrNotAssignedReviewer
+		}
+
+		review := models.AuthenticationReview{
+			RequestID:    request.ID,
+			ReviewerID:   reviewerID,
+			Findings:     submission.Findings,
+			Score:        submission.Score,
+			Verdict:      submission.Verdict,
+			Photos:       submission.Photos,
+			Measurements: submission.Measurements,
+		}
+		if err := tx.Create(&review).Error; err != nil {
+			return fmt.Errorf("failed to save review: %v", err)
+		}
+
+		if err := tx.Where("request_id = ?", request.ID).Order("created_at asc").Find(&request.Reviews).Error; err != nil {
+			return err
+		}
+
+		if request.RequiresSecondOpinion && len(request.Reviews) < 2 {
+			request.Status = models.AuthRequestStatusAwaitingSecondOpinion
+			request.AssigneeID = nil
+			request.ClaimedAt = nil
+			return tx.Model(&request).Select("status", "assignee_id", "claimed_at").Updates(&request).Error
+		}
+
+		return s.finalize(tx, &request)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+// CombineReviews returns the mean score of the reviews and their verdict.
+// Every reviewer must pass a product for it to pass.
+func CombineReviews(reviews []models.AuthenticationReview) (float64, string) {
+	if len(reviews) == 0 {
+		return 0, models.AuthVerdictInconclusive
+	}
+
+	verdict := models.AuthVerdictPass
+	var total float64
+	for _, review := range reviews {
+		total += review.Score
+		if review.Verdict != models.AuthVerdictPass {
+			verdict = models.AuthVerdictFail
+		}
+	}
+	return total / float64(len(reviews)), verdict
+}
+
+// finalize records the combined review verdict as an authentication with an
+// evidence bundle of the product's photos and every review, updates the
+// product's certificates and queues the on-chain update
+func (s *ReviewService) finalize(tx *gorm.DB, request *models.AuthenticationRequest) error {
+	score, verdict := CombineReviews(request.Reviews)
+
+	details, err := json.Marshal(ReviewDetails{
+		Verdict:   verdict,
+		RequestID: request.ID,
+		Reviews:   request.Reviews,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode review details: %v", err)
+	}
+
+	evidence := ProductPhotoEvidence(&request.Product)
+	for _, review := range request.Reviews {
+		inputs, err := ReviewEvidence(review)
+		if err != nil {
+			return err
+		}
+		evidence = append(evidence, inputs...)
+	}
+	bundle, err := s.evidenceService.BuildBundle(request.ProductID, evidence)
+	if err != nil {
+		return err
+	}
+
+	auth := models.Authentication{
+		ProductID: request.ProductID,
+		Result:    verdict == models.AuthVerdictPass,
+		Verdict:   verdict,
+		Method:    models.AuthMethodReview,
+		Score:     score,
+		Details:   string(details),
+	}
+	s.expiryService.SetExpiry(&auth, request.Category, time.Now())
+	if err := tx.Omit("Product").Create(&auth).Error; err != nil {
+		return fmt.Errorf("failed to save authentication: %v", err)
+	}
+	if err := s.evidenceService.SaveBundle(tx, &auth, bundle); err != nil {
+		return err
+	}
+	if err := s.certificateService.RecordAuthentication(tx, &auth); err != nil {
+		return err
+	}
+
+	op := models.OperatorTransaction{
+		Kind:            models.OperatorTxKindAuthenticate,
+		Reference:       request.ID,
+		ChainID:         request.Product.ChainID,
+		ContractAddress: request.Product.ContractAddress,
+		TokenID:         *request.Product.TokenID,
+		Authenticated:   verdict == models.AuthVerdictPass,
+	}
+	if err := s.operatorService.Enqueue(tx, &op); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	request.Status = models.AuthRequestStatusCompleted
+	request.Verdict = verdict
+	request.Score = score
+	request.AuthenticationID = &auth.ID
+	request.OnChainTxID = &op.ID
+	request.AssigneeID = nil
+	request.CompletedAt = &now
+	return tx.Model(request).
+		Select("status", "verdict", "score", "authentication_id", "on_chain_tx_id", "assignee_id", "completed_at").
+		Updates(request).Error
+}
+
+// StartReviewMonitor flags requests that miss their SLA until ctx is cancelled
+func (s *ReviewService) StartReviewMonitor(ctx context.Context) {
+	ticker := time.NewTicker(reviewMonitorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.FlagOverdueRequests(); err != nil {
+				utils.LogError(err, map[string]interface{}{
+					"component": "review_monitor",
+				})
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// FlagOverdueRequests marks open requests past their due time and returns
+// how many were flagged
+func (s *ReviewService) FlagOverdueRequests() (int64, error) {
+	result := s.db.Model(&models.AuthenticationRequest{}).
+		Where("status IN ? AND overdue = ? AND due_at < ?", openRequestStatuses, false, time.Now()).
+		Update("overdue", true)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to flag overdue requests: %v", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
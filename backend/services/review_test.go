@@ -0,0 +1,431 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/models"
+	"gorm.io/gorm"
+)
+
+func TestCombineReviews(t *testing.T) {
+	t.Run("Single Pass", func(t *testing.T) {
+		score, verdict := CombineReviews([]models.AuthenticationReview{
+			{Score: 92, Verdict: models.AuthVerdictPass},
+		})
+		assert.Equal(t, 92.0, score)
+		assert.Equal(t, models.AuthVerdictPass, verdict)
+	})
+
+	t.Run("Second Opinion Disagrees", func(t *testing.T) {
+		score, verdict := CombineReviews([]models.AuthenticationReview{
+			{Score: 90, Verdict: models.AuthVerdictPass},
+			{Score: 30, Verdict: models.AuthVerdictFail},
+		})
+		assert.Equal(t, 60.0, score)
+		assert.Equal(t, models.AuthVerdictFail, verdict)
+	})
+
+	t.Run("No Reviews", func(t *testing.T) {
+		_, verdict := CombineReviews(nil)
+		assert.Equal(t, models.AuthVerdictInconclusive, verdict)
+	})
+}
+
+// reviewTest is a review service over a test market with two authenticators
+// and a minted product with a photo. Products priced at 1 ETH or more need a
+// second opinion.
+type reviewTest struct {
+	*chainFixture
+	reviews   *ReviewService
+	uploadDir string
+	product   *models.Product
+	alice     *models.User
+	bob       *models.User
+}
+
+func newReviewTest(t *testing.T) *reviewTest {
+	f := newChainFixture(t)
+	uploadDir := t.TempDir()
+	certificates := newTestCertificateService(t, testSigningSeed)
+	certificates.db = f.db
+	r := &reviewTest{
+		chainFixture: f,
+		reviews: &ReviewService{
+			db:                 f.db,
+			operatorService:    &OperatorService{db: f.db},
+			certificateService: certificates,
+			evidenceService:    &EvidenceService{db: f.db, uploadDir: uploadDir},
+			expiryService:      &AuthExpiryService{db: f.db, certificateService: certificates, validity: 365 * 24 * time.Hour},
+			sla:                48 * time.Hour,
+			secondOpinionPrice: 1,
+		},
+		uploadDir: uploadDir,
+		product:   f.mintedProduct("1"),
+		alice:     createAuthenticator(t, f.db, "0xa11ce"),
+		bob:       createAuthenticator(t, f.db, "0xb0b5"),
+	}
+	r.upload("products/front.jpg")
+	require.NoError(t, f.db.Create(&models.ProductImage{ProductID: r.product.ID, URL: "products/front.jpg"}).Error)
+	return r
+}
+
+// createAuthenticator creates a user with the authenticator role
+func createAuthenticator(t *testing.T, db *gorm.DB, wallet string) *models.User {
+	user := createUser(t, db, common.HexToAddress(wallet).Hex())
+	require.NoError(t, db.Model(user).Update("role", models.RoleAuthenticator).Error)
+	return user
+}
+
+// upload stores a file in the upload dir and returns its URL
+func (r *reviewTest) upload(path string) string {
+	full := filepath.Join(r.uploadDir, path)
+	require.NoError(r.t, os.MkdirAll(filepath.Dir(full), 0755))
+	require.NoError(r.t, os.WriteFile(full, []byte("photo of "+path), 0644))
+	return "http://localhost:8080/uploads/" + path
+}
+
+// request queues the product for review, needing a second opinion if asked
+func (r *reviewTest) request(secondOpinion bool) *models.AuthenticationRequest {
+	if secondOpinion {
+		require.NoError(r.t, r.db.Model(r.product).Update("price", 2.5).Error)
+	}
+	request, err := r.reviews.RequestReview(r.product.ID, r.seller.ID)
+	require.NoError(r.t, err)
+	assert.Equal(r.t, secondOpinion, request.RequiresSecondOpinion)
+	return request
+}
+
+func (r *reviewTest) claim(request *models.AuthenticationRequest, reviewer *models.User) {
+	_, err := r.reviews.Claim(request.ID, reviewer.ID)
+	require.NoError(r.t, err)
+}
+
+func (r *reviewTest) submit(request *models.AuthenticationRequest, reviewer *models.User, score float64, verdict string) *models.AuthenticationRequest {
+	updated, err := r.reviews.SubmitReview(request.ID, reviewer.ID, ReviewSubmission{
+		Findings:     "Checked by " + reviewer.Name,
+		Score:        score,
+		Verdict:      verdict,
+		Photos:       []string{r.upload("reviews/" + reviewer.ID + ".jpg")},
+		Measurements: []models.Measurement{{Name: "insole", Value: 27.5, Unit: "cm"}},
+	})
+	require.NoError(r.t, err)
+	return updated
+}
+
+func (r *reviewTest) stored(request *models.AuthenticationRequest) *models.AuthenticationRequest {
+	var stored models.AuthenticationRequest
+	r.reload(&stored, request.ID)
+	return &stored
+}
+
+func TestRequestReview(t *testing.T) {
+	r := newReviewTest(t)
+
+	_, err := r.reviews.RequestReview(r.product.ID, r.buyer.ID)
+	assert.ErrorIs(t, err, ErrNotProductSeller)
+	_, err = r.reviews.RequestReview(createProduct(t, r.db, r.seller).ID, r.seller.ID)
+	assert.ErrorIs(t, err, ErrProductNotMinted)
+
+	request := r.request(false)
+	assert.Equal(t, models.AuthRequestStatusQueued, request.Status)
+	assert.Equal(t, r.product.Category, request.Category)
+	assert.WithinDuration(t, time.Now().Add(48*time.Hour), request.DueAt, time.Minute)
+
+	_, err = r.reviews.RequestReview(r.product.ID, r.seller.ID)
+	assert.ErrorIs(t, err, ErrReviewRequestExists)
+}
+
+func TestClaim(t *testing.T) {
+	t.Run("one reviewer wins", func(t *testing.T) {
+		r := newReviewTest(t)
+		request := r.request(false)
+
+		claimed, err := r.reviews.Claim(request.ID, r.alice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.AuthRequestStatusInReview, claimed.Status)
+		assert.Equal(t, r.alice.ID, *claimed.AssigneeID)
+		assert.NotNil(t, claimed.ClaimedAt)
+
+		_, err = r.reviews.Claim(request.ID, r.bob.ID)
+		assert.ErrorIs(t, err, ErrRequestNotClaimable)
+		assert.Equal(t, r.alice.ID, *r.stored(request).AssigneeID)
+	})
+
+	t.Run("claimed while reading", func(t *testing.T) {
+		r := newReviewTest(t)
+		request := r.request(false)
+
+		// Bob's claim lands after Alice's has read the request as unclaimed
+		// but before it updates it
+		raced := false
+		require.NoError(t, r.db.Callback().Update().Before("gorm:update").Register("test:rival_claim", func(tx *gorm.DB) {
+			if raced || tx.Statement.Table != "authentication_requests" {
+				return
+			}
+			raced = true
+			tx.Session(&gorm.Session{NewDB: true}).Exec(
+				"UPDATE authentication_requests SET assignee_id = ?, status = ? WHERE id = ?",
+				r.bob.ID, models.AuthRequestStatusInReview, request.ID)
+		}))
+
+		_, err := r.reviews.Claim(request.ID, r.alice.ID)
+		assert.ErrorIs(t, err, ErrRequestNotClaimable)
+		assert.True(t, raced)
+		// Alice did not take the request. Bob's simulated claim ran in her
+		// transaction, so it was rolled back with it.
+		assert.Nil(t, r.stored(request).AssigneeID)
+	})
+
+	t.Run("own product", func(t *testing.T) {
+		r := newReviewTest(t)
+		request := r.request(false)
+		require.NoError(t, r.db.Model(r.seller).Update("role", models.RoleAuthenticator).Error)
+
+		queue, total, err := r.reviews.ListQueue(r.seller.ID, "", 1, 10)
+		require.NoError(t, err)
+		assert.Zero(t, total)
+		assert.Empty(t, queue)
+
+		_, err = r.reviews.Claim(request.ID, r.seller.ID)
+		assert.ErrorIs(t, err, ErrOwnProductReview)
+		assert.Equal(t, models.AuthRequestStatusQueued, r.stored(request).Status)
+
+		_, err = r.reviews.Reassign(request.ID, r.seller.ID)
+		assert.ErrorIs(t, err, ErrOwnProductReview)
+	})
+
+	t.Run("unknown request", func(t *testing.T) {
+		r := newReviewTest(t)
+		_, err := r.reviews.Claim("00000000-0000-0000-0000-000000000000", r.alice.ID)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
+
+func TestRelease(t *testing.T) {
+	r := newReviewTest(t)
+	request := r.request(false)
+
+	_, err := r.reviews.Release(request.ID, r.alice.ID)
+	assert.ErrorIs(t, err, ErrNotAssignedReviewer)
+
+	r.claim(request, r.alice)
+	_, err = r.reviews.Release(request.ID, r.bob.ID)
+	assert.ErrorIs(t, err, ErrNotAssignedReviewer)
+
+	released, err := r.reviews.Release(request.ID, r.alice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.AuthRequestStatusQueued, released.Status)
+
+	stored := r.stored(request)
+	assert.Equal(t, models.AuthRequestStatusQueued, stored.Status)
+	assert.Nil(t, stored.AssigneeID)
+	assert.Nil(t, stored.ClaimedAt)
+
+	// Back in the queue for anyone
+	r.claim(request, r.bob)
+}
+
+func TestReassign(t *testing.T) {
+	r := newReviewTest(t)
+	request := r.request(true)
+	r.claim(request, r.alice)
+
+	_, err := r.reviews.Reassign(request.ID, r.buyer.ID)
+	assert.ErrorIs(t, err, ErrNotReviewer)
+	_, err = r.reviews.Reassign(request.ID, "00000000-0000-0000-0000-000000000000")
+	assert.ErrorIs(t, err, ErrNotReviewer)
+
+	reassigned, err := r.reviews.Reassign(request.ID, r.bob.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.AuthRequestStatusInReview, reassigned.Status)
+	assert.Equal(t, r.bob.ID, *r.stored(request).AssigneeID)
+
+	// After Bob's review the request waits for a second opinion, which Bob
+	// cannot give
+	r.submit(request, r.bob, 90, models.AuthVerdictPass)
+	_, err = r.reviews.Reassign(request.ID, r.bob.ID)
+	assert.ErrorIs(t, err, ErrAlreadyReviewed)
+
+	reassigned, err = r.reviews.Reassign(request.ID, "")
+	require.NoError(t, err)
+	assert.Equal(t, models.AuthRequestStatusAwaitingSecondOpinion, reassigned.Status)
+	assert.Nil(t, r.stored(request).AssigneeID)
+
+	r.claim(request, r.alice)
+	r.submit(request, r.alice, 80, models.AuthVerdictPass)
+	_, err = r.reviews.Reassign(request.ID, r.bob.ID)
+	assert.ErrorIs(t, err, ErrRequestClosed)
+}
+
+func TestSubmitReview(t *testing.T) {
+	r := newReviewTest(t)
+	request := r.request(false)
+	r.claim(request, r.alice)
+
+	invalid := []ReviewSubmission{
+		{Score: 101, Verdict: models.AuthVerdictPass},
+		{Score: -1, Verdict: models.AuthVerdictPass},
+		{Score: 50, Verdict: models.AuthVerdictInconclusive},
+		{Score: 50, Verdict: models.AuthVerdictPass, Measurements: []models.Measurement{{Value: 1}}},
+	}
+	for _, submission := range invalid {
+		_, err := r.reviews.SubmitReview(request.ID, r.alice.ID, submission)
+		assert.ErrorIs(t, err, ErrInvalidReview)
+	}
+
+	_, err := r.reviews.SubmitReview(request.ID, r.alice.ID, ReviewSubmission{
+		Score:   90,
+		Verdict: models.AuthVerdictPass,
+		Photos:  []string{"http://localhost:8080/uploads/reviews/missing.jpg"},
+	})
+	assert.ErrorIs(t, err, ErrEvidenceFileMissing)
+
+	_, err = r.reviews.SubmitReview(request.ID, r.bob.ID, ReviewSubmission{Score: 90, Verdict: models.AuthVerdictPass})
+	assert.ErrorIs(t, err, ErrNotAssignedReviewer)
+	assert.Zero(t, r.count(&models.AuthenticationReview{}, "request_id = ?", request.ID))
+}
+
+func TestFinalizeReview(t *testing.T) {
+	r := newReviewTest(t)
+	request := r.request(false)
+	r.claim(request, r.alice)
+
+	completed := r.submit(request, r.alice, 92, models.AuthVerdictPass)
+	assert.Equal(t, models.AuthRequestStatusCompleted, completed.Status)
+	assert.Equal(t, models.AuthVerdictPass, completed.Verdict)
+	assert.Equal(t, 92.0, completed.Score)
+	assert.NotNil(t, completed.CompletedAt)
+	assert.Nil(t, r.stored(request).AssigneeID)
+
+	// The authentication, with the reviews in its details
+	require.NotNil(t, completed.AuthenticationID)
+	var auth models.Authentication
+	r.reload(&auth, *completed.AuthenticationID)
+	assert.Equal(t, r.product.ID, auth.ProductID)
+	assert.True(t, auth.Result)
+	assert.Equal(t, models.AuthMethodReview, auth.Method)
+	assert.Equal(t, 92.0, auth.Score)
+	require.NotNil(t, auth.ExpiresAt)
+	var details ReviewDetails
+	require.NoError(t, json.Unmarshal([]byte(auth.Details), &details))
+	assert.Equal(t, request.ID, details.RequestID)
+	require.Len(t, details.Reviews, 1)
+	assert.Equal(t, r.alice.ID, details.Reviews[0].ReviewerID)
+
+	// The product photo then the review's findings, photo and measurement
+	bundle, err := r.reviews.evidenceService.GetBundle(auth.ID)
+	require.NoError(t, err)
+	require.Len(t, bundle.Items, 4)
+	assert.Equal(t, "products/front.jpg", bundle.Items[0].URL)
+	assert.Equal(t, models.EvidenceKindNote, bundle.Items[1].Kind)
+	assert.Equal(t, "reviews/"+r.alice.ID+".jpg", bundle.Items[2].URL)
+	assert.Equal(t, models.EvidenceKindMeasurement, bundle.Items[3].Kind)
+	root, err := BundleRoot(bundle.Items)
+	require.NoError(t, err)
+	assert.Equal(t, root, bundle.MerkleRoot)
+
+	assert.Equal(t, int64(1), r.count(&models.Certificate{}, "authentication_id = ? AND revoked_at IS NULL", auth.ID))
+
+	// The on-chain update is queued
+	require.NotNil(t, completed.OnChainTxID)
+	var op models.OperatorTransaction
+	r.reload(&op, *completed.OnChainTxID)
+	assert.Equal(t, models.OperatorTxKindAuthenticate, op.Kind)
+	assert.Equal(t, models.OperatorTxStatusQueued, op.Status)
+	assert.Equal(t, request.ID, op.Reference)
+	assert.Equal(t, "1", op.TokenID)
+	assert.Equal(t, r.chainID, op.ChainID)
+	assert.Equal(t, r.contract, op.ContractAddress)
+	assert.True(t, op.Authenticated)
+}
+
+func TestSecondOpinion(t *testing.T) {
+	r := newReviewTest(t)
+	request := r.request(true)
+	r.claim(request, r.alice)
+
+	waiting := r.submit(request, r.alice, 90, models.AuthVerdictPass)
+	assert.Equal(t, models.AuthRequestStatusAwaitingSecondOpinion, waiting.Status)
+	assert.Nil(t, r.stored(request).AssigneeID)
+	assert.Zero(t, r.count(&models.Authentication{}, "product_id = ?", r.product.ID))
+
+	// Alice can neither see nor claim it again
+	queue, _, err := r.reviews.ListQueue(r.alice.ID, "", 1, 10)
+	require.NoError(t, err)
+	assert.Empty(t, queue)
+	_, err = r.reviews.Claim(request.ID, r.alice.ID)
+	assert.ErrorIs(t, err, ErrAlreadyReviewed)
+
+	queue, _, err = r.reviews.ListQueue(r.bob.ID, "", 1, 10)
+	require.NoError(t, err)
+	require.Len(t, queue, 1)
+	r.claim(request, r.bob)
+
+	// Bob disagrees, so the product fails with the mean score
+	completed := r.submit(request, r.bob, 30, models.AuthVerdictFail)
+	assert.Equal(t, models.AuthRequestStatusCompleted, completed.Status)
+	assert.Equal(t, models.AuthVerdictFail, completed.Verdict)
+	assert.Equal(t, 60.0, completed.Score)
+
+	var auth models.Authentication
+	r.reload(&auth, *completed.AuthenticationID)
+	assert.False(t, auth.Result)
+	assert.Nil(t, auth.ExpiresAt)
+	assert.Zero(t, r.count(&models.Certificate{}, "authentication_id = ?", auth.ID))
+
+	bundle, err := r.reviews.evidenceService.GetBundle(auth.ID)
+	require.NoError(t, err)
+	assert.Len(t, bundle.Items, 7)
+
+	var op models.OperatorTransaction
+	r.reload(&op, *completed.OnChainTxID)
+	assert.False(t, op.Authenticated)
+}
+
+func TestFlagOverdueRequests(t *testing.T) {
+	r := newReviewTest(t)
+	overdue := r.request(false)
+	claimed := r.createRequest(models.AuthRequestStatusInReview, -time.Hour)
+	onTime := r.createRequest(models.AuthRequestStatusQueued, time.Hour)
+	closed := r.createRequest(models.AuthRequestStatusCompleted, -time.Hour)
+	require.NoError(t, r.db.Model(overdue).Update("due_at", time.Now().Add(-time.Minute)).Error)
+
+	flagged, err := r.reviews.FlagOverdueRequests()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), flagged)
+	assert.True(t, r.stored(overdue).Overdue)
+	assert.True(t, r.stored(claimed).Overdue)
+	assert.False(t, r.stored(onTime).Overdue)
+	assert.False(t, r.stored(closed).Overdue)
+
+	// Flagged requests are not counted again
+	flagged, err = r.reviews.FlagOverdueRequests()
+	require.NoError(t, err)
+	assert.Zero(t, flagged)
+
+	requests, total, err := r.reviews.ListRequests("", true, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, requests, 2)
+}
+
+// createRequest stores a request for a new product in a status, due after due
+func (r *reviewTest) createRequest(status string, due time.Duration) *models.AuthenticationRequest {
+	product := createProduct(r.t, r.db, r.seller)
+	request := models.AuthenticationRequest{
+		ProductID: product.ID,
+		SellerID:  r.seller.ID,
+		Category:  product.Category,
+		Status:    status,
+		DueAt:     time.Now().Add(due),
+	}
+	require.NoError(r.t, r.db.Omit("Product").Create(&request).Error)
+	return &request
+}
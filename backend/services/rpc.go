@@ -0,0 +1,702 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/yourusername/revibe/backend/utils"
+)
+
+const (
+	rpcCheckTimeout = 5 * time.Second
+	rpcMinBackoff   = time.Second
+	rpcMaxBackoff   = 5 * time.Minute
+)
+
+var (
+	ErrNoRPCProvider          = errors.New("no healthy RPC provider available")
+	ErrNoSubscriptionProvider = errors.New("no healthy websocket RPC provider available")
+)
+
+// infuraNetworks maps chain IDs to Infura network names
+var infuraNetworks = map[string]string{
+	"1":        "mainnet",
+	"11155111": "sepolia",
+	"17000":    "holesky",
+	"137":      "polygon-mainnet",
+	"80002":    "polygon-amoy",
+}
+
+// ParseRPCEndpoints parses a comma-separated list of chainId=url pairs, such
+// as "1=https://eth.example.com,1=wss://eth.example.com/ws". A chain may
+// list several HTTP and websocket URLs.
+func ParseRPCEndpoints(spec string) (map[string][]string, error) {
+	endpoints := make(map[string][]string)
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		chainID, endpoint, ok := strings.Cut(pair, "=")
+		chainID = strings.TrimSpace(chainID)
+		if _, valid := new(big.Int).SetString(chainID, 10); !ok || !valid {
+			return nil, fmt.Errorf("invalid RPC endpoint %q", pair)
+		}
+		endpoint = strings.TrimSpace(endpoint)
+		u, err := url.Parse(endpoint)
+		if err != nil || u.Host == "" ||
+			(u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "ws" && u.Scheme != "wss") {
+			return nil, fmt.Errorf("invalid RPC URL %q", endpoint)
+		}
+		endpoints[chainID] = append(endpoints[chainID], endpoint)
+	}
+	return endpoints, nil
+}
+
+// InfuraEndpoints returns the Infura HTTP and websocket URLs for a chain, or
+// nil if Infura does not serve it
+func InfuraEndpoints(chainID, infuraID string) []string {
+	network, ok := infuraNetworks[chainID]
+	if !ok || infuraID == "" {
+		return nil
+	}
+	return []string{
+		fmt.Sprintf("https://%s.infura.io/v3/%s", network, infuraID),
+		fmt.Sprintf("wss://%s.infura.io/ws/v3/%s", network, infuraID),
+	}
+}
+
+// redactRPCURL drops the path and query of an RPC URL, which usually carry
+// an API key
+func redactRPCURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "invalid"
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// isProviderError reports whether an RPC error is the provider's fault, so
+// the request should be retried elsewhere. Errors the node answered with,
+// such as reverts or missing receipts, are not.
+func isProviderError(err error) bool {
+	if err == nil || errors.Is(err, ethereum.NotFound) ||
+		errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return true
+	}
+
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		switch rpcErr.ErrorCode() {
+		case -32005, -32603: // limit exceeded, internal error
+			return true
+		}
+		return false
+	}
+
+	return true
+}
+
+// tokenBucket limits the request rate of a provider. A rate of zero means
+// no limit.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64) tokenBucket {
+	burst := rate
+	if burst < 1 {
+		burst = 1
+	}
+	return tokenBucket{rate: rate, burst: burst, tokens: burst}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+}
+
+// take spends a token if one is available. Otherwise it returns how long
+// until the next one is.
+func (b *tokenBucket) take(now time.Time) (bool, time.Duration) {
+	if b.rate <= 0 {
+		return true, 0
+	}
+	b.refill(now)
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// RPCProvider is one RPC endpoint in an RPCPool
+type RPCProvider struct {
+	url       string
+	websocket bool
+
+	mu          sync.Mutex
+	client      *ethclient.Client
+	budget      tokenBucket
+	healthy     bool
+	failures    int
+	retryAt     time.Time
+	blockNumber uint64
+	lastError   string
+	checkedAt   time.Time
+}
+
+// RPCProviderStatus reports the health of a provider
+type RPCProviderStatus struct {
+	URL         string     `json:"url"`
+	Websocket   bool       `json:"websocket"`
+	Healthy     bool       `json:"healthy"`
+	Failures    int        `json:"failures"`
+	RetryAt     *time.Time `json:"retryAt,omitempty"`
+	BlockNumber uint64     `json:"blockNumber"`
+	LastError   string     `json:"lastError,omitempty"`
+	CheckedAt   *time.Time `json:"checkedAt,omitempty"`
+}
+
+func newRPCProvider(rawURL string, requestsPerSecond float64) *RPCProvider {
+	return &RPCProvider{
+		url:       rawURL,
+		websocket: strings.HasPrefix(rawURL, "ws"),
+		budget:    newTokenBucket(requestsPerSecond),
+	}
+}
+
+// available reports whether a provider may serve requests: it is healthy,
+// or its backoff has passed and it may be tried again
+func (p *RPCProvider) available(now time.Time) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.client != nil && (p.healthy || !now.Before(p.retryAt))
+}
+
+func (p *RPCProvider) isHealthy() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.healthy
+}
+
+func (p *RPCProvider) take(now time.Time) (bool, time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.budget.take(now)
+}
+
+func (p *RPCProvider) markSuccess() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.healthy = true
+	p.failures = 0
+	p.lastError = ""
+}
+
+// markFailure takes a provider out of rotation, backing off exponentially
+// with each consecutive failure
+func (p *RPCProvider) markFailure(err error, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.healthy = false
+	p.failures++
+	backoff := rpcMaxBackoff
+	if p.failures <= 20 {
+		if b := rpcMinBackoff << (p.failures - 1); b < rpcMaxBackoff {
+			backoff = b
+		}
+	}
+	p.retryAt = now.Add(backoff)
+	p.lastError = err.Error()
+}
+
+func (p *RPCProvider) status() RPCProviderStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	status := RPCProviderStatus{
+		URL:         redactRPCURL(p.url),
+		Websocket:   p.websocket,
+		Healthy:     p.healthy,
+		Failures:    p.failures,
+		BlockNumber: p.blockNumber,
+		LastError:   p.lastError,
+	}
+	if !p.healthy && !p.retryAt.IsZero() {
+		retryAt := p.retryAt
+		status.RetryAt = &retryAt
+	}
+	if !p.checkedAt.IsZero() {
+		checkedAt := p.checkedAt
+		status.CheckedAt = &checkedAt
+	}
+	return status
+}
+
+// RPCPool spreads requests for one chain across several RPC providers.
+// Reads fail over between healthy providers; writes are pinned to one
+// provider so a transaction's nonce, fees and broadcast agree.
+type RPCPool struct {
+	chainID     *big.Int
+	providers   []*RPCProvider
+	maxBlockLag uint64
+	next        uint32
+}
+
+// NewRPCPool connects to the given RPC URLs for a chain. Each provider may
+// serve requestsPerSecond requests, or any number if it is zero, and is
+// taken out of rotation when it falls more than maxBlockLag blocks behind
+// the others.
+func NewRPCPool(ctx context.Context, chainID *big.Int, urls []string, requestsPerSecond float64, maxBlockLag uint64) (*RPCPool, error) {
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no RPC endpoints configured for chain %s", chainID)
+	}
+
+	pool := &RPCPool{chainID: chainID, maxBlockLag: maxBlockLag}
+	for _, rawURL := range urls {
+		pool.providers = append(pool.providers, newRPCProvider(rawURL, requestsPerSecond))
+	}
+
+	pool.CheckHealth(ctx)
+	for _, provider := range pool.providers {
+		if provider.isHealthy() {
+			return pool, nil
+		}
+	}
+	pool.Close()
+	return nil, fmt.Errorf("failed to connect to any RPC endpoint for chain %s", chainID)
+}
+
+// Close closes every provider's connection
+func (p *RPCPool) Close() {
+	for _, provider := range p.providers {
+		provider.mu.Lock()
+		if provider.client != nil {
+			provider.client.Close()
+			provider.client = nil
+		}
+		provider.mu.Unlock()
+	}
+}
+
+// ChainID returns the ID of the chain the pool serves
+func (p *RPCPool) ChainID() *big.Int {
+	return new(big.Int).Set(p.chainID)
+}
+
+// Status reports the health of every provider
+func (p *RPCPool) Status() []RPCProviderStatus {
+	statuses := make([]RPCProviderStatus, len(p.providers))
+	for i, provider := range p.providers {
+		statuses[i] = provider.status()
+	}
+	return statuses
+}
+
+// StartHealthChecks checks providers every interval until ctx is cancelled
+func (p *RPCPool) StartHealthChecks(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.CheckHealth(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// CheckHealth connects to providers that are not yet connected and polls
+// the latest block of every provider due a check. Providers serving the
+// wrong chain, failing, or lagging behind the best block are backed off.
+func (p *RPCPool) CheckHealth(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, provider := range p.providers {
+		provider.mu.Lock()
+		due := provider.healthy || !time.Now().Before(provider.retryAt)
+		provider.mu.Unlock()
+		if !due {
+			continue
+		}
+
+		wg.Add(1)
+		go func(provider *RPCProvider) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, rpcCheckTimeout)
+			defer cancel()
+			if err := p.checkProvider(checkCtx, provider); err != nil {
+				provider.markFailure(err, time.Now())
+				utils.LogError(err, map[string]interface{}{
+					"component": "rpc_pool",
+					"provider":  redactRPCURL(provider.url),
+				})
+			}
+		}(provider)
+	}
+	wg.Wait()
+
+	p.checkLag()
+}
+
+func (p *RPCPool) checkProvider(ctx context.Context, provider *RPCProvider) error {
+	provider.mu.Lock()
+	client := provider.client
+	provider.mu.Unlock()
+
+	if client == nil {
+		dialed, err := ethclient.DialContext(ctx, provider.url)
+		if err != nil {
+			return fmt.Errorf("failed to connect: %v", err)
+		}
+		chainID, err := dialed.ChainID(ctx)
+		if err != nil {
+			dialed.Close()
+			return fmt.Errorf("failed to get chain ID: %v", err)
+		}
+		if chainID.Cmp(p.chainID) != 0 {
+			dialed.Close()
+			return fmt.Errorf("provider serves chain %s, expected %s", chainID, p.chainID)
+		}
+		provider.mu.Lock()
+		provider.client = dialed
+		provider.mu.Unlock()
+		client = dialed
+	}
+
+	blockNumber, err := client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get block number: %v", err)
+	}
+
+	provider.mu.Lock()
+	provider.blockNumber = blockNumber
+	provider.checkedAt = time.Now()
+	provider.mu.Unlock()
+	provider.markSuccess()
+	return nil
+}
+
+// checkLag backs off healthy providers that trail the best known block by
+// more than maxBlockLag
+func (p *RPCPool) checkLag() {
+	var best uint64
+	for _, provider := range p.providers {
+		provider.mu.Lock()
+		if provider.healthy && provider.blockNumber > best {
+			best = provider.blockNumber
+		}
+		provider.mu.Unlock()
+	}
+
+	for _, provider := range p.providers {
+		provider.mu.Lock()
+		lag := best - provider.blockNumber
+		lagging := provider.healthy && lag > p.maxBlockLag
+		provider.mu.Unlock()
+		if lagging {
+			provider.markFailure(fmt.Errorf("provider is %d blocks behind", lag), time.Now())
+		}
+	}
+}
+
+// acquire picks an available provider that has not been tried yet, healthy
+// ones first and in rotation, waiting for request budget if every candidate
+// has spent it
+func (p *RPCPool) acquire(ctx context.Context, tried map[*RPCProvider]bool, websocket bool) (*RPCProvider, error) {
+	for {
+		now := time.Now()
+		start := int(atomic.AddUint32(&p.next, 1))
+
+		var candidates []*RPCProvider
+		for _, healthy := range []bool{true, false} {
+			for i := range p.providers {
+				provider := p.providers[(start+i)%len(p.providers)]
+				if tried[provider] || (websocket && !provider.websocket) ||
+					!provider.available(now) || provider.isHealthy() != healthy {
+					continue
+				}
+				candidates = append(candidates, provider)
+			}
+		}
+		if len(candidates) == 0 {
+			if websocket {
+				return nil, ErrNoSubscriptionProvider
+			}
+			return nil, ErrNoRPCProvider
+		}
+
+		var wait time.Duration
+		for _, provider := range candidates {
+			ok, after := provider.take(now)
+			if ok {
+				return provider, nil
+			}
+			if wait == 0 || after < wait {
+				wait = after
+			}
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// do runs a request on an available provider, failing over to the next one
+// when the provider is at fault
+func (p *RPCPool) do(ctx context.Context, websocket bool, fn func(*ethclient.Client) error) error {
+	tried := make(map[*RPCProvider]bool)
+	var lastErr error
+	for {
+		provider, err := p.acquire(ctx, tried, websocket)
+		if err != nil {
+			if lastErr != nil && !errors.Is(err, ctx.Err()) {
+				return lastErr
+			}
+			return err
+		}
+
+		err = p.run(ctx, provider, fn)
+		if err == nil || ctx.Err() != nil || !isProviderError(err) {
+			return err
+		}
+		tried[provider] = true
+		lastErr = err
+	}
+}
+
+// run runs a request on one provider and records the outcome
+func (p *RPCPool) run(ctx context.Context, provider *RPCProvider, fn func(*ethclient.Client) error) error {
+	provider.mu.Lock()
+	client := provider.client
+	provider.mu.Unlock()
+	if client == nil {
+		return ErrNoRPCProvider
+	}
+
+	err := fn(client)
+	switch {
+	case err == nil || !isProviderError(err):
+		provider.markSuccess()
+	case ctx.Err() == nil:
+		provider.markFailure(err, time.Now())
+	}
+	return err
+}
+
+// Backend returns a contract backend that sends each request to any
+// healthy provider
+func (p *RPCPool) Backend() *RPCBackend {
+	return &RPCBackend{pool: p}
+}
+
+// Pin returns a contract backend bound to a single healthy provider, for
+// building, signing and broadcasting one transaction
+func (p *RPCPool) Pin(ctx context.Context) (*RPCBackend, error) {
+	provider, err := p.acquire(ctx, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	// acquire spent a request from the budget to reserve the provider
+	return &RPCBackend{pool: p, pinned: provider, reserved: 1}, nil
+}
+
+// RPCBackend implements bind.ContractBackend on an RPCPool, either across
+// all providers or pinned to one
+type RPCBackend struct {
+	pool     *RPCPool
+	pinned   *RPCProvider
+	reserved int32
+}
+
+// Provider returns the redacted URL of a pinned backend's provider
+func (b *RPCBackend) Provider() string {
+	if b.pinned == nil {
+		return ""
+	}
+	return redactRPCURL(b.pinned.url)
+}
+
+func (b *RPCBackend) call(ctx context.Context, websocket bool, fn func(*ethclient.Client) error) error {
+	if b.pinned == nil {
+		return b.pool.do(ctx, websocket, fn)
+	}
+
+	if atomic.AddInt32(&b.reserved, -1) < 0 {
+		for {
+			ok, wait := b.pinned.take(time.Now())
+			if ok {
+				break
+			}
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	return b.pool.run(ctx, b.pinned, fn)
+}
+
+// CodeAt returns the code of an account
+func (b *RPCBackend) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) (code []byte, err error) {
+	err = b.call(ctx, false, func(c *ethclient.Client) (err error) {
+		code, err = c.CodeAt(ctx, account, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+// CallContract executes a contract call
+func (b *RPCBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) (result []byte, err error) {
+	err = b.call(ctx, false, func(c *ethclient.Client) (err error) {
+		result, err = c.CallContract(ctx, call, blockNumber)
+		return err
+	})
+	return result, err
+}
+
+// HeaderByNumber returns a block header, or the latest one if number is nil
+func (b *RPCBackend) HeaderByNumber(ctx context.Context, number *big.Int) (header *types.Header, err error) {
+	err = b.call(ctx, false, func(c *ethclient.Client) (err error) {
+		header, err = c.HeaderByNumber(ctx, number)
+		return err
+	})
+	return header, err
+}
+
+// PendingCodeAt returns the code of an account in the pending state
+func (b *RPCBackend) PendingCodeAt(ctx context.Context, account common.Address) (code []byte, err error) {
+	err = b.call(ctx, false, func(c *ethclient.Client) (err error) {
+		code, err = c.PendingCodeAt(ctx, account)
+		return err
+	})
+	return code, err
+}
+
+// PendingNonceAt returns the next nonce of an account
+func (b *RPCBackend) PendingNonceAt(ctx context.Context, account common.Address) (nonce uint64, err error) {
+	err = b.call(ctx, false, func(c *ethclient.Client) (err error) {
+		nonce, err = c.PendingNonceAt(ctx, account)
+		return err
+	})
+	return nonce, err
+}
+
+// NonceAt returns the nonce of an account at a block; nil means the latest block
+func (b *RPCBackend) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (nonce uint64, err error) {
+	err = b.call(ctx, false, func(c *ethclient.Client) (err error) {
+		nonce, err = c.NonceAt(ctx, account, blockNumber)
+		return err
+	})
+	return nonce, err
+}
+
+// SuggestGasPrice returns a legacy gas price
+func (b *RPCBackend) SuggestGasPrice(ctx context.Context) (price *big.Int, err error) {
+	err = b.call(ctx, false, func(c *ethclient.Client) (err error) {
+		price, err = c.SuggestGasPrice(ctx)
+		return err
+	})
+	return price, err
+}
+
+// SuggestGasTipCap returns a priority fee for dynamic fee transactions
+func (b *RPCBackend) SuggestGasTipCap(ctx context.Context) (tip *big.Int, err error) {
+	err = b.call(ctx, false, func(c *ethclient.Client) (err error) {
+		tip, err = c.SuggestGasTipCap(ctx)
+		return err
+	})
+	return tip, err
+}
+
+// EstimateGas estimates the gas a call needs
+func (b *RPCBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (gas uint64, err error) {
+	err = b.call(ctx, false, func(c *ethclient.Client) (err error) {
+		gas, err = c.EstimateGas(ctx, call)
+		return err
+	})
+	return gas, err
+}
+
+// SendTransaction broadcasts a signed transaction
+func (b *RPCBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return b.call(ctx, false, func(c *ethclient.Client) error {
+		return c.SendTransaction(ctx, tx)
+	})
+}
+
+// FilterLogs returns the logs matching a query
+func (b *RPCBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) (logs []types.Log, err error) {
+	err = b.call(ctx, false, func(c *ethclient.Client) (err error) {
+		logs, err = c.FilterLogs(ctx, query)
+		return err
+	})
+	return logs, err
+}
+
+// SubscribeFilterLogs streams the logs matching a query. It needs a
+// websocket provider.
+func (b *RPCBackend) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (sub ethereum.Subscription, err error) {
+	err = b.call(ctx, true, func(c *ethclient.Client) (err error) {
+		sub, err = c.SubscribeFilterLogs(ctx, query, ch)
+		return err
+	})
+	return sub, err
+}
+
+// BlockNumber returns the number of the latest block
+func (b *RPCBackend) BlockNumber(ctx context.Context) (number uint64, err error) {
+	err = b.call(ctx, false, func(c *ethclient.Client) (err error) {
+		number, err = c.BlockNumber(ctx)
+		return err
+	})
+	return number, err
+}
+
+// TransactionReceipt returns the receipt of a mined transaction
+func (b *RPCBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
+	err = b.call(ctx, false, func(c *ethclient.Client) (err error) {
+		receipt, err = c.TransactionReceipt(ctx, txHash)
+		return err
+	})
+	return receipt, err
+}
+
+// TransactionByHash returns a transaction and whether it is still pending
+func (b *RPCBackend) TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error) {
+	err = b.call(ctx, false, func(c *ethclient.Client) (err error) {
+		tx, isPending, err = c.TransactionByHash(ctx, hash)
+		return err
+	})
+	return tx, isPending, err
+}
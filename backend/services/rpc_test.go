@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRPCNode answers eth_chainId and eth_blockNumber, or fails with a 503
+type fakeRPCNode struct {
+	*httptest.Server
+	failing  atomic.Bool
+	requests atomic.Int32
+}
+
+func newFakeRPCNode(t *testing.T, blockNumber string) *fakeRPCNode {
+	node := &fakeRPCNode{}
+	node.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		node.requests.Add(1)
+		if node.failing.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		result := map[string]string{"eth_chainId": "0xaa36a7", "eth_blockNumber": blockNumber}[req.Method]
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	t.Cleanup(node.Close)
+	return node
+}
+
+// jsonRPCError is an error a node answered a request with
+type jsonRPCError struct {
+	code    int
+	message string
+}
+
+func (e jsonRPCError) Error() string  { return e.message }
+func (e jsonRPCError) ErrorCode() int { return e.code }
+
+func TestParseRPCEndpoints(t *testing.T) {
+	endpoints, err := ParseRPCEndpoints("11155111=https://rpc.example.com, 11155111=wss://rpc.example.com/ws,,31337=http://127.0.0.1:8545")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"11155111": {"https://rpc.example.com", "wss://rpc.example.com/ws"},
+		"31337":    {"http://127.0.0.1:8545"},
+	}, endpoints)
+
+	_, err = ParseRPCEndpoints("sepolia=https://rpc.example.com")
+	assert.Error(t, err)
+	_, err = ParseRPCEndpoints("1=ftp://rpc.example.com")
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{
+		"https://sepolia.infura.io/v3/key",
+		"wss://sepolia.infura.io/ws/v3/key",
+	}, InfuraEndpoints("11155111", "key"))
+	assert.Nil(t, InfuraEndpoints("31337", "key"))
+	assert.Equal(t, "https://mainnet.infura.io", redactRPCURL("https://mainnet.infura.io/v3/key"))
+}
+
+func TestTokenBucket(t *testing.T) {
+	bucket := newTokenBucket(2)
+	now := time.Now()
+
+	ok, _ := bucket.take(now)
+	assert.True(t, ok)
+	ok, _ = bucket.take(now)
+	assert.True(t, ok)
+	ok, wait := bucket.take(now)
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, wait)
+
+	ok, _ = bucket.take(now.Add(500 * time.Millisecond))
+	assert.True(t, ok)
+
+	unlimited := newTokenBucket(0)
+	for i := 0; i < 100; i++ {
+		ok, _ := unlimited.take(now)
+		require.True(t, ok)
+	}
+}
+
+func TestIsProviderError(t *testing.T) {
+	assert.False(t, isProviderError(nil))
+	assert.False(t, isProviderError(ethereum.NotFound))
+	assert.False(t, isProviderError(jsonRPCError{3, "execution reverted"}))
+	assert.True(t, isProviderError(jsonRPCError{-32005, "limit exceeded"}))
+	assert.True(t, isProviderError(rpc.HTTPError{StatusCode: http.StatusTooManyRequests}))
+	assert.True(t, isProviderError(errors.New("connection refused")))
+}
+
+func TestRPCProviderBackoff(t *testing.T) {
+	provider := newRPCProvider("https://rpc.example.com", 0)
+	now := time.Now()
+
+	provider.markFailure(errors.New("down"), now)
+	assert.Equal(t, now.Add(time.Second), provider.retryAt)
+	provider.markFailure(errors.New("down"), now)
+	provider.markFailure(errors.New("down"), now)
+	assert.Equal(t, now.Add(4*time.Second), provider.retryAt)
+
+	for i := 0; i < 30; i++ {
+		provider.markFailure(errors.New("down"), now)
+	}
+	assert.Equal(t, now.Add(rpcMaxBackoff), provider.retryAt)
+
+	provider.markSuccess()
+	assert.True(t, provider.isHealthy())
+	assert.Zero(t, provider.failures)
+}
+
+func TestRPCPoolFailover(t *testing.T) {
+	ctx := context.Background()
+	primary := newFakeRPCNode(t, "0x64")
+	secondary := newFakeRPCNode(t, "0x64")
+
+	pool, err := NewRPCPool(ctx, big.NewInt(11155111), []string{primary.URL, secondary.URL}, 0, 5)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	primary.failing.Store(true)
+	for i := 0; i < 4; i++ {
+		number, err := pool.Backend().BlockNumber(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(100), number)
+	}
+
+	// The failing provider is backed off rather than retried on every read
+	assert.Equal(t, int32(3), primary.requests.Load())
+	status := pool.Status()
+	assert.False(t, status[0].Healthy)
+	assert.NotNil(t, status[0].RetryAt)
+	assert.True(t, status[1].Healthy)
+
+	t.Run("Pinned", func(t *testing.T) {
+		backend, err := pool.Pin(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, redactRPCURL(secondary.URL), backend.Provider())
+
+		secondary.failing.Store(true)
+		defer secondary.failing.Store(false)
+		_, err = backend.BlockNumber(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("Wrong Chain", func(t *testing.T) {
+		_, err := NewRPCPool(ctx, big.NewInt(1), []string{secondary.URL}, 0, 5)
+		assert.Error(t, err)
+	})
+}
+
+func TestRPCPoolBlockLag(t *testing.T) {
+	ctx := context.Background()
+	current := newFakeRPCNode(t, "0x64")
+	behind := newFakeRPCNode(t, "0x50")
+
+	pool, err := NewRPCPool(ctx, big.NewInt(11155111), []string{behind.URL, current.URL}, 0, 5)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	status := pool.Status()
+	assert.False(t, status[0].Healthy)
+	assert.Equal(t, "provider is 20 blocks behind", status[0].LastError)
+	assert.True(t, status[1].Healthy)
+}
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/revibe/backend/config"
+	"github.com/yourusername/revibe/backend/models"
+	"github.com/yourusername/revibe/backend/utils"
+	"gorm.io/gorm"
+)
+
+const shipmentPollInterval = 30 * time.Minute
+
+var (
+	ErrOrderNotShippable = errors.New("order is not awaiting shipment")
+	ErrNotOrderSeller    = errors.New("only the seller can ship this order")
+	ErrNotOrderBuyer     = errors.New("only the buyer can confirm receipt")
+	ErrNoShipment        = errors.New("order has not been shipped")
+)
+
+// shipmentStatusRank orders statuses so carrier updates never move a shipment backwards
+var shipmentStatusRank = map[string]int{
+	models.ShipmentStatusShipped:   1,
+	models.ShipmentStatusInTransit: 2,
+	models.ShipmentStatusDelivered: 3,
+}
+
+// ShipmentService tracks fulfilment of completed orders
+type ShipmentService struct {
+	db      *gorm.DB
+	tracker CarrierTracker
+	sla     time.Duration
+}
+
+// NewShipmentService creates a new ShipmentService instance. tracker may be
+// nil, in which case shipments only change through seller and buyer actions.
+func NewShipmentService(db *gorm.DB, tracker CarrierTracker) *ShipmentService {
+	return &ShipmentService{
+		db:      db,
+		tracker: tracker,
+		sla:     config.AppConfig.ShippingSLA,
+	}
+}
+
+// AddTracking records that the seller has shipped an order
+func (s *ShipmentService) AddTracking(orderID, sellerID, carrier, trackingNumber string) (*models.Shipment, error) {
+	var shipment models.Shipment
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var order models.Order
+		if err := tx.First(&order, "id = ?", orderID).Error; err != nil {
+			return err
+		}
+		if order.SellerID != sellerID {
+			return ErrNotOrderSeller
+		}
+		if order.Status != models.OrderStatusCompleted {
+			return ErrOrderNotShippable
+		}
+
+		now := time.Now()
+		shipment = models.Shipment{
+			OrderID:        order.ID,
+			Carrier:        carrier,
+			TrackingNumber: trackingNumber,
+			Status:         models.ShipmentStatusShipped,
+			ShippedAt:      now,
+			Events: []models.ShipmentEvent{{
+				Status:     models.ShipmentStatusShipped,
+				Source:     models.ShipmentSourceSeller,
+				OccurredAt: now,
+			}},
+		}
+		if err := tx.Create(&shipment).Error; err != nil {
+			return fmt.Errorf("failed to save shipment: %v", err)
+		}
+
+		return tx.Model(&order).Update("shipping_overdue", false).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &shipment, nil
+}
+
+// GetShipments returns an order's shipments with their event history
+func (s *ShipmentService) GetShipments(orderID string) ([]models.Shipment, error) {
+	var shipments []models.Shipment
+	err := s.db.Preload("Events", func(db *gorm.DB) *gorm.DB {
+		return db.Order("occurred_at asc")
+	}).Where("order_id = ?", orderID).Order("created_at asc").Find(&shipments).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch shipments: %v", err)
+	}
+
+	return shipments, nil
+}
+
+// ConfirmReceipt records the buyer's confirmation that an order arrived
+func (s *ShipmentService) ConfirmReceipt(orderID, buyerID string) (*models.Shipment, error) {
+	var shipment models.Shipment
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var order models.Order
+		if err := tx.First(&order, "id = ?", orderID).Error; err != nil {
+			return err
+		}
+		if order.BuyerID != buyerID {
+			return ErrNotOrderBuyer
+		}
+
+		if err := tx.Where("order_id = ?", order.ID).Order("created_at desc").First(&shipment).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrNoShipment
+			}
+			return err
+		}
+		if shipment.ConfirmedAt != nil {
+			return nil
+		}
+
+		now := time.Now()
+		shipment.ConfirmedAt = &now
+		if shipment.DeliveredAt == nil {
+			shipment.DeliveredAt = &now
+		}
+		shipment.Status = models.ShipmentStatusDelivered
+		if err := tx.Save(&shipment).Error; err != nil {
+			return fmt.Errorf("failed to update shipment: %v", err)
+		}
+
+		event := models.ShipmentEvent{
+			ShipmentID:  shipment.ID,
+			Status:      models.ShipmentStatusDelivered,
+			Description: "Receipt confirmed by buyer",
+			Source:      models.ShipmentSourceBuyer,
+			OccurredAt:  now,
+		}
+		return tx.Create(&event).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &shipment, nil
+}
+
+// StartShipmentMonitor refreshes carrier tracking and flags overdue orders
+// until ctx is cancelled
+func (s *ShipmentService) StartShipmentMonitor(ctx context.Context) {
+	ticker := time.NewTicker(shipmentPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.RefreshTracking(ctx); err != nil {
+				utils.LogError(err, map[string]interface{}{
+					"component": "shipment_monitor",
+				})
+			}
+			if _, err := s.FlagOverdueOrders(); err != nil {
+				utils.LogError(err, map[string]interface{}{
+					"component": "shipment_monitor",
+				})
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// RefreshTracking pulls new carrier events for every undelivered shipment
+func (s *ShipmentService) RefreshTracking(ctx context.Context) error {
+	if s.tracker == nil {
+		return nil
+	}
+
+	var shipments []models.Shipment
+	if err := s.db.Preload("Events").
+		Where("status <> ?", models.ShipmentStatusDelivered).
+		Find(&shipments).Error; err != nil {
+		return fmt.Errorf("failed to fetch shipments: %v", err)
+	}
+
+	for i := range shipments {
+		if err := s.refreshShipment(ctx, &shipments[i]); err != nil {
+			utils.LogError(err, map[string]interface{}{
+				"component":   "shipment_monitor",
+				"shipment_id": shipments[i].ID,
+			})
+		}
+	}
+
+	return nil
+}
+
+func (s *ShipmentService) refreshShipment(ctx context.Context, shipment *models.Shipment) error {
+	events, err := s.tracker.Track(ctx, shipment.Carrier, shipment.TrackingNumber)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	for _, e := range shipment.Events {
+		if e.Source == models.ShipmentSourceCarrier {
+			seen[e.Status+e.OccurredAt.UTC().Format(time.RFC3339)] = true
+		}
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		for _, e := range events {
+			if _, ok := shipmentStatusRank[e.Status]; !ok {
+				continue
+			}
+			key := e.Status + e.OccurredAt.UTC().Format(time.RFC3339)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			event := models.ShipmentEvent{
+				ShipmentID:  shipment.ID,
+				Status:      e.Status,
+				Location:    e.Location,
+				Description: e.Description,
+				Source:      models.ShipmentSourceCarrier,
+				OccurredAt:  e.OccurredAt,
+			}
+			if err := tx.Create(&event).Error; err != nil {
+				return fmt.Errorf("failed to save shipment event: %v", err)
+			}
+
+			if shipmentStatusRank[e.Status] > shipmentStatusRank[shipment.Status] {
+				shipment.Status = e.Status
+				if e.Status == models.ShipmentStatusDelivered {
+					deliveredAt := e.OccurredAt
+					shipment.DeliveredAt = &deliveredAt
+				}
+			}
+		}
+
+		return tx.Model(shipment).Updates(map[string]interface{}{
+			"status":       shipment.Status,
+			"delivered_at": shipment.DeliveredAt,
+		}).Error
+	})
+}
+
+// FlagOverdueOrders marks completed orders that have not shipped within the
+// shipping SLA and returns how many were flagged
+func (s *ShipmentService) FlagOverdueOrders() (int64, error) {
+	result := s.db.Model(&models.Order{}).
+		Where("status = ? AND shipping_overdue = ? AND completed_at < ?",
+			models.OrderStatusCompleted, false, time.Now().Add(-s.sla)).
+		Where("NOT EXISTS (SELECT 1 FROM shipments WHERE shipments.order_id = orders.id)").
+		Update("shipping_overdue", true)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to flag overdue orders: %v", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// GetOverdueOrders returns orders flagged as not shipped within the SLA
+func (s *ShipmentService) GetOverdueOrders() ([]models.Order, error) {
+	var orders []models.Order
+	err := s.db.Preload("Product").Preload("Buyer").Preload("Seller").
+		Where("shipping_overdue = ?", true).
+		Order("completed_at asc").
+		Find(&orders).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch overdue orders: %v", err)
+	}
+
+	return orders, nil
+}
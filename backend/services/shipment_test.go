@@ -0,0 +1,313 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/models"
+	"gorm.io/gorm"
+)
+
+// fakeCarrierTracker reports the events set for each tracking number
+type fakeCarrierTracker struct {
+	events map[string][]TrackingEvent
+}
+
+func (f *fakeCarrierTracker) Track(ctx context.Context, carrier, trackingNumber string) ([]TrackingEvent, error) {
+	events, ok := f.events[trackingNumber]
+	if !ok {
+		return nil, ErrTrackingNotFound
+	}
+	return events, nil
+}
+
+// shipmentTest is a shipment service over a test database with an order
+// between a seller and a buyer
+type shipmentTest struct {
+	t         *testing.T
+	db        *gorm.DB
+	tracker   *fakeCarrierTracker
+	shipments *ShipmentService
+	seller    *models.User
+	buyer     *models.User
+	order     *models.Order
+}
+
+func newShipmentTest(t *testing.T) *shipmentTest {
+	db := newTestDB(t)
+	tracker := &fakeCarrierTracker{events: map[string][]TrackingEvent{}}
+	s := &shipmentTest{
+		t:         t,
+		db:        db,
+		tracker:   tracker,
+		shipments: &ShipmentService{db: db, tracker: tracker, sla: 72 * time.Hour},
+		seller:    createUser(t, db, "0x5e11e500000000000000000000000000000000aa"),
+		buyer:     createUser(t, db, "0xb0b0000000000000000000000000000000000bbb"),
+	}
+	s.order = s.createOrder(models.OrderStatusCompleted, time.Now())
+	return s
+}
+
+var shipmentOrders int64
+
+// createOrder creates an order for a new product in a status, completed at
+// completedAt if it is completed
+func (s *shipmentTest) createOrder(status string, completedAt time.Time) *models.Order {
+	shipmentOrders++
+	product := createProduct(s.t, s.db, s.seller)
+	order := models.Order{
+		ProductID: product.ID,
+		BuyerID:   s.buyer.ID,
+		SellerID:  s.seller.ID,
+		Price:     0.1,
+		Status:    status,
+		TxHash:    txHash(shipmentOrders),
+	}
+	if status == models.OrderStatusCompleted {
+		order.CompletedAt = &completedAt
+	}
+	require.NoError(s.t, s.db.Create(&order).Error)
+	return &order
+}
+
+// shipment reloads a shipment with its events, oldest first
+func (s *shipmentTest) shipment(id string) *models.Shipment {
+	var shipment models.Shipment
+	require.NoError(s.t, s.db.Preload("Events", func(db *gorm.DB) *gorm.DB {
+		return db.Order("occurred_at asc")
+	}).First(&shipment, "id = ?", id).Error)
+	return &shipment
+}
+
+func (s *shipmentTest) overdue(orderID string) bool {
+	var order models.Order
+	require.NoError(s.t, s.db.First(&order, "id = ?", orderID).Error)
+	return order.ShippingOverdue
+}
+
+func TestAddTracking(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		shipper func(s *shipmentTest) string
+		wantErr error
+	}{
+		{name: "seller ships", status: models.OrderStatusCompleted},
+		{
+			name:    "buyer ships",
+			status:  models.OrderStatusCompleted,
+			shipper: func(s *shipmentTest) string { return s.buyer.ID },
+			wantErr: ErrNotOrderSeller,
+		},
+		{name: "sale pending", status: models.OrderStatusPending, wantErr: ErrOrderNotShippable},
+		{name: "sale failed", status: models.OrderStatusFailed, wantErr: ErrOrderNotShippable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newShipmentTest(t)
+			order := s.createOrder(tt.status, time.Now())
+			require.NoError(t, s.db.Model(order).Update("shipping_overdue", true).Error)
+			shipper := s.seller.ID
+			if tt.shipper != nil {
+				shipper = tt.shipper(s)
+			}
+
+			shipment, err := s.shipments.AddTracking(order.ID, shipper, "ups", "1Z999")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.True(t, s.overdue(order.ID))
+				return
+			}
+			require.NoError(t, err)
+
+			saved := s.shipment(shipment.ID)
+			assert.Equal(t, models.ShipmentStatusShipped, saved.Status)
+			assert.Equal(t, "ups", saved.Carrier)
+			assert.Equal(t, "1Z999", saved.TrackingNumber)
+			require.Len(t, saved.Events, 1)
+			assert.Equal(t, models.ShipmentSourceSeller, saved.Events[0].Source)
+			assert.False(t, s.overdue(order.ID))
+		})
+	}
+
+	t.Run("unknown order", func(t *testing.T) {
+		s := newShipmentTest(t)
+		_, err := s.shipments.AddTracking("00000000-0000-0000-0000-000000000000", s.seller.ID, "ups", "1Z999")
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
+
+func TestConfirmReceipt(t *testing.T) {
+	s := newShipmentTest(t)
+
+	_, err := s.shipments.ConfirmReceipt(s.order.ID, s.buyer.ID)
+	assert.ErrorIs(t, err, ErrNoShipment)
+
+	shipment, err := s.shipments.AddTracking(s.order.ID, s.seller.ID, "ups", "1Z999")
+	require.NoError(t, err)
+
+	_, err = s.shipments.ConfirmReceipt(s.order.ID, s.seller.ID)
+	assert.ErrorIs(t, err, ErrNotOrderBuyer)
+
+	confirmed, err := s.shipments.ConfirmReceipt(s.order.ID, s.buyer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ShipmentStatusDelivered, confirmed.Status)
+	require.NotNil(t, confirmed.ConfirmedAt)
+	require.NotNil(t, confirmed.DeliveredAt)
+
+	// Confirming again changes nothing
+	again, err := s.shipments.ConfirmReceipt(s.order.ID, s.buyer.ID)
+	require.NoError(t, err)
+	assert.True(t, confirmed.ConfirmedAt.Equal(*again.ConfirmedAt))
+
+	saved := s.shipment(shipment.ID)
+	require.Len(t, saved.Events, 2)
+	assert.Equal(t, models.ShipmentSourceBuyer, saved.Events[1].Source)
+	assert.Equal(t, models.ShipmentStatusDelivered, saved.Events[1].Status)
+}
+
+func TestConfirmReceiptKeepsCarrierDeliveryTime(t *testing.T) {
+	s := newShipmentTest(t)
+	shipment, err := s.shipments.AddTracking(s.order.ID, s.seller.ID, "ups", "1Z999")
+	require.NoError(t, err)
+	delivered := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+	s.tracker.events["1Z999"] = []TrackingEvent{{Status: models.ShipmentStatusDelivered, OccurredAt: delivered}}
+	require.NoError(t, s.shipments.RefreshTracking(context.Background()))
+
+	confirmed, err := s.shipments.ConfirmReceipt(s.order.ID, s.buyer.ID)
+	require.NoError(t, err)
+	assert.True(t, delivered.Equal(*confirmed.DeliveredAt))
+	assert.True(t, confirmed.ConfirmedAt.After(delivered))
+	assert.Equal(t, shipment.ID, confirmed.ID)
+}
+
+func TestRefreshShipment(t *testing.T) {
+	day := time.Date(2024, 3, 20, 9, 0, 0, 0, time.UTC)
+	shipped := TrackingEvent{Status: models.ShipmentStatusShipped, Location: "Berlin", OccurredAt: day}
+	inTransit := TrackingEvent{Status: models.ShipmentStatusInTransit, Location: "Hamburg", OccurredAt: day.Add(24 * time.Hour)}
+	delivered := TrackingEvent{Status: models.ShipmentStatusDelivered, Location: "Hamburg", OccurredAt: day.Add(48 * time.Hour)}
+	// A scan reported late, after the parcel was delivered
+	lateScan := TrackingEvent{Status: models.ShipmentStatusInTransit, Location: "Hamburg depot", OccurredAt: day.Add(47 * time.Hour)}
+	unknown := TrackingEvent{Status: "held_at_customs", OccurredAt: day.Add(12 * time.Hour)}
+
+	tests := []struct {
+		name          string
+		updates       [][]TrackingEvent
+		wantStatus    string
+		wantEvents    int
+		wantDelivered *time.Time
+	}{
+		{
+			name:       "in transit",
+			updates:    [][]TrackingEvent{{shipped, inTransit}},
+			wantStatus: models.ShipmentStatusInTransit,
+			wantEvents: 3,
+		},
+		{
+			name:          "delivered",
+			updates:       [][]TrackingEvent{{shipped, inTransit, delivered}},
+			wantStatus:    models.ShipmentStatusDelivered,
+			wantEvents:    4,
+			wantDelivered: &delivered.OccurredAt,
+		},
+		{
+			name:       "history reported again",
+			updates:    [][]TrackingEvent{{shipped}, {shipped, inTransit}, {shipped, inTransit}},
+			wantStatus: models.ShipmentStatusInTransit,
+			wantEvents: 3,
+		},
+		{
+			name:          "late scan does not move the shipment back",
+			updates:       [][]TrackingEvent{{shipped, delivered}, {shipped, lateScan, delivered}},
+			wantStatus:    models.ShipmentStatusDelivered,
+			wantEvents:    4,
+			wantDelivered: &delivered.OccurredAt,
+		},
+		{
+			name:       "unknown statuses are skipped",
+			updates:    [][]TrackingEvent{{shipped, unknown}},
+			wantStatus: models.ShipmentStatusShipped,
+			wantEvents: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newShipmentTest(t)
+			shipment, err := s.shipments.AddTracking(s.order.ID, s.seller.ID, "ups", "1Z999")
+			require.NoError(t, err)
+
+			for _, events := range tt.updates {
+				s.tracker.events["1Z999"] = events
+				require.NoError(t, s.shipments.refreshShipment(context.Background(), s.shipment(shipment.ID)))
+			}
+
+			saved := s.shipment(shipment.ID)
+			assert.Equal(t, tt.wantStatus, saved.Status)
+			assert.Len(t, saved.Events, tt.wantEvents)
+			if tt.wantDelivered == nil {
+				assert.Nil(t, saved.DeliveredAt)
+			} else {
+				require.NotNil(t, saved.DeliveredAt)
+				assert.True(t, tt.wantDelivered.Equal(*saved.DeliveredAt))
+			}
+		})
+	}
+
+	t.Run("unknown tracking number", func(t *testing.T) {
+		s := newShipmentTest(t)
+		shipment, err := s.shipments.AddTracking(s.order.ID, s.seller.ID, "ups", "1Z000")
+		require.NoError(t, err)
+		err = s.shipments.refreshShipment(context.Background(), s.shipment(shipment.ID))
+		assert.ErrorIs(t, err, ErrTrackingNotFound)
+	})
+}
+
+func TestRefreshTrackingSkipsDelivered(t *testing.T) {
+	s := newShipmentTest(t)
+	shipment, err := s.shipments.AddTracking(s.order.ID, s.seller.ID, "ups", "1Z999")
+	require.NoError(t, err)
+	_, err = s.shipments.ConfirmReceipt(s.order.ID, s.buyer.ID)
+	require.NoError(t, err)
+
+	s.tracker.events["1Z999"] = []TrackingEvent{{Status: models.ShipmentStatusInTransit, OccurredAt: time.Now()}}
+	require.NoError(t, s.shipments.RefreshTracking(context.Background()))
+	assert.Len(t, s.shipment(shipment.ID).Events, 2)
+}
+
+func TestFlagOverdueOrders(t *testing.T) {
+	s := newShipmentTest(t)
+	late := time.Now().Add(-s.shipments.sla - time.Hour)
+
+	overdue := s.createOrder(models.OrderStatusCompleted, late)
+	shipped := s.createOrder(models.OrderStatusCompleted, late)
+	_, err := s.shipments.AddTracking(shipped.ID, s.seller.ID, "ups", "1Z999")
+	require.NoError(t, err)
+	recent := s.createOrder(models.OrderStatusCompleted, time.Now().Add(-time.Hour))
+	pending := s.createOrder(models.OrderStatusPending, late)
+
+	flagged, err := s.shipments.FlagOverdueOrders()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), flagged)
+	assert.True(t, s.overdue(overdue.ID))
+	for _, order := range []*models.Order{shipped, recent, pending, s.order} {
+		assert.False(t, s.overdue(order.ID))
+	}
+
+	// Flagged orders are not counted again
+	flagged, err = s.shipments.FlagOverdueOrders()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), flagged)
+
+	orders, err := s.shipments.GetOverdueOrders()
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Equal(t, overdue.ID, orders[0].ID)
+
+	// Shipping clears the flag
+	_, err = s.shipments.AddTracking(overdue.ID, s.seller.ID, "ups", "1Z998")
+	require.NoError(t, err)
+	assert.False(t, s.overdue(overdue.ID))
+}
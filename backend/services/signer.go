@@ -0,0 +1,367 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/yourusername/revibe/backend/config"
+)
+
+// Operator signer types
+const (
+	SignerTypeKeystore = "keystore"
+	SignerTypeFile     = "file"
+	SignerTypeEnv      = "env"
+	SignerTypeRemote   = "remote"
+)
+
+// operatorKeyEnv holds a raw operator key for the env signer. It is read
+// here rather than into config so the key is not copied into the config
+// struct, and is removed from the environment once read. The process's copy
+// of the environment string cannot be wiped, so production deployments
+// should prefer the file, keystore or remote signer.
+const operatorKeyEnv = "OPERATOR_PRIVATE_KEY"
+
+var (
+	ErrUnknownSignerType = errors.New("unknown operator signer type")
+	ErrSignerMismatch    = errors.New("remote signer returned a different transaction")
+)
+
+// Signer signs transactions for a single account. Implementations never
+// expose the account's key.
+type Signer interface {
+	Address() common.Address
+	SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// NewOperatorSigner creates the operator's signer from config. It returns
+// nil if no operator signer is configured.
+func NewOperatorSigner() (Signer, error) {
+	signerType := config.AppConfig.OperatorSigner
+	if signerType == "" {
+		if _, ok := os.LookupEnv(operatorKeyEnv); !ok {
+			return nil, nil
+		}
+		signerType = SignerTypeEnv
+	}
+
+	switch signerType {
+	case SignerTypeKeystore:
+		return NewKeystoreSigner(config.AppConfig.OperatorKeystore, config.AppConfig.OperatorKeystorePasswordFile)
+	case SignerTypeFile:
+		return NewFileSigner(config.AppConfig.OperatorKeyFile)
+	case SignerTypeEnv:
+		return NewEnvSigner(operatorKeyEnv)
+	case SignerTypeRemote:
+		return NewRemoteSigner(config.AppConfig.OperatorSignerURL, config.AppConfig.OperatorSignerMethod, config.AppConfig.OperatorAddress)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSignerType, signerType)
+	}
+}
+
+// NewSignerTransactor returns transact options that sign with a Signer, for
+// use with the contract bindings
+func NewSignerTransactor(ctx context.Context, signer Signer, chainID *big.Int) *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From:    signer.Address(),
+		Context: ctx,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != signer.Address() {
+				return nil, bind.ErrNotAuthorized
+			}
+			return signer.SignTx(ctx, tx, chainID)
+		},
+	}
+}
+
+// wipeKey overwrites a private key's scalar once it is no longer needed
+func wipeKey(key *ecdsa.PrivateKey) {
+	clear(key.D.Bits())
+}
+
+// LocalSigner signs with a key loaded from a file or the environment. The
+// key is parsed from bytes that are wiped straight away; formatting the
+// signer prints only its address.
+type LocalSigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewFileSigner loads a hex private key from a file, such as a mounted secret
+func NewFileSigner(path string) (*LocalSigner, error) {
+	if path == "" {
+		return nil, fmt.Errorf("operator key file not configured")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read operator key file: %v", err)
+	}
+	defer clear(data)
+
+	return newLocalSigner(data)
+}
+
+// NewEnvSigner loads a hex private key from an environment variable and
+// removes the variable. Go strings are immutable, so the string the runtime
+// read from the environment stays in memory until it is collected; only the
+// copy parsed here is wiped.
+func NewEnvSigner(name string) (*LocalSigner, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return nil, fmt.Errorf("%s is not set", name)
+	}
+	if err := os.Unsetenv(name); err != nil {
+		return nil, fmt.Errorf("failed to clear %s: %v", name, err)
+	}
+
+	data := []byte(value)
+	defer clear(data)
+
+	return newLocalSigner(data)
+}
+
+func newLocalSigner(data []byte) (*LocalSigner, error) {
+	data = bytes.TrimPrefix(bytes.TrimSpace(data), []byte("0x"))
+
+	raw := make([]byte, hex.DecodedLen(len(data)))
+	defer clear(raw)
+	if _, err := hex.Decode(raw, data); err != nil {
+		return nil, fmt.Errorf("invalid operator key")
+	}
+
+	key, err := crypto.ToECDSA(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid operator key")
+	}
+
+	return &LocalSigner{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}, nil
+}
+
+// Address returns the signer's account
+func (s *LocalSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx signs a transaction for chainID
+func (s *LocalSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key)
+}
+
+// String prints the signer's address, never its key
+func (s *LocalSigner) String() string {
+	return "LocalSigner(" + s.address.Hex() + ")"
+}
+
+// GoString keeps %#v from printing the key
+func (s *LocalSigner) GoString() string {
+	return s.String()
+}
+
+// KeystoreSigner signs with a geth encrypted keystore file. The passphrase
+// is not kept: it is re-read from its file for each signature, and the key
+// decrypted with it is wiped afterwards, so neither is in memory between
+// signatures.
+type KeystoreSigner struct {
+	keyJSON      []byte
+	passwordFile string
+	address      common.Address
+}
+
+// NewKeystoreSigner loads a keystore file and checks its passphrase, read
+// from passwordFile
+func NewKeystoreSigner(path, passwordFile string) (*KeystoreSigner, error) {
+	if path == "" || passwordFile == "" {
+		return nil, fmt.Errorf("operator keystore and password file must both be configured")
+	}
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read operator keystore: %v", err)
+	}
+
+	s := &KeystoreSigner{
+		keyJSON:      keyJSON,
+		passwordFile: passwordFile,
+	}
+	key, err := s.decrypt()
+	if err != nil {
+		return nil, err
+	}
+	s.address = key.Address
+	wipeKey(key.PrivateKey)
+
+	return s, nil
+}
+
+// decrypt reads the passphrase and decrypts the key. The keystore package
+// takes the passphrase as a string, which cannot be wiped; it is only
+// referenced for the length of the call.
+func (s *KeystoreSigner) decrypt() (*keystore.Key, error) {
+	password, err := os.ReadFile(s.passwordFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read operator keystore password: %v", err)
+	}
+	defer clear(password)
+
+	key, err := keystore.DecryptKey(s.keyJSON, string(bytes.TrimRight(password, "\r\n")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt operator keystore: %v", err)
+	}
+	return key, nil
+}
+
+// Address returns the signer's account
+func (s *KeystoreSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx decrypts the key, signs a transaction for chainID and wipes the key
+func (s *KeystoreSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	key, err := s.decrypt()
+	if err != nil {
+		return nil, err
+	}
+	defer wipeKey(key.PrivateKey)
+
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), key.PrivateKey)
+}
+
+// String prints the signer's address
+func (s *KeystoreSigner) String() string {
+	return "KeystoreSigner(" + s.address.Hex() + ")"
+}
+
+// GoString keeps %#v to the signer's address
+func (s *KeystoreSigner) GoString() string {
+	return s.String()
+}
+
+// RemoteSigner asks an external signer over HTTP JSON-RPC to sign, in the
+// style of Clef (account_signTransaction) or web3signer
+// (eth_signTransaction). The key never reaches the backend.
+type RemoteSigner struct {
+	client  *rpc.Client
+	method  string
+	address common.Address
+}
+
+// NewRemoteSigner connects to a remote signer that holds address's key
+func NewRemoteSigner(url, method, address string) (*RemoteSigner, error) {
+	if url == "" {
+		return nil, fmt.Errorf("operator signer URL not configured")
+	}
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid operator address: %s", address)
+	}
+	if method == "" {
+		method = "eth_signTransaction"
+	}
+
+	client, err := rpc.DialHTTP(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to remote signer: %v", err)
+	}
+
+	return &RemoteSigner{client: client, method: method, address: common.HexToAddress(address)}, nil
+}
+
+// remoteSignerArgs are the transaction arguments shared by Clef and web3signer
+type remoteSignerArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to,omitempty"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+}
+
+// decodeSignedTx reads a signer's reply: the raw transaction as a hex
+// string (web3signer), or an object with a raw field (Clef, geth)
+func decodeSignedTx(result json.RawMessage) (*types.Transaction, error) {
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err != nil {
+		var reply struct {
+			Raw hexutil.Bytes `json:"raw"`
+		}
+		if err := json.Unmarshal(result, &reply); err != nil || len(reply.Raw) == 0 {
+			return nil, fmt.Errorf("unexpected remote signer response")
+		}
+		raw = reply.Raw
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("failed to decode signed transaction: %v", err)
+	}
+	return tx, nil
+}
+
+// Address returns the signer's account
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx has the remote signer sign a transaction for chainID. The reply is
+// checked to be the same transaction, signed by the expected account.
+func (s *RemoteSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	args := remoteSignerArgs{
+		From:    s.address,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   (*hexutil.Big)(tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    tx.Data(),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if tx.Type() == types.LegacyTxType {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	} else {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	}
+
+	var result json.RawMessage
+	if err := s.client.CallContext(ctx, &result, s.method, args); err != nil {
+		return nil, fmt.Errorf("remote signer failed: %v", err)
+	}
+	signed, err := decodeSignedTx(result)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := types.LatestSignerForChainID(chainID)
+	if signer.Hash(signed) != signer.Hash(tx) {
+		return nil, ErrSignerMismatch
+	}
+	sender, err := types.Sender(signer, signed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote signature: %v", err)
+	}
+	if sender != s.address {
+		return nil, fmt.Errorf("remote signer signed as %s, expected %s", sender.Hex(), s.address.Hex())
+	}
+
+	return signed, nil
+}
+
+// Close closes the connection to the remote signer
+func (s *RemoteSigner) Close() {
+	s.client.Close()
+}
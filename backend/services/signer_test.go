@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testChainID = big.NewInt(11155111)
+
+func testTx() *types.Transaction {
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   testChainID,
+		Nonce:     7,
+		GasTipCap: big.NewInt(1e9),
+		GasFeeCap: big.NewInt(30e9),
+		Gas:       60000,
+		To:        &to,
+		Value:     big.NewInt(0),
+		Data:      []byte{0x01, 0x02},
+	})
+}
+
+// assertSignedBy checks a signed transaction recovers to address
+func assertSignedBy(t *testing.T, signed *types.Transaction, address common.Address) {
+	sender, err := types.Sender(types.LatestSignerForChainID(testChainID), signed)
+	require.NoError(t, err)
+	assert.Equal(t, address, sender)
+}
+
+// assertKeyHidden checks no formatting verb prints the key
+func assertKeyHidden(t *testing.T, signer interface{}, secrets ...string) {
+	for _, verb := range []string{"%v", "%+v", "%#v", "%s"} {
+		out := fmt.Sprintf(verb, signer)
+		for _, secret := range secrets {
+			assert.NotContains(t, out, secret, verb)
+		}
+	}
+}
+
+func newTestKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return key, hex.EncodeToString(crypto.FromECDSA(key))
+}
+
+func TestFileSigner(t *testing.T) {
+	key, keyHex := newTestKey(t)
+	path := filepath.Join(t.TempDir(), "operator.key")
+	require.NoError(t, os.WriteFile(path, []byte("0x"+keyHex+"\n"), 0600))
+
+	signer, err := NewFileSigner(path)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer.Address())
+
+	signed, err := signer.SignTx(context.Background(), testTx(), testChainID)
+	require.NoError(t, err)
+	assertSignedBy(t, signed, signer.Address())
+	assertKeyHidden(t, signer, keyHex)
+
+	require.NoError(t, os.WriteFile(path, []byte("not a key"), 0600))
+	_, err = NewFileSigner(path)
+	assert.Error(t, err)
+}
+
+func TestEnvSigner(t *testing.T) {
+	key, keyHex := newTestKey(t)
+	t.Setenv("TEST_OPERATOR_KEY", keyHex)
+
+	signer, err := NewEnvSigner("TEST_OPERATOR_KEY")
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer.Address())
+
+	// The key is removed from the environment once read
+	_, ok := os.LookupEnv("TEST_OPERATOR_KEY")
+	assert.False(t, ok)
+	_, err = NewEnvSigner("TEST_OPERATOR_KEY")
+	assert.Error(t, err)
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	key, keyHex := newTestKey(t)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{
+		Id:         uuid.New(),
+		Address:    address,
+		PrivateKey: key,
+	}, "correct horse", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "keystore.json")
+	passwordPath := filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(keyPath, keyJSON, 0600))
+	require.NoError(t, os.WriteFile(passwordPath, []byte("correct horse\n"), 0600))
+
+	signer, err := NewKeystoreSigner(keyPath, passwordPath)
+	require.NoError(t, err)
+	assert.Equal(t, address, signer.Address())
+
+	signed, err := signer.SignTx(context.Background(), testTx(), testChainID)
+	require.NoError(t, err)
+	assertSignedBy(t, signed, address)
+	assertKeyHidden(t, signer, keyHex, "correct horse")
+
+	// The passphrase is read from its file for each signature, not kept
+	require.NoError(t, os.WriteFile(passwordPath, []byte("wrong"), 0600))
+	_, err = signer.SignTx(context.Background(), testTx(), testChainID)
+	assert.Error(t, err)
+	_, err = NewKeystoreSigner(keyPath, passwordPath)
+	assert.Error(t, err)
+}
+
+// fakeRemoteSigner speaks enough JSON-RPC to stand in for Clef or
+// web3signer. tamper, if set, changes the transaction before signing.
+func fakeRemoteSigner(t *testing.T, key *ecdsa.PrivateKey, tamper func(*types.DynamicFeeTx)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage    `json:"id"`
+			Method string             `json:"method"`
+			Params []remoteSignerArgs `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		args := req.Params[0]
+
+		inner := &types.DynamicFeeTx{
+			ChainID:   args.ChainID.ToInt(),
+			Nonce:     uint64(args.Nonce),
+			GasTipCap: args.MaxPriorityFeePerGas.ToInt(),
+			GasFeeCap: args.MaxFeePerGas.ToInt(),
+			Gas:       uint64(args.Gas),
+			To:        args.To,
+			Value:     args.Value.ToInt(),
+			Data:      args.Data,
+		}
+		if tamper != nil {
+			tamper(inner)
+		}
+		signed, err := types.SignNewTx(key, types.LatestSignerForChainID(inner.ChainID), inner)
+		require.NoError(t, err)
+		raw, err := signed.MarshalBinary()
+		require.NoError(t, err)
+
+		var result interface{} = hexutil.Bytes(raw)
+		if req.Method == "account_signTransaction" {
+			result = map[string]interface{}{"raw": hexutil.Bytes(raw), "tx": signed}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+}
+
+func TestRemoteSigner(t *testing.T) {
+	key, _ := newTestKey(t)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	for _, method := range []string{"eth_signTransaction", "account_signTransaction"} {
+		t.Run(method, func(t *testing.T) {
+			server := fakeRemoteSigner(t, key, nil)
+			defer server.Close()
+
+			signer, err := NewRemoteSigner(server.URL, method, address.Hex())
+			require.NoError(t, err)
+			defer signer.Close()
+
+			tx := testTx()
+			signed, err := signer.SignTx(context.Background(), tx, testChainID)
+			require.NoError(t, err)
+			assertSignedBy(t, signed, address)
+			assert.Equal(t, tx.Nonce(), signed.Nonce())
+		})
+	}
+
+	t.Run("changed transaction", func(t *testing.T) {
+		server := fakeRemoteSigner(t, key, func(tx *types.DynamicFeeTx) { tx.Nonce++ })
+		defer server.Close()
+
+		signer, err := NewRemoteSigner(server.URL, "", address.Hex())
+		require.NoError(t, err)
+		defer signer.Close()
+
+		_, err = signer.SignTx(context.Background(), testTx(), testChainID)
+		assert.ErrorIs(t, err, ErrSignerMismatch)
+	})
+
+	t.Run("wrong account", func(t *testing.T) {
+		other, _ := newTestKey(t)
+		server := fakeRemoteSigner(t, other, nil)
+		defer server.Close()
+
+		signer, err := NewRemoteSigner(server.URL, "", address.Hex())
+		require.NoError(t, err)
+		defer signer.Close()
+
+		_, err = signer.SignTx(context.Background(), testTx(), testChainID)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/mattn/go-sqlite3"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/models"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// testDriver is SQLite with the Postgres functions the models use
+const testDriver = "sqlite3_revibe"
+
+func init() {
+	sql.Register(testDriver, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			return conn.RegisterFunc("gen_random_uuid", uuid.NewString, false)
+		},
+	})
+}
+
+// testDialector is the SQLite dialector with a migrator that writes the
+// models' Postgres column defaults in SQLite's syntax
+type testDialector struct {
+	*sqlite.Dialector
+}
+
+func (d testDialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return testMigrator{d.Dialector.Migrator(db)}
+}
+
+type testMigrator struct {
+	gorm.Migrator
+}
+
+// FullDataTypeOf wraps function defaults such as gen_random_uuid() in the
+// parentheses SQLite requires
+func (m testMigrator) FullDataTypeOf(field *schema.Field) clause.Expr {
+	if strings.HasSuffix(field.DefaultValue, "()") {
+		wrapped := *field
+		wrapped.DefaultValue = "(" + field.DefaultValue + ")"
+		return m.Migrator.FullDataTypeOf(&wrapped)
+	}
+	return m.Migrator.FullDataTypeOf(field)
+}
+
+// newTestDB opens an empty database in the test's temp dir with every model
+// migrated
+func newTestDB(t *testing.T) *gorm.DB {
+	dsn := filepath.Join(t.TempDir(), "revibe.db") + "?_busy_timeout=5000"
+	db, err := gorm.Open(testDialector{&sqlite.Dialector{DriverName: testDriver, DSN: dsn}}, &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	require.NoError(t, models.AutoMigrate(db))
+	return db
+}
+
+// createUser creates an account with a wallet
+func createUser(t *testing.T, db *gorm.DB, wallet string) *models.User {
+	user := models.User{Name: "user " + wallet[:8], WalletAddress: wallet}
+	require.NoError(t, db.Create(&user).Error)
+	return &user
+}
+
+// createProduct creates an approved, unminted product for a seller
+func createProduct(t *testing.T, db *gorm.DB, seller *models.User) *models.Product {
+	product := models.Product{
+		Name:        "Test sneakers",
+		Description: "Worn twice",
+		Price:       0.1,
+		Category:    "sneakers",
+		Condition:   "like_new",
+		SellerID:    seller.ID,
+	}
+	require.NoError(t, db.Create(&product).Error)
+	return &product
+}
+
+// testMarket is the backend's chain pipeline over a test database: orders,
+// checkout holds in an in-memory Redis, the ledger, and an indexer following
+// one deployment with the projection as its handler
+type testMarket struct {
+	t            *testing.T
+	db           *gorm.DB
+	web3         *Web3Service
+	registry     *DeploymentRegistry
+	redis        *miniredis.Miniredis
+	reservations *ReservationService
+	ledger       *LedgerService
+	orders       *OrderService
+	projection   *ProjectionService
+	indexer      *IndexerService
+}
+
+func newTestMarket(t *testing.T, web3 *Web3Service) *testMarket {
+	db := newTestDB(t)
+	registry, err := newDeploymentRegistry([]*Web3Service{web3}, web3.ChainID().Int64())
+	require.NoError(t, err)
+
+	server := miniredis.RunT(t)
+	reservations := &ReservationService{
+		db:    db,
+		redis: redis.NewClient(&redis.Options{Addr: server.Addr()}),
+		ttl:   15 * time.Minute,
+	}
+	ledger := NewLedgerService(db, registry)
+	orders := NewOrderService(db, registry, reservations, ledger)
+	certificates := newTestCertificateService(t, testSigningSeed)
+	certificates.db = db
+	expiry := &AuthExpiryService{db: db, certificateService: certificates}
+	projection := NewProjectionService(db, orders, ledger, certificates, expiry)
+
+	indexer := &IndexerService{db: db, web3Service: web3, startBlock: 1, blockRange: 100}
+	indexer.AddHandler(projection)
+
+	return &testMarket{
+		t:            t,
+		db:           db,
+		web3:         web3,
+		registry:     registry,
+		redis:        server,
+		reservations: reservations,
+		ledger:       ledger,
+		orders:       orders,
+		projection:   projection,
+		indexer:      indexer,
+	}
+}
+
+// sync indexes the chain up to its head
+func (m *testMarket) sync() {
+	require.NoError(m.t, m.indexer.Sync(context.Background()))
+}
+
+// reload reads a record back from the database. The record is zeroed
+// first, as GORM leaves fields it scans NULL into unchanged.
+func (m *testMarket) reload(record interface{}, id string) {
+	value := reflect.ValueOf(record).Elem()
+	value.Set(reflect.Zero(value.Type()))
+	require.NoError(m.t, m.db.Unscoped().First(record, "id = ?", id).Error)
+}
+
+// count counts a model's rows matching a condition
+func (m *testMarket) count(model interface{}, query string, args ...interface{}) int64 {
+	var n int64
+	require.NoError(m.t, m.db.Model(model).Where(query, args...).Count(&n).Error)
+	return n
+}
+
+// chainFixture is a test market on the mock chain with a seller and a buyer
+// who both have accounts
+type chainFixture struct {
+	*testMarket
+	chain        *mockChain
+	chainID      int64
+	contract     string
+	seller       *models.User
+	sellerWallet common.Address
+	buyer        *models.User
+	buyerWallet  common.Address
+}
+
+func newChainFixture(t *testing.T) *chainFixture {
+	chain := newMockChain(t)
+	market := newTestMarket(t, chain.web3)
+	f := &chainFixture{
+		testMarket:   market,
+		chain:        chain,
+		chainID:      chain.chainID.Int64(),
+		contract:     mockReVibeAddress.Hex(),
+		sellerWallet: common.HexToAddress("0x5e11e5"),
+		buyerWallet:  common.HexToAddress("0xb0b"),
+	}
+	f.seller = createUser(t, market.db, f.sellerWallet.Hex())
+	f.buyer = createUser(t, market.db, f.buyerWallet.Hex())
+	return f
+}
+
+// mintedProduct creates an approved product linked to a token of the mock
+// contract and owned by its seller, priced at 0.1 ETH
+func (f *chainFixture) mintedProduct(tokenID string) *models.Product {
+	product := createProduct(f.t, f.db, f.seller)
+	require.NoError(f.t, f.db.Model(product).Updates(map[string]interface{}{
+		"token_id":         tokenID,
+		"chain_id":         f.chainID,
+		"contract_address": f.contract,
+		"owner_address":    f.sellerWallet.Hex(),
+		"price_wei":        "100000000000000000",
+	}).Error)
+	f.reload(product, product.ID)
+	return product
+}
+
+// txHash returns a made-up transaction hash
+func txHash(n int64) string {
+	return common.BigToHash(big.NewInt(n)).Hex()
+}
@@ -0,0 +1,779 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/yourusername/revibe/backend/config"
+	"github.com/yourusername/revibe/backend/models"
+	"github.com/yourusername/revibe/backend/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// gasLimitMargin is added to estimates, in percent, since state can
+	// change between estimation and inclusion
+	gasLimitMargin = 20
+	// minFeeBumpPercent is the smallest increase nodes accept for a
+	// replacement transaction
+	minFeeBumpPercent = 10
+)
+
+var (
+	ErrNoSigner    = errors.New("no signer registered for sender")
+	ErrFeeCapped   = errors.New("transaction fees have reached the configured maximum")
+	ErrWouldRevert = errors.New("transaction would revert")
+	errDuplicateTx = errors.New("transaction reference already submitted")
+)
+
+// TxRequest is a transaction for the manager to send on ChainID, or on the
+// active deployment's chain if it is zero. A request whose Reference was
+// already submitted returns the earlier transaction instead of sending again.
+type TxRequest struct {
+	ChainID   int64
+	From      common.Address
+	To        *common.Address
+	Value     *big.Int
+	Data      []byte
+	Reference string
+}
+
+// txFees are the gas prices of one attempt: GasPrice on legacy chains, the
+// fee and tip caps on EIP-1559 chains
+type txFees struct {
+	GasPrice *big.Int
+	FeeCap   *big.Int
+	TipCap   *big.Int
+}
+
+// bumpFee raises a fee by percent, rounding up, or to the suggested fee if
+// that is higher
+func bumpFee(old, suggested *big.Int, percent int64) *big.Int {
+	bumped := new(big.Int).Mul(old, big.NewInt(100+percent))
+	bumped.Add(bumped, big.NewInt(99))
+	bumped.Quo(bumped, big.NewInt(100))
+	if bumped.Cmp(old) <= 0 {
+		bumped.Add(old, common.Big1)
+	}
+	if suggested != nil && suggested.Cmp(bumped) > 0 {
+		return new(big.Int).Set(suggested)
+	}
+	return bumped
+}
+
+// bumpFees returns the fees for a replacement of an attempt. It returns
+// false if the replacement would have to pay more than maxFee per gas.
+func bumpFees(old, suggested txFees, percent int64, maxFee *big.Int) (txFees, bool) {
+	if old.GasPrice != nil {
+		price := bumpFee(old.GasPrice, suggested.GasPrice, percent)
+		return txFees{GasPrice: price}, price.Cmp(maxFee) <= 0
+	}
+
+	tip := bumpFee(old.TipCap, suggested.TipCap, percent)
+	feeCap := bumpFee(old.FeeCap, suggested.FeeCap, percent)
+	if feeCap.Cmp(tip) < 0 {
+		feeCap = new(big.Int).Set(tip)
+	}
+	return txFees{FeeCap: feeCap, TipCap: tip}, feeCap.Cmp(maxFee) <= 0
+}
+
+// capFees limits first-attempt fees to maxFee per gas
+func capFees(fees txFees, maxFee *big.Int) txFees {
+	if fees.GasPrice != nil {
+		if fees.GasPrice.Cmp(maxFee) > 0 {
+			fees.GasPrice = new(big.Int).Set(maxFee)
+		}
+		return fees
+	}
+	if fees.FeeCap.Cmp(maxFee) > 0 {
+		fees.FeeCap = new(big.Int).Set(maxFee)
+	}
+	if fees.TipCap.Cmp(fees.FeeCap) > 0 {
+		fees.TipCap = new(big.Int).Set(fees.FeeCap)
+	}
+	return fees
+}
+
+// attemptFees reads back the fees an attempt was signed with
+func attemptFees(attempt *models.TransactionAttempt) txFees {
+	parse := func(value string) *big.Int {
+		if value == "" {
+			return nil
+		}
+		n, _ := new(big.Int).SetString(value, 10)
+		return n
+	}
+	return txFees{
+		GasPrice: parse(attempt.GasPrice),
+		FeeCap:   parse(attempt.MaxFeePerGas),
+		TipCap:   parse(attempt.MaxPriorityFeePerGas),
+	}
+}
+
+// isAlreadySent reports whether a broadcast error means the node already
+// has the transaction, or another one with its nonce
+func isAlreadySent(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "already known") ||
+		strings.Contains(msg, "known transaction") ||
+		strings.Contains(msg, "nonce too low")
+}
+
+// isRejected reports whether a broadcast error means no node will accept
+// the transaction as signed, however often it is sent
+func isRejected(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, reason := range []string{
+		"insufficient funds",
+		"intrinsic gas too low",
+		"exceeds block gas limit",
+		"invalid sender",
+		"transaction type not supported",
+		"oversized data",
+	} {
+		if strings.Contains(msg, reason) {
+			return true
+		}
+	}
+	return false
+}
+
+// TransactionManager sends transactions signed by the backend's own
+// accounts. It allocates each sender's nonces, estimates gas and fees,
+// persists every signed attempt before broadcasting it, follows pending
+// transactions until they are mined and replaces stuck ones with higher fees.
+type TransactionManager struct {
+	db            *gorm.DB
+	registry      *DeploymentRegistry
+	pollInterval  time.Duration
+	stuckAfter    time.Duration
+	bumpPercent   int64
+	maxFee        *big.Int
+	confirmations uint64
+
+	mu      sync.RWMutex
+	signers map[common.Address]Signer
+}
+
+// NewTransactionManager creates a new TransactionManager instance
+func NewTransactionManager(db *gorm.DB, registry *DeploymentRegistry) *TransactionManager {
+	bumpPercent := int64(config.AppConfig.TxFeeBumpPercent)
+	if bumpPercent < minFeeBumpPercent {
+		bumpPercent = minFeeBumpPercent
+	}
+
+	return &TransactionManager{
+		db:            db,
+		registry:      registry,
+		pollInterval:  config.AppConfig.TxPollInterval,
+		stuckAfter:    config.AppConfig.TxStuckAfter,
+		bumpPercent:   bumpPercent,
+		maxFee:        new(big.Int).Mul(big.NewInt(int64(config.AppConfig.TxMaxFeeGwei)), big.NewInt(1e9)),
+		confirmations: uint64(config.AppConfig.IndexerConfirmations),
+		signers:       make(map[common.Address]Signer),
+	}
+}
+
+// RegisterSigner lets the manager send transactions from signer's account
+func (m *TransactionManager) RegisterSigner(signer Signer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.signers[signer.Address()] = signer
+}
+
+// chain returns a deployment on a chain, or the active deployment for chain
+// ID zero, for its chain backend
+func (m *TransactionManager) chain(chainID int64) (*Web3Service, error) {
+	if chainID == 0 {
+		return m.registry.Active(), nil
+	}
+	return m.registry.Chain(chainID)
+}
+
+func (m *TransactionManager) signer(from common.Address) (Signer, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	signer, ok := m.signers[from]
+	if !ok {
+		return nil, ErrNoSigner
+	}
+	return signer, nil
+}
+
+// Submit signs and sends a transaction. The transaction is stored with its
+// nonce before it is broadcast; if the broadcast fails it is retried by the
+// manager's loop, so the returned transaction is pending either way. Calls
+// that would revert fail here with ErrWouldRevert, before a nonce is used;
+// transactions nodes reject are cancelled, see send.
+func (m *TransactionManager) Submit(ctx context.Context, req TxRequest) (*models.ManagedTransaction, error) {
+	if req.Reference != "" {
+		existing, err := m.findByReference(req.Reference)
+		if err == nil {
+			return existing, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	signer, err := m.signer(req.From)
+	if err != nil {
+		return nil, err
+	}
+
+	web3Service, err := m.chain(req.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	backend, err := web3Service.pin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	value := req.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	gas, err := backend.EstimateGas(ctx, ethereum.CallMsg{From: req.From, To: req.To, Value: value, Data: req.Data})
+	if err != nil {
+		if reason, ok := revertReason(err); ok && reason != "" {
+			return nil, fmt.Errorf("%w: %s", ErrWouldRevert, reason)
+		} else if ok {
+			return nil, ErrWouldRevert
+		}
+		return nil, fmt.Errorf("failed to estimate gas: %v", err)
+	}
+
+	fees, err := m.suggestFees(ctx, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	mtx := models.ManagedTransaction{
+		ChainID:     web3Service.ChainID().Int64(),
+		FromAddress: req.From.Hex(),
+		ValueWei:    value.String(),
+		GasLimit:    gas * (100 + gasLimitMargin) / 100,
+		Status:      models.TxStatusPending,
+	}
+	if req.Reference != "" {
+		mtx.Reference = &req.Reference
+	}
+	if req.To != nil {
+		mtx.ToAddress = req.To.Hex()
+	}
+	if len(req.Data) > 0 {
+		mtx.Data = hexutil.Encode(req.Data)
+	}
+
+	var attempt *models.TransactionAttempt
+	err = m.db.Transaction(func(tx *gorm.DB) error {
+		nonce, err := m.allocateNonce(ctx, tx, backend, mtx.ChainID, req.From)
+		if err != nil {
+			return err
+		}
+		mtx.Nonce = nonce
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&mtx)
+		if result.Error != nil {
+			return fmt.Errorf("failed to store transaction: %v", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errDuplicateTx
+		}
+
+		attempt, err = m.sign(ctx, tx, &mtx, signer, fees, false)
+		return err
+	})
+	if errors.Is(err, errDuplicateTx) {
+		return m.findByReference(req.Reference)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	mtx.Attempts = []models.TransactionAttempt{*attempt}
+	if err := m.send(ctx, &mtx, &mtx.Attempts[0]); err != nil {
+		utils.LogError(err, map[string]interface{}{
+			"component": "tx_manager",
+			"tx_id":     mtx.ID,
+			"tx_hash":   attempt.TxHash,
+		})
+	}
+
+	return &mtx, nil
+}
+
+func (m *TransactionManager) findByReference(reference string) (*models.ManagedTransaction, error) {
+	var mtx models.ManagedTransaction
+	if err := m.db.Preload("Attempts").First(&mtx, "reference = ?", reference).Error; err != nil {
+		return nil, err
+	}
+	return &mtx, nil
+}
+
+// allocateNonce hands out the sender's next nonce under a row lock, so
+// concurrent submissions never share one. The stored nonce catches up with
+// the chain if the account has sent transactions from elsewhere.
+func (m *TransactionManager) allocateNonce(ctx context.Context, tx *gorm.DB, backend ChainBackend, chainID int64, from common.Address) (uint64, error) {
+	row := models.SenderNonce{ChainID: chainID, Address: from.Hex()}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&row).Error; err != nil {
+		return 0, fmt.Errorf("failed to create sender nonce: %v", err)
+	}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("chain_id = ? AND address = ?", row.ChainID, row.Address).
+		First(&row).Error; err != nil {
+		return 0, fmt.Errorf("failed to lock sender nonce: %v", err)
+	}
+
+	pending, err := backend.PendingNonceAt(ctx, from)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get nonce: %v", err)
+	}
+	if pending > row.NextNonce {
+		row.NextNonce = pending
+	}
+
+	nonce := row.NextNonce
+	row.NextNonce++
+	if err := tx.Save(&row).Error; err != nil {
+		return 0, fmt.Errorf("failed to update sender nonce: %v", err)
+	}
+	return nonce, nil
+}
+
+// suggestFees returns the network's current fees, capped at the maximum
+func (m *TransactionManager) suggestFees(ctx context.Context, backend ChainBackend) (txFees, error) {
+	header, err := backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return txFees{}, fmt.Errorf("failed to get latest header: %v", err)
+	}
+
+	if header.BaseFee == nil {
+		price, err := backend.SuggestGasPrice(ctx)
+		if err != nil {
+			return txFees{}, fmt.Errorf("failed to suggest gas price: %v", err)
+		}
+		return capFees(txFees{GasPrice: price}, m.maxFee), nil
+	}
+
+	tip, err := backend.SuggestGasTipCap(ctx)
+	if err != nil {
+		return txFees{}, fmt.Errorf("failed to suggest gas tip: %v", err)
+	}
+	// Leave room for the base fee to double before the transaction is priced out
+	feeCap := new(big.Int).Mul(header.BaseFee, big.NewInt(2))
+	feeCap.Add(feeCap, tip)
+	return capFees(txFees{FeeCap: feeCap, TipCap: tip}, m.maxFee), nil
+}
+
+// sign signs a transaction at the given fees and stores it as a new
+// attempt. A cancellation is a plain transfer of nothing from the sender to
+// itself at the transaction's nonce.
+func (m *TransactionManager) sign(ctx context.Context, tx *gorm.DB, mtx *models.ManagedTransaction, signer Signer, fees txFees, cancel bool) (*models.TransactionAttempt, error) {
+	var to *common.Address
+	if mtx.ToAddress != "" {
+		address := common.HexToAddress(mtx.ToAddress)
+		to = &address
+	}
+	value, _ := new(big.Int).SetString(mtx.ValueWei, 10)
+	data := common.FromHex(mtx.Data)
+	gas := mtx.GasLimit
+	if cancel {
+		from := common.HexToAddress(mtx.FromAddress)
+		to, value, data, gas = &from, new(big.Int), nil, params.TxGas
+	}
+
+	chainID := big.NewInt(mtx.ChainID)
+	var unsigned *types.Transaction
+	if fees.GasPrice != nil {
+		unsigned = types.NewTx(&types.LegacyTx{
+			Nonce:    mtx.Nonce,
+			GasPrice: fees.GasPrice,
+			Gas:      gas,
+			To:       to,
+			Value:    value,
+			Data:     data,
+		})
+	} else {
+		unsigned = types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     mtx.Nonce,
+			GasTipCap: fees.TipCap,
+			GasFeeCap: fees.FeeCap,
+			Gas:       gas,
+			To:        to,
+			Value:     value,
+			Data:      data,
+		})
+	}
+
+	signed, err := signer.SignTx(ctx, unsigned, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %v", err)
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction: %v", err)
+	}
+
+	attempt := models.TransactionAttempt{
+		TransactionID: mtx.ID,
+		TxHash:        signed.Hash().Hex(),
+		RawTx:         hexutil.Encode(raw),
+		Cancel:        cancel,
+	}
+	if fees.GasPrice != nil {
+		attempt.GasPrice = fees.GasPrice.String()
+	} else {
+		attempt.MaxFeePerGas = fees.FeeCap.String()
+		attempt.MaxPriorityFeePerGas = fees.TipCap.String()
+	}
+	if err := tx.Create(&attempt).Error; err != nil {
+		return nil, fmt.Errorf("failed to store transaction attempt: %v", err)
+	}
+
+	mtx.TxHash = attempt.TxHash
+	if err := tx.Model(mtx).Update("tx_hash", attempt.TxHash).Error; err != nil {
+		return nil, fmt.Errorf("failed to update transaction: %v", err)
+	}
+	return &attempt, nil
+}
+
+// broadcast sends a stored attempt on its chain and records when it was
+// accepted
+func (m *TransactionManager) broadcast(ctx context.Context, chainID int64, attempt *models.TransactionAttempt) error {
+	var signed types.Transaction
+	if err := signed.UnmarshalBinary(common.FromHex(attempt.RawTx)); err != nil {
+		return fmt.Errorf("failed to decode transaction: %v", err)
+	}
+
+	web3Service, err := m.chain(chainID)
+	if err != nil {
+		return err
+	}
+	backend, err := web3Service.pin(ctx)
+	if err != nil {
+		return err
+	}
+	if err := backend.SendTransaction(ctx, &signed); err != nil && !isAlreadySent(err) {
+		return fmt.Errorf("failed to send transaction: %v", err)
+	}
+
+	now := time.Now()
+	attempt.SentAt = &now
+	return m.db.Model(attempt).Update("sent_at", now).Error
+}
+
+// send broadcasts an attempt. A transaction nodes reject outright, such as
+// one its sender cannot pay for, would hold up every later nonce of the
+// sender, so its nonce is used up by a cancellation instead and the
+// transaction fails once that is mined.
+func (m *TransactionManager) send(ctx context.Context, mtx *models.ManagedTransaction, attempt *models.TransactionAttempt) error {
+	err := m.broadcast(ctx, mtx.ChainID, attempt)
+	if err == nil || attempt.Cancel || !isRejected(err) {
+		return err
+	}
+	return m.cancel(ctx, mtx, err)
+}
+
+// cancel records why a transaction was rejected and signs and sends a
+// cancellation at its nonce, outbidding any attempt a node already holds
+func (m *TransactionManager) cancel(ctx context.Context, mtx *models.ManagedTransaction, reason error) error {
+	signer, err := m.signer(common.HexToAddress(mtx.FromAddress))
+	if err != nil {
+		return err
+	}
+
+	web3Service, err := m.chain(mtx.ChainID)
+	if err != nil {
+		return err
+	}
+	backend, err := web3Service.pin(ctx)
+	if err != nil {
+		return err
+	}
+	fees, err := m.suggestFees(ctx, backend)
+	if err != nil {
+		return err
+	}
+	for i := len(mtx.Attempts) - 1; i >= 0; i-- {
+		if mtx.Attempts[i].SentAt != nil {
+			var ok bool
+			if fees, ok = bumpFees(attemptFees(&mtx.Attempts[i]), fees, m.bumpPercent, m.maxFee); !ok {
+				return ErrFeeCapped
+			}
+			break
+		}
+	}
+
+	var attempt *models.TransactionAttempt
+	err = m.db.Transaction(func(tx *gorm.DB) error {
+		mtx.Error = fmt.Sprintf("rejected by node: %v", reason)
+		if err := tx.Model(mtx).Update("error", mtx.Error).Error; err != nil {
+			return fmt.Errorf("failed to update transaction: %v", err)
+		}
+		attempt, err = m.sign(ctx, tx, mtx, signer, fees, true)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	mtx.Attempts = append(mtx.Attempts, *attempt)
+
+	utils.LogWarning("cancelling rejected transaction", map[string]interface{}{
+		"component": "tx_manager",
+		"tx_id":     mtx.ID,
+		"tx_hash":   attempt.TxHash,
+		"error":     reason.Error(),
+	})
+	return m.broadcast(ctx, mtx.ChainID, &mtx.Attempts[len(mtx.Attempts)-1])
+}
+
+// StartTransactionManager follows pending transactions until ctx is cancelled
+func (m *TransactionManager) StartTransactionManager(ctx context.Context) {
+	ticker := time.NewTicker(m.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.ProcessPending(ctx); err != nil {
+				utils.LogError(err, map[string]interface{}{
+					"component": "tx_manager",
+				})
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ProcessPending checks every pending transaction on the deployments' chains
+// once
+func (m *TransactionManager) ProcessPending(ctx context.Context) error {
+	var txs []models.ManagedTransaction
+	if err := m.db.Preload("Attempts", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at asc")
+	}).
+		Where("status = ? AND chain_id IN ?", models.TxStatusPending, m.registry.ChainIDs()).
+		Order("from_address, nonce").
+		Find(&txs).Error; err != nil {
+		return fmt.Errorf("failed to fetch pending transactions: %v", err)
+	}
+
+	for i := range txs {
+		if err := m.check(ctx, &txs[i]); err != nil {
+			utils.LogError(err, map[string]interface{}{
+				"component": "tx_manager",
+				"tx_id":     txs[i].ID,
+				"tx_hash":   txs[i].TxHash,
+			})
+		}
+	}
+
+	return nil
+}
+
+// check looks for a receipt of any attempt, sends an attempt that was never
+// accepted by a node and replaces the transaction if it is stuck
+func (m *TransactionManager) check(ctx context.Context, mtx *models.ManagedTransaction) error {
+	if len(mtx.Attempts) == 0 {
+		return fmt.Errorf("transaction has no signed attempts")
+	}
+	web3Service, err := m.chain(mtx.ChainID)
+	if err != nil {
+		return err
+	}
+	// Every read comes from one provider, so the nonces and receipts agree
+	// on which blocks exist
+	backend, err := web3Service.pin(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Read the nonces first: any attempt mined by then has a receipt below
+	from := common.HexToAddress(mtx.FromAddress)
+	head, err := backend.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get block number: %v", err)
+	}
+	var confirmed uint64
+	if safe, ok := safeHead(head, m.confirmations); ok {
+		if confirmed, err = backend.NonceAt(ctx, from, new(big.Int).SetUint64(safe)); err != nil {
+			return fmt.Errorf("failed to get nonce: %v", err)
+		}
+	}
+	mined, err := backend.NonceAt(ctx, from, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get nonce: %v", err)
+	}
+
+	for i := range mtx.Attempts {
+		receipt, err := backend.TransactionReceipt(ctx, common.HexToHash(mtx.Attempts[i].TxHash))
+		if err == ethereum.NotFound {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get receipt: %v", err)
+		}
+		return m.finalize(mtx, &mtx.Attempts[i], receipt)
+	}
+
+	// With no receipt for any attempt, the nonce was used by some other
+	// transaction. That is only final once the block using it is confirmed;
+	// until then a reorg could still mine one of the attempts.
+	if confirmed > mtx.Nonce {
+		mtx.Status = models.TxStatusFailed
+		mtx.Error = "nonce was used by another transaction"
+		return m.db.Omit(clause.Associations).Save(mtx).Error
+	}
+	if mined > mtx.Nonce {
+		return nil
+	}
+
+	latest := &mtx.Attempts[len(mtx.Attempts)-1]
+	if latest.SentAt == nil {
+		return m.send(ctx, mtx, latest)
+	}
+	// Only the sender's next transaction can be stuck on its own fees
+	if mined < mtx.Nonce || time.Since(*latest.SentAt) < m.stuckAfter {
+		return nil
+	}
+	return m.replace(ctx, mtx, latest)
+}
+
+// replace signs and sends the transaction, or its cancellation, again with
+// higher fees
+func (m *TransactionManager) replace(ctx context.Context, mtx *models.ManagedTransaction, latest *models.TransactionAttempt) error {
+	signer, err := m.signer(common.HexToAddress(mtx.FromAddress))
+	if err != nil {
+		return err
+	}
+
+	web3Service, err := m.chain(mtx.ChainID)
+	if err != nil {
+		return err
+	}
+	backend, err := web3Service.pin(ctx)
+	if err != nil {
+		return err
+	}
+	suggested, err := m.suggestFees(ctx, backend)
+	if err != nil {
+		return err
+	}
+	fees, ok := bumpFees(attemptFees(latest), suggested, m.bumpPercent, m.maxFee)
+	if !ok {
+		return ErrFeeCapped
+	}
+
+	var attempt *models.TransactionAttempt
+	err = m.db.Transaction(func(tx *gorm.DB) error {
+		attempt, err = m.sign(ctx, tx, mtx, signer, fees, latest.Cancel)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	mtx.Attempts = append(mtx.Attempts, *attempt)
+
+	utils.LogInfo("replacing stuck transaction", map[string]interface{}{
+		"component": "tx_manager",
+		"tx_id":     mtx.ID,
+		"replaces":  latest.TxHash,
+		"tx_hash":   attempt.TxHash,
+	})
+	return m.send(ctx, mtx, &mtx.Attempts[len(mtx.Attempts)-1])
+}
+
+// finalize records the outcome of the attempt that was mined. A mined
+// cancellation fails the transaction with the reason it was cancelled.
+func (m *TransactionManager) finalize(mtx *models.ManagedTransaction, attempt *models.TransactionAttempt, receipt *types.Receipt) error {
+	now := time.Now()
+	switch {
+	case attempt.Cancel:
+		mtx.Status = models.TxStatusFailed
+	case receipt.Status != types.ReceiptStatusSuccessful:
+		mtx.Status = models.TxStatusFailed
+		mtx.Error = "transaction reverted"
+	default:
+		mtx.Status = models.TxStatusConfirmed
+		mtx.Error = ""
+	}
+	mtx.TxHash = attempt.TxHash
+	mtx.BlockNumber = receipt.BlockNumber.Uint64()
+	mtx.GasUsed = receipt.GasUsed
+	mtx.ConfirmedAt = &now
+	return m.db.Omit(clause.Associations).Save(mtx).Error
+}
+
+// GetTransaction retrieves a managed transaction with its attempts
+func (m *TransactionManager) GetTransaction(id string) (*models.ManagedTransaction, error) {
+	var mtx models.ManagedTransaction
+	err := m.db.Preload("Attempts", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at asc")
+	}).First(&mtx, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &mtx, nil
+}
+
+// Wait blocks until a managed transaction is mined or fails, or ctx is done
+func (m *TransactionManager) Wait(ctx context.Context, id string) (*models.ManagedTransaction, error) {
+	ticker := time.NewTicker(m.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		mtx, err := m.GetTransaction(id)
+		if err != nil {
+			return nil, err
+		}
+		if mtx.Status != models.TxStatusPending {
+			return mtx, nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// ListTransactions returns a page of managed transactions, newest first,
+// optionally filtered by status
+func (m *TransactionManager) ListTransactions(status string, page, limit int) ([]models.ManagedTransaction, int64, error) {
+	query := m.db.Model(&models.ManagedTransaction{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count transactions: %v", err)
+	}
+
+	var txs []models.ManagedTransaction
+	err := query.Order("created_at desc").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&txs).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch transactions: %v", err)
+	}
+
+	return txs, total, nil
+}
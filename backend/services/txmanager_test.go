@@ -0,0 +1,358 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/models"
+	"gorm.io/gorm"
+)
+
+func gwei(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e9))
+}
+
+func TestBumpFee(t *testing.T) {
+	// Rounds up so the increase is never below the percentage
+	assert.Equal(t, big.NewInt(12), bumpFee(big.NewInt(10), nil, 15))
+	// A zero fee still increases
+	assert.Equal(t, big.NewInt(1), bumpFee(big.NewInt(0), nil, 15))
+	// The network's suggestion wins when it is higher
+	assert.Equal(t, gwei(40), bumpFee(gwei(20), gwei(40), 15))
+	assert.Equal(t, gwei(23), bumpFee(gwei(20), gwei(5), 15))
+}
+
+func TestBumpFees(t *testing.T) {
+	maxFee := gwei(100)
+
+	t.Run("legacy", func(t *testing.T) {
+		fees, ok := bumpFees(txFees{GasPrice: gwei(20)}, txFees{GasPrice: gwei(10)}, 10, maxFee)
+		assert.True(t, ok)
+		assert.Equal(t, gwei(22), fees.GasPrice)
+		assert.Nil(t, fees.FeeCap)
+	})
+
+	t.Run("dynamic fee", func(t *testing.T) {
+		old := txFees{FeeCap: gwei(50), TipCap: gwei(2)}
+		fees, ok := bumpFees(old, txFees{FeeCap: gwei(30), TipCap: gwei(3)}, 10, maxFee)
+		assert.True(t, ok)
+		assert.Equal(t, gwei(55), fees.FeeCap)
+		assert.Equal(t, gwei(3), fees.TipCap)
+		assert.Nil(t, fees.GasPrice)
+	})
+
+	t.Run("fee cap", func(t *testing.T) {
+		_, ok := bumpFees(txFees{FeeCap: gwei(95), TipCap: gwei(2)}, txFees{}, 10, maxFee)
+		assert.False(t, ok)
+	})
+}
+
+func TestCapFees(t *testing.T) {
+	maxFee := gwei(100)
+
+	fees := capFees(txFees{FeeCap: gwei(300), TipCap: gwei(150)}, maxFee)
+	assert.Equal(t, gwei(100), fees.FeeCap)
+	assert.Equal(t, gwei(100), fees.TipCap)
+
+	fees = capFees(txFees{GasPrice: gwei(30)}, maxFee)
+	assert.Equal(t, gwei(30), fees.GasPrice)
+}
+
+func TestAttemptFees(t *testing.T) {
+	fees := attemptFees(&models.TransactionAttempt{MaxFeePerGas: "50000000000", MaxPriorityFeePerGas: "2000000000"})
+	assert.Equal(t, gwei(50), fees.FeeCap)
+	assert.Equal(t, gwei(2), fees.TipCap)
+	assert.Nil(t, fees.GasPrice)
+
+	fees = attemptFees(&models.TransactionAttempt{GasPrice: "20000000000"})
+	assert.Equal(t, gwei(20), fees.GasPrice)
+	assert.Nil(t, fees.FeeCap)
+}
+
+func TestIsAlreadySent(t *testing.T) {
+	assert.True(t, isAlreadySent(errors.New("already known")))
+	assert.True(t, isAlreadySent(errors.New("Known transaction: 0xabc")))
+	assert.True(t, isAlreadySent(errors.New("nonce too low: next nonce 5, tx nonce 4")))
+	assert.False(t, isAlreadySent(errors.New("replacement transaction underpriced")))
+	assert.False(t, isAlreadySent(errors.New("insufficient funds for gas * price + value")))
+}
+
+func TestIsRejected(t *testing.T) {
+	for msg, rejected := range map[string]bool{
+		"failed to send transaction: insufficient funds for gas * price + value": true,
+		"intrinsic gas too low: have 20000, want 21000":                          true,
+		"exceeds block gas limit":                                                true,
+		"invalid sender":                                                         true,
+		"replacement transaction underpriced":                                    false,
+		"context deadline exceeded":                                              false,
+		"429 Too Many Requests":                                                  false,
+		"nonce too low":                                                          false,
+	} {
+		assert.Equal(t, rejected, isRejected(errors.New(msg)), msg)
+	}
+}
+
+// txManagerTest is a transaction manager sending from the mock chain's
+// funded account
+type txManagerTest struct {
+	t       *testing.T
+	ctx     context.Context
+	chain   *mockChain
+	db      *gorm.DB
+	manager *TransactionManager
+	signer  Signer
+}
+
+func newTxManagerTest(t *testing.T) *txManagerTest {
+	chain := newMockChain(t)
+	db := newTestDB(t)
+	registry, err := newDeploymentRegistry([]*Web3Service{chain.web3}, chain.chainID.Int64())
+	require.NoError(t, err)
+	signer, err := newLocalSigner([]byte(hex.EncodeToString(crypto.FromECDSA(chain.key))))
+	require.NoError(t, err)
+
+	manager := &TransactionManager{
+		db:           db,
+		registry:     registry,
+		pollInterval: 10 * time.Millisecond,
+		stuckAfter:   time.Hour,
+		bumpPercent:  15,
+		maxFee:       gwei(500),
+		signers:      make(map[common.Address]Signer),
+	}
+	manager.RegisterSigner(signer)
+
+	// Until a block is mined the node reports its transaction index as
+	// still being built
+	chain.commit()
+	return &txManagerTest{t: t, ctx: context.Background(), chain: chain, db: db, manager: manager, signer: signer}
+}
+
+// request is a transfer of 1 wei with an idempotency key
+func (x *txManagerTest) request(reference string) TxRequest {
+	to := common.HexToAddress("0xca11")
+	return TxRequest{From: x.signer.Address(), To: &to, Value: big.NewInt(1), Reference: reference}
+}
+
+func (x *txManagerTest) submit(reference string) *models.ManagedTransaction {
+	mtx, err := x.manager.Submit(x.ctx, x.request(reference))
+	require.NoError(x.t, err)
+	return mtx
+}
+
+// underpriced submits a transaction with fees too low to be mined, as if
+// the network's fees rose straight after it was sent
+func (x *txManagerTest) underpriced(reference string) *models.ManagedTransaction {
+	maxFee := x.manager.maxFee
+	x.manager.maxFee = big.NewInt(1000)
+	defer func() { x.manager.maxFee = maxFee }()
+	return x.submit(reference)
+}
+
+func (x *txManagerTest) process() {
+	require.NoError(x.t, x.manager.ProcessPending(x.ctx))
+}
+
+func (x *txManagerTest) load(id string) *models.ManagedTransaction {
+	mtx, err := x.manager.GetTransaction(id)
+	require.NoError(x.t, err)
+	return mtx
+}
+
+func TestSubmitAllocatesNonces(t *testing.T) {
+	x := newTxManagerTest(t)
+
+	const submissions = 8
+	ids := make([]string, submissions)
+	errs := make([]error, submissions)
+	var wg sync.WaitGroup
+	for i := 0; i < submissions; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			mtx, err := x.manager.Submit(x.ctx, x.request(fmt.Sprintf("op:%d", i)))
+			errs[i] = err
+			if err == nil {
+				ids[i] = mtx.ID
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var nonces []int
+	for i := range ids {
+		require.NoError(t, errs[i])
+		nonces = append(nonces, int(x.load(ids[i]).Nonce))
+	}
+	sort.Ints(nonces)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, nonces)
+
+	// With no gaps every one of them is mined
+	x.chain.commit()
+	x.process()
+	for _, id := range ids {
+		assert.Equal(t, models.TxStatusConfirmed, x.load(id).Status)
+	}
+
+	var row models.SenderNonce
+	require.NoError(t, x.db.First(&row, "address = ?", x.signer.Address().Hex()).Error)
+	assert.Equal(t, uint64(submissions), row.NextNonce)
+}
+
+func TestSubmitReference(t *testing.T) {
+	x := newTxManagerTest(t)
+
+	first := x.submit("operator:1")
+	again := x.submit("operator:1")
+	assert.Equal(t, first.ID, again.ID)
+	assert.Equal(t, first.TxHash, again.TxHash)
+	require.Len(t, again.Attempts, 1)
+
+	var count int64
+	require.NoError(t, x.db.Model(&models.ManagedTransaction{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+	require.NoError(t, x.db.Model(&models.TransactionAttempt{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	// The nonce was not used up by the repeat
+	assert.Equal(t, uint64(1), x.submit("operator:2").Nonce)
+}
+
+func TestReplaceStuckTransaction(t *testing.T) {
+	x := newTxManagerTest(t)
+	mtx := x.underpriced("operator:1")
+	x.chain.commit()
+
+	// Not replaced until it has been waiting long enough
+	x.process()
+	require.Len(t, x.load(mtx.ID).Attempts, 1)
+
+	x.manager.stuckAfter = 0
+	x.process()
+	stuck := x.load(mtx.ID)
+	require.Len(t, stuck.Attempts, 2)
+	original, replacement := stuck.Attempts[0], stuck.Attempts[1]
+	assert.NotNil(t, replacement.SentAt)
+	assert.Equal(t, replacement.TxHash, stuck.TxHash)
+	assert.Equal(t, models.TxStatusPending, stuck.Status)
+	for _, fee := range []func(txFees) *big.Int{
+		func(f txFees) *big.Int { return f.FeeCap },
+		func(f txFees) *big.Int { return f.TipCap },
+	} {
+		old, bumped := fee(attemptFees(&original)), fee(attemptFees(&replacement))
+		assert.True(t, bumped.Cmp(bumpFee(old, nil, 15)) >= 0, "%s is not %s bumped", bumped, old)
+	}
+
+	block := x.chain.commit()
+	x.process()
+	mined := x.load(mtx.ID)
+	assert.Equal(t, models.TxStatusConfirmed, mined.Status)
+	assert.Equal(t, replacement.TxHash, mined.TxHash)
+	assert.Equal(t, block, mined.BlockNumber)
+	assert.NotZero(t, mined.GasUsed)
+	assert.NotNil(t, mined.ConfirmedAt)
+}
+
+func TestNonceUsedByAnotherTransaction(t *testing.T) {
+	x := newTxManagerTest(t)
+	x.manager.confirmations = 2
+	mtx := x.underpriced("operator:1")
+
+	// The account sends something else at the same nonce
+	to := common.HexToAddress("0xca11")
+	other, err := types.SignNewTx(x.chain.key, types.LatestSignerForChainID(x.chain.chainID), &types.DynamicFeeTx{
+		ChainID:   x.chain.chainID,
+		Nonce:     mtx.Nonce,
+		GasTipCap: gwei(1),
+		GasFeeCap: gwei(100),
+		Gas:       21000,
+		To:        &to,
+	})
+	require.NoError(t, err)
+	require.NoError(t, x.chain.client.SendTransaction(x.ctx, other))
+	x.chain.commit()
+
+	// Until that is confirmed the transaction may yet be mined
+	x.process()
+	assert.Equal(t, models.TxStatusPending, x.load(mtx.ID).Status)
+	x.chain.commit()
+	x.process()
+	assert.Equal(t, models.TxStatusPending, x.load(mtx.ID).Status)
+
+	x.chain.commit()
+	x.process()
+	failed := x.load(mtx.ID)
+	assert.Equal(t, models.TxStatusFailed, failed.Status)
+	assert.Equal(t, "nonce was used by another transaction", failed.Error)
+}
+
+func TestRejectedTransactionIsCancelled(t *testing.T) {
+	x := newTxManagerTest(t)
+
+	// A transaction stored with too little gas, never accepted by a node
+	to := common.HexToAddress("0xca11")
+	rejected := models.ManagedTransaction{
+		ChainID:     x.chain.chainID.Int64(),
+		FromAddress: x.signer.Address().Hex(),
+		ToAddress:   to.Hex(),
+		ValueWei:    "1",
+		GasLimit:    20000,
+		Status:      models.TxStatusPending,
+	}
+	require.NoError(t, x.db.Transaction(func(tx *gorm.DB) error {
+		backend, err := x.chain.web3.pin(x.ctx)
+		if err != nil {
+			return err
+		}
+		if rejected.Nonce, err = x.manager.allocateNonce(x.ctx, tx, backend, rejected.ChainID, x.signer.Address()); err != nil {
+			return err
+		}
+		if err := tx.Create(&rejected).Error; err != nil {
+			return err
+		}
+		_, err = x.manager.sign(x.ctx, tx, &rejected, x.signer, txFees{FeeCap: gwei(100), TipCap: gwei(1)}, false)
+		return err
+	}))
+
+	// A later transaction waits on its nonce
+	later := x.submit("operator:1")
+	assert.Equal(t, rejected.Nonce+1, later.Nonce)
+	x.chain.commit()
+	x.process()
+	assert.Equal(t, models.TxStatusPending, x.load(later.ID).Status)
+
+	cancelling := x.load(rejected.ID)
+	assert.Equal(t, models.TxStatusPending, cancelling.Status)
+	assert.Contains(t, cancelling.Error, "intrinsic gas too low")
+	require.Len(t, cancelling.Attempts, 2)
+	assert.Nil(t, cancelling.Attempts[0].SentAt)
+	assert.True(t, cancelling.Attempts[1].Cancel)
+	assert.NotNil(t, cancelling.Attempts[1].SentAt)
+
+	// Once the cancellation is mined the transaction fails and the later
+	// one goes through
+	x.chain.commit()
+	x.process()
+	failed := x.load(rejected.ID)
+	assert.Equal(t, models.TxStatusFailed, failed.Status)
+	assert.Contains(t, failed.Error, "rejected by node")
+	assert.Equal(t, cancelling.Attempts[1].TxHash, failed.TxHash)
+	assert.Equal(t, models.TxStatusConfirmed, x.load(later.ID).Status)
+
+	receipt, err := x.chain.client.TransactionReceipt(x.ctx, common.HexToHash(failed.TxHash))
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	assert.Equal(t, uint64(21000), receipt.GasUsed)
+}
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/yourusername/revibe/backend/config"
+	"github.com/yourusername/revibe/backend/models"
+	"github.com/yourusername/revibe/backend/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Transaction lookup statuses
+const (
+	TxLookupPending = "pending"
+	TxLookupMined   = "mined"
+	TxLookupFailed  = "failed"
+)
+
+// ErrTransactionNotFound is returned for a transaction the node does not know
+var ErrTransactionNotFound = errors.New("transaction not found")
+
+var txHashPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+
+// DecodedEvent is a ReVibe contract log decoded with the contract's ABI
+type DecodedEvent struct {
+	Name     string                 `json:"name"`
+	LogIndex uint                   `json:"logIndex"`
+	Args     map[string]interface{} `json:"args"`
+}
+
+// TransactionStatus is a transaction's progress and, once it is mined, its
+// outcome. EffectiveGasPrice is in wei.
+type TransactionStatus struct {
+	TxHash            string         `json:"txHash"`
+	Status            string         `json:"status"`
+	From              string         `json:"from,omitempty"`
+	To                string         `json:"to,omitempty"`
+	BlockNumber       uint64         `json:"blockNumber,omitempty"`
+	BlockHash         string         `json:"blockHash,omitempty"`
+	Confirmations     uint64         `json:"confirmations"`
+	GasUsed           uint64         `json:"gasUsed,omitempty"`
+	EffectiveGasPrice string         `json:"effectiveGasPrice,omitempty"`
+	Events            []DecodedEvent `json:"events"`
+	RevertReason      string         `json:"revertReason,omitempty"`
+}
+
+// TransactionStatusService reports the status of transactions by hash. It
+// reads receipts from the indexers' receipt cache and asks the chain only
+// for transactions that are not cached yet.
+type TransactionStatusService struct {
+	db            *gorm.DB
+	registry      *DeploymentRegistry
+	confirmations uint64
+}
+
+// NewTransactionStatusService creates a new TransactionStatusService instance
+func NewTransactionStatusService(db *gorm.DB, registry *DeploymentRegistry) *TransactionStatusService {
+	return &TransactionStatusService{
+		db:            db,
+		registry:      registry,
+		confirmations: uint64(config.AppConfig.IndexerConfirmations),
+	}
+}
+
+// newCachedReceipt builds the cache record of a mined transaction, replaying
+// it for the revert reason if it failed
+func newCachedReceipt(ctx context.Context, web3Service *Web3Service, tx *types.Transaction, receipt *types.Receipt) (*models.CachedReceipt, error) {
+	from, err := types.Sender(types.LatestSignerForChainID(web3Service.ChainID()), tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover sender: %v", err)
+	}
+	logs, err := json.Marshal(receipt.Logs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode logs: %v", err)
+	}
+
+	record := &models.CachedReceipt{
+		ChainID:     web3Service.ChainID().Int64(),
+		TxHash:      receipt.TxHash.Hex(),
+		BlockNumber: receipt.BlockNumber.Uint64(),
+		BlockHash:   receipt.BlockHash.Hex(),
+		FromAddress: from.Hex(),
+		Status:      receipt.Status,
+		GasUsed:     receipt.GasUsed,
+		Logs:        string(logs),
+	}
+	if tx.To() != nil {
+		record.ToAddress = tx.To().Hex()
+	}
+	if receipt.EffectiveGasPrice != nil {
+		record.EffectiveGasPrice = receipt.EffectiveGasPrice.String()
+	}
+
+	if receipt.Status == types.ReceiptStatusFailed {
+		reason, err := web3Service.RevertReason(ctx, tx, from, record.BlockNumber)
+		if err != nil {
+			utils.LogWarning("failed to decode revert reason", map[string]interface{}{
+				"tx_hash": record.TxHash,
+				"error":   err.Error(),
+			})
+		}
+		record.RevertReason = reason
+	}
+	return record, nil
+}
+
+// fetchReceipt fetches a mined transaction and its receipt and builds its
+// cache record
+func fetchReceipt(ctx context.Context, web3Service *Web3Service, txHash common.Hash) (*models.CachedReceipt, error) {
+	tx, _, err := web3Service.TransactionByHash(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := web3Service.GetTransactionReceipt(ctx, txHash.Hex())
+	if err != nil {
+		return nil, err
+	}
+	return newCachedReceipt(ctx, web3Service, tx, receipt)
+}
+
+// cacheReceipts stores receipt records, keeping any already cached
+func cacheReceipts(tx *gorm.DB, records []*models.CachedReceipt) error {
+	if len(records) == 0 {
+		return nil
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(records).Error; err != nil {
+		return fmt.Errorf("failed to cache receipts: %v", err)
+	}
+	return nil
+}
+
+// GetTransactionStatus reports whether a transaction on a chain is pending,
+// mined or failed, with its confirmations, gas and decoded ReVibe events.
+// Chain ID zero is the active deployment's chain.
+func (s *TransactionStatusService) GetTransactionStatus(ctx context.Context, chainID int64, hash string) (*TransactionStatus, error) {
+	if !txHashPattern.MatchString(hash) {
+		return nil, ErrInvalidTxHash
+	}
+	txHash := common.HexToHash(hash)
+
+	web3Service := s.registry.Active()
+	if chainID != 0 {
+		var err error
+		if web3Service, err = s.registry.Chain(chainID); err != nil {
+			return nil, err
+		}
+	}
+
+	var record models.CachedReceipt
+	err := s.db.Where("chain_id = ? AND tx_hash = ?", web3Service.ChainID().Int64(), txHash.Hex()).
+		First(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return s.lookup(ctx, web3Service, txHash)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch cached receipt: %v", err)
+	}
+
+	head, err := web3Service.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block number: %v", err)
+	}
+	return s.status(&record, head)
+}
+
+// lookup asks the chain about a transaction that is not cached, and caches
+// its receipt once it has as many confirmations as the indexer waits for
+func (s *TransactionStatusService) lookup(ctx context.Context, web3Service *Web3Service, txHash common.Hash) (*TransactionStatus, error) {
+	tx, pending, err := web3Service.TransactionByHash(ctx, txHash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, ErrTransactionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transaction: %v", err)
+	}
+	if pending {
+		return s.pending(web3Service, tx), nil
+	}
+
+	receipt, err := web3Service.GetTransactionReceipt(ctx, txHash.Hex())
+	if errors.Is(err, ethereum.NotFound) {
+		return s.pending(web3Service, tx), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch receipt: %v", err)
+	}
+	record, err := newCachedReceipt(ctx, web3Service, tx, receipt)
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := web3Service.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block number: %v", err)
+	}
+	if safe, ok := safeHead(head, s.confirmations); ok && record.BlockNumber <= safe {
+		if err := cacheReceipts(s.db, []*models.CachedReceipt{record}); err != nil {
+			return nil, err
+		}
+	}
+	return s.status(record, head)
+}
+
+// pending reports a transaction the node knows but has not mined
+func (s *TransactionStatusService) pending(web3Service *Web3Service, tx *types.Transaction) *TransactionStatus {
+	status := &TransactionStatus{
+		TxHash: tx.Hash().Hex(),
+		Status: TxLookupPending,
+		Events: []DecodedEvent{},
+	}
+	if from, err := types.Sender(types.LatestSignerForChainID(web3Service.ChainID()), tx); err == nil {
+		status.From = from.Hex()
+	}
+	if tx.To() != nil {
+		status.To = tx.To().Hex()
+	}
+	return status
+}
+
+// status reports a mined transaction from its receipt record, decoding the
+// logs of every deployment on its chain
+func (s *TransactionStatusService) status(record *models.CachedReceipt, head uint64) (*TransactionStatus, error) {
+	var logs []*types.Log
+	if err := json.Unmarshal([]byte(record.Logs), &logs); err != nil {
+		return nil, fmt.Errorf("failed to decode cached logs: %v", err)
+	}
+
+	status := &TransactionStatus{
+		TxHash:            record.TxHash,
+		Status:            TxLookupMined,
+		From:              record.FromAddress,
+		To:                record.ToAddress,
+		BlockNumber:       record.BlockNumber,
+		BlockHash:         record.BlockHash,
+		GasUsed:           record.GasUsed,
+		EffectiveGasPrice: record.EffectiveGasPrice,
+		Events:            []DecodedEvent{},
+	}
+	if record.Status == types.ReceiptStatusFailed {
+		status.Status = TxLookupFailed
+		status.RevertReason = record.RevertReason
+	}
+	if head >= record.BlockNumber {
+		status.Confirmations = head - record.BlockNumber + 1
+	}
+
+	for _, vLog := range logs {
+		web3Service, err := s.registry.Get(record.ChainID, vLog.Address.Hex())
+		if err != nil {
+			continue
+		}
+		name, args, err := web3Service.DecodeEventArgs(*vLog)
+		if err != nil {
+			continue
+		}
+		status.Events = append(status.Events, DecodedEvent{Name: name, LogIndex: vLog.Index, Args: args})
+	}
+	return status, nil
+}
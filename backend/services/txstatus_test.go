@@ -0,0 +1,121 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/models"
+)
+
+// revertError is a node's reply to a reverted call
+type revertError struct {
+	message string
+	data    string
+}
+
+func (e revertError) Error() string          { return e.message }
+func (e revertError) ErrorData() interface{} { return e.data }
+
+// encodeRevert encodes Error(string) revert data
+func encodeRevert(t *testing.T, reason string) string {
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	data, err := abi.Arguments{{Type: stringType}}.Pack(reason)
+	require.NoError(t, err)
+	return hexutil.Encode(append(crypto.Keccak256([]byte("Error(string)"))[:4], data...))
+}
+
+func TestRevertReason(t *testing.T) {
+	reason, ok := revertReason(revertError{
+		message: "execution reverted: Insufficient payment",
+		data:    encodeRevert(t, "Insufficient payment"),
+	})
+	assert.True(t, ok)
+	assert.Equal(t, "Insufficient payment", reason)
+
+	// Nodes that return no data still name the reason in the message
+	reason, ok = revertReason(errors.New("execution reverted: Product already sold"))
+	assert.True(t, ok)
+	assert.Equal(t, "Product already sold", reason)
+
+	reason, ok = revertReason(errors.New("execution reverted"))
+	assert.True(t, ok)
+	assert.Equal(t, "", reason)
+
+	_, ok = revertReason(errors.New("missing trie node"))
+	assert.False(t, ok)
+}
+
+func TestTransactionStatusFromRecord(t *testing.T) {
+	contractAddr := common.HexToAddress("0x00000000000000000000000000000000000000cc")
+	web3Service, err := NewWeb3ServiceWithBackend(nil, testChainID, contractAddr)
+	require.NoError(t, err)
+	registry, err := newDeploymentRegistry([]*Web3Service{web3Service}, testChainID.Int64())
+	require.NoError(t, err)
+	s := &TransactionStatusService{registry: registry}
+
+	seller := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	buyer := common.HexToAddress("0x00000000000000000000000000000000000000b2")
+	event := web3Service.abi.Events[EventProductSold]
+	data, err := event.Inputs.NonIndexed().Pack(big.NewInt(1e17))
+	require.NoError(t, err)
+	logs, err := json.Marshal([]*types.Log{
+		{
+			Address: contractAddr,
+			Topics: []common.Hash{
+				event.ID,
+				common.BigToHash(big.NewInt(42)),
+				common.BytesToHash(seller.Bytes()),
+				common.BytesToHash(buyer.Bytes()),
+			},
+			Data:  data,
+			Index: 4,
+		},
+		// Logs of other contracts are left out
+		{Address: seller, Topics: []common.Hash{event.ID}, Index: 5},
+	})
+	require.NoError(t, err)
+
+	record := &models.CachedReceipt{
+		ChainID:           testChainID.Int64(),
+		TxHash:            "0xabc",
+		BlockNumber:       100,
+		Status:            types.ReceiptStatusSuccessful,
+		GasUsed:           84211,
+		EffectiveGasPrice: "1650000000",
+		Logs:              string(logs),
+	}
+	status, err := s.status(record, 109)
+	require.NoError(t, err)
+	assert.Equal(t, TxLookupMined, status.Status)
+	assert.Equal(t, uint64(10), status.Confirmations)
+	assert.Equal(t, []DecodedEvent{{
+		Name:     EventProductSold,
+		LogIndex: 4,
+		Args: map[string]interface{}{
+			"tokenId": "42",
+			"seller":  seller,
+			"buyer":   buyer,
+			"price":   "100000000000000000",
+		},
+	}}, status.Events)
+
+	record.Status = types.ReceiptStatusFailed
+	record.RevertReason = "Insufficient payment"
+	record.Logs = "[]"
+	status, err = s.status(record, 100)
+	require.NoError(t, err)
+	assert.Equal(t, TxLookupFailed, status.Status)
+	assert.Equal(t, uint64(1), status.Confirmations)
+	assert.Equal(t, "Insufficient payment", status.RevertReason)
+	assert.Empty(t, status.Events)
+}
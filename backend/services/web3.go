@@ -2,83 +2,270 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/yourusername/revibe/backend/config"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/yourusername/revibe/backend/contracts"
+	"github.com/yourusername/revibe/backend/models"
 )
 
-// Web3Service handles blockchain interactions
+// ChainBackend is the chain access Web3Service and the transaction manager
+// need. RPCBackend provides it over the configured RPC providers; tests use
+// go-ethereum's simulated backend.
+type ChainBackend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	ethereum.BlockNumberReader
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+}
+
+// Web3Service handles the blockchain interactions with one ReVibe
+// deployment
 type Web3Service struct {
-	client       *ethclient.Client
-	contract     *ReVibeContract
+	backend      ChainBackend
+	pin          func(ctx context.Context) (ChainBackend, error)
+	contract     *contracts.ReVibeContract
+	abi          *abi.ABI
 	contractAddr common.Address
 	chainID      *big.Int
+	deployment   models.Deployment
 }
 
-// NewWeb3Service creates a new Web3Service instance
-func NewWeb3Service() (*Web3Service, error) {
-	// Connect to Ethereum network
-	client, err := ethclient.Dial(fmt.Sprintf("https://mainnet.infura.io/v3/%s", config.AppConfig.InfuraID))
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to Ethereum network: %v", err)
+// OnChainProduct is a product as recorded by the ReVibe contract
+type OnChainProduct struct {
+	TokenID         *big.Int
+	Seller          common.Address
+	Price           *big.Int
+	IsAuthenticated bool
+	IsSold          bool
+	Metadata        string
+}
+
+// NewWeb3ServiceWithBackend creates a Web3Service for the ReVibe contract at
+// contractAddr on any chain backend, such as a simulated one. Every
+// transaction goes through the same backend. The contract is treated as an
+// active deployment of the current ABI version.
+func NewWeb3ServiceWithBackend(backend ChainBackend, chainID *big.Int, contractAddr common.Address) (*Web3Service, error) {
+	pin := func(context.Context) (ChainBackend, error) {
+		return backend, nil
 	}
+	return newWeb3Service(backend, pin, models.Deployment{
+		ChainID:         chainID.Int64(),
+		ContractAddress: contractAddr.Hex(),
+		ABIVersion:      defaultABIVersion,
+		Status:          models.DeploymentStatusActive,
+	})
+}
 
-	// Get chain ID
-	chainID, err := client.ChainID(context.Background())
+// newWeb3Service creates a Web3Service for a deployment. pin returns the
+// backend a transaction is sent through.
+func newWeb3Service(backend ChainBackend, pin func(ctx context.Context) (ChainBackend, error), deployment models.Deployment) (*Web3Service, error) {
+	metadata, ok := contractABIs[deployment.ABIVersion]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownABIVersion, deployment.ABIVersion)
+	}
+	contractABI, err := metadata.GetAbi()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get chain ID: %v", err)
+		return nil, fmt.Errorf("failed to parse contract ABI: %v", err)
 	}
 
-	// Parse contract address
-	contractAddr := common.HexToAddress(config.AppConfig.ContractAddress)
-
-	// Create contract instance
-	contract, err := NewReVibeContract(contractAddr, client)
+	// Create contract instance for reads
+	contractAddr := common.HexToAddress(deployment.ContractAddress)
+	contract, err := contracts.NewReVibeContract(contractAddr, backend)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create contract instance: %v", err)
 	}
 
 	return &Web3Service{
-		client:       client,
+		backend:      backend,
+		pin:          pin,
 		contract:     contract,
+		abi:          contractABI,
 		contractAddr: contractAddr,
-		chainID:      chainID,
+		chainID:      big.NewInt(deployment.ChainID),
+		deployment:   deployment,
 	}, nil
 }
 
-// Close closes the Web3Service connection
-func (s *Web3Service) Close() {
-	if s.client != nil {
-		s.client.Close()
+// transactor returns a contract instance pinned to one RPC provider, so a
+// transaction's nonce, gas and broadcast all go to the same node
+func (s *Web3Service) transactor(auth *bind.TransactOpts) (*contracts.ReVibeContract, error) {
+	ctx := auth.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	backend, err := s.pin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return contracts.NewReVibeContract(s.contractAddr, backend)
+}
+
+// ChainID returns the ID of the connected chain
+func (s *Web3Service) ChainID() *big.Int {
+	return new(big.Int).Set(s.chainID)
+}
+
+// ContractAddress returns the address of the ReVibe contract
+func (s *Web3Service) ContractAddress() common.Address {
+	return s.contractAddr
+}
+
+// Deployment returns the deployment the service talks to
+func (s *Web3Service) Deployment() models.Deployment {
+	return s.deployment
+}
+
+// ReadOnly reports whether the deployment is a legacy one, which the
+// backend no longer sends transactions to
+func (s *Web3Service) ReadOnly() bool {
+	return s.deployment.Status != models.DeploymentStatusActive
+}
+
+// GetTransactionReceipt retrieves the receipt of a mined transaction. It
+// returns ethereum.NotFound while the transaction is still pending.
+func (s *Web3Service) GetTransactionReceipt(ctx context.Context, txHash string) (*types.Receipt, error) {
+	return s.backend.TransactionReceipt(ctx, common.HexToHash(txHash))
+}
+
+// TransactionByHash retrieves a transaction and whether it is still pending.
+// It returns ethereum.NotFound if the node does not know it.
+func (s *Web3Service) TransactionByHash(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error) {
+	return s.backend.TransactionByHash(ctx, txHash)
+}
+
+// RevertReason replays a failed transaction on the state before its block
+// and returns why it reverted, such as "Insufficient payment". It returns an
+// error when the node cannot replay it, for example because it has pruned
+// that state.
+func (s *Web3Service) RevertReason(ctx context.Context, tx *types.Transaction, from common.Address, blockNumber uint64) (string, error) {
+	if blockNumber == 0 {
+		return "", fmt.Errorf("cannot replay a transaction in the genesis block")
+	}
+	msg := ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+	_, err := s.backend.CallContract(ctx, msg, new(big.Int).SetUint64(blockNumber-1))
+	if err == nil {
+		return "", fmt.Errorf("transaction did not revert when replayed")
+	}
+	if reason, ok := revertReason(err); ok {
+		return reason, nil
+	}
+	return "", fmt.Errorf("failed to replay transaction: %v", err)
+}
+
+// revertReason extracts the reason from a call's revert error, decoding the
+// Error(string) or Panic(uint256) data when the node returns it
+func revertReason(err error) (string, bool) {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if reason, err := abi.UnpackRevert(common.FromHex(data)); err == nil {
+				return reason, true
+			}
+		}
+	}
+
+	message := err.Error()
+	if !strings.HasPrefix(message, "execution reverted") {
+		return "", false
+	}
+	return strings.TrimPrefix(strings.TrimPrefix(message, "execution reverted"), ": "), true
+}
+
+// FindProductListed returns the ProductListed event emitted by the contract
+// in a receipt, or nil if there is none
+func (s *Web3Service) FindProductListed(receipt *types.Receipt) *contracts.ReVibeContractProductListed {
+	for _, vLog := range receipt.Logs {
+		if vLog.Address != s.contractAddr {
+			continue
+		}
+		if event, err := s.contract.ParseProductListed(*vLog); err == nil {
+			return event
+		}
+	}
+	return nil
+}
+
+// FindProductSold returns the ProductSold event emitted by the contract in a
+// receipt, or nil if there is none
+func (s *Web3Service) FindProductSold(receipt *types.Receipt) *contracts.ReVibeContractProductSold {
+	for _, vLog := range receipt.Logs {
+		if vLog.Address != s.contractAddr {
+			continue
+		}
+		if event, err := s.contract.ParseProductSold(*vLog); err == nil {
+			return event
+		}
+	}
+	return nil
+}
+
+// BlockNumber returns the number of the latest block
+func (s *Web3Service) BlockNumber(ctx context.Context) (uint64, error) {
+	return s.backend.BlockNumber(ctx)
+}
+
+// GetProductSoldEvents returns the ProductSold events emitted between two
+// blocks, inclusive
+func (s *Web3Service) GetProductSoldEvents(ctx context.Context, fromBlock, toBlock *big.Int) ([]*contracts.ReVibeContractProductSold, error) {
+	logs, err := s.GetPastEvents(ctx, fromBlock, toBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get past events: %v", err)
 	}
+
+	var events []*contracts.ReVibeContractProductSold
+	for _, vLog := range logs {
+		if event, err := s.contract.ParseProductSold(vLog); err == nil {
+			events = append(events, event)
+		}
+	}
+	return events, nil
 }
 
-// GetAuth creates an authentication transactor
-func (s *Web3Service) GetAuth(privateKey string) (*bind.TransactOpts, error) {
-	// Parse private key
-	key, err := crypto.HexToECDSA(strings.TrimPrefix(privateKey, "0x"))
+// GetUserPurchases retrieves the token IDs bought by an address
+func (s *Web3Service) GetUserPurchases(user common.Address) ([]*big.Int, error) {
+	tokenIDs, err := s.contract.GetUserPurchases(nil, user)
 	if err != nil {
-		return nil, fmt.Errorf("invalid private key: %v", err)
+		return nil, fmt.Errorf("failed to get user purchases: %v", err)
 	}
 
-	// Create auth
-	auth, err := bind.NewKeyedTransactorWithChainID(key, s.chainID)
+	return tokenIDs, nil
+}
+
+// GetUserProducts retrieves the token IDs listed by an address
+func (s *Web3Service) GetUserProducts(user common.Address) ([]*big.Int, error) {
+	tokenIDs, err := s.contract.GetUserProducts(nil, user)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create auth: %v", err)
+		return nil, fmt.Errorf("failed to get user products: %v", err)
 	}
 
-	return auth, nil
+	return tokenIDs, nil
 }
 
-// ListProduct lists a product on the blockchain
-func (s *Web3Service) ListProduct(auth *bind.TransactOpts, name string, price *big.Int) (string, error) {
-	tx, err := s.contract.ListProduct(auth, name, price)
+// ListProduct lists a product on the blockchain. metadataURI should point at
+// the product's ERC-721 metadata document.
+func (s *Web3Service) ListProduct(auth *bind.TransactOpts, price *big.Int, metadataURI string) (string, error) {
+	contract, err := s.transactor(auth)
+	if err != nil {
+		return "", fmt.Errorf("failed to list product: %v", err)
+	}
+
+	tx, err := contract.ListProduct(auth, price, metadataURI)
 	if err != nil {
 		return "", fmt.Errorf("failed to list product: %v", err)
 	}
@@ -88,7 +275,12 @@ func (s *Web3Service) ListProduct(auth *bind.TransactOpts, name string, price *b
 
 // BuyProduct purchases a product
 func (s *Web3Service) BuyProduct(auth *bind.TransactOpts, productID *big.Int) (string, error) {
-	tx, err := s.contract.BuyProduct(auth, productID)
+	contract, err := s.transactor(auth)
+	if err != nil {
+		return "", fmt.Errorf("failed to buy product: %v", err)
+	}
+
+	tx, err := contract.BuyProduct(auth, productID)
 	if err != nil {
 		return "", fmt.Errorf("failed to buy product: %v", err)
 	}
@@ -96,19 +288,41 @@ func (s *Web3Service) BuyProduct(auth *bind.TransactOpts, productID *big.Int) (s
 	return tx.Hash().Hex(), nil
 }
 
-// AuthenticateProduct authenticates a product
-func (s *Web3Service) AuthenticateProduct(auth *bind.TransactOpts, productID *big.Int) (string, error) {
-	tx, err := s.contract.AuthenticateProduct(auth, productID)
+// AuthenticateProductData packs the calldata of an authenticateProduct call,
+// for the operator to send through the transaction manager
+func (s *Web3Service) AuthenticateProductData(productID *big.Int, authenticated bool) ([]byte, error) {
+	return s.pack("authenticateProduct", productID, authenticated)
+}
+
+// UpdatePlatformFeeData packs the calldata of an updatePlatformFee call.
+// fee is in parts per thousand.
+func (s *Web3Service) UpdatePlatformFeeData(fee *big.Int) ([]byte, error) {
+	return s.pack("updatePlatformFee", fee)
+}
+
+// WithdrawData packs the calldata of a withdraw call, which sends the
+// contract's balance to its owner
+func (s *Web3Service) WithdrawData() ([]byte, error) {
+	return s.pack("withdraw")
+}
+
+// pack encodes a call to a contract method
+func (s *Web3Service) pack(method string, args ...interface{}) ([]byte, error) {
+	data, err := s.abi.Pack(method, args...)
 	if err != nil {
-		return "", fmt.Errorf("failed to authenticate product: %v", err)
+		return nil, fmt.Errorf("failed to pack %s call: %v", method, err)
 	}
-
-	return tx.Hash().Hex(), nil
+	return data, nil
 }
 
 // UpdatePrice updates a product's price
 func (s *Web3Service) UpdatePrice(auth *bind.TransactOpts, productID *big.Int, newPrice *big.Int) (string, error) {
-	tx, err := s.contract.UpdatePrice(auth, productID, newPrice)
+	contract, err := s.transactor(auth)
+	if err != nil {
+		return "", fmt.Errorf("failed to update price: %v", err)
+	}
+
+	tx, err := contract.UpdatePrice(auth, productID, newPrice)
 	if err != nil {
 		return "", fmt.Errorf("failed to update price: %v", err)
 	}
@@ -116,49 +330,73 @@ func (s *Web3Service) UpdatePrice(auth *bind.TransactOpts, productID *big.Int, n
 	return tx.Hash().Hex(), nil
 }
 
-// GetProduct retrieves product details
-func (s *Web3Service) GetProduct(productID *big.Int) (*Product, error) {
-	product, err := s.contract.GetProduct(nil, productID)
+// GetProduct retrieves a product as recorded by the contract
+func (s *Web3Service) GetProduct(productID *big.Int) (*OnChainProduct, error) {
+	product, err := s.contract.Products(nil, productID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get product: %v", err)
 	}
+	if product.Seller == (common.Address{}) {
+		return nil, ErrProductNotMinted
+	}
 
-	return &Product{
-		ID:          productID.String(),
-		Name:        product.Name,
-		Price:       product.Price.String(),
-		Seller:      product.Seller.Hex(),
-		IsListed:    product.IsListed,
+	return &OnChainProduct{
+		TokenID:         product.Id,
+		Seller:          product.Seller,
+		Price:           product.Price,
 		IsAuthenticated: product.IsAuthenticated,
+		IsSold:          product.IsSold,
+		Metadata:        product.Metadata,
 	}, nil
 }
 
+// GetProductMetadata retrieves the metadata string stored when a product was listed
+func (s *Web3Service) GetProductMetadata(productID *big.Int) (string, error) {
+	product, err := s.contract.Products(nil, productID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get product metadata: %v", err)
+	}
+
+	return product.Metadata, nil
+}
+
+// GetPlatformFee retrieves the platform fee in parts per thousand. A nil
+// blockNumber reads the current fee; otherwise the fee in force at that block.
+func (s *Web3Service) GetPlatformFee(blockNumber *big.Int) (*big.Int, error) {
+	fee, err := s.contract.PlatformFee(&bind.CallOpts{BlockNumber: blockNumber})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get platform fee: %v", err)
+	}
+
+	return fee, nil
+}
+
 // GetProductPrice retrieves a product's price
 func (s *Web3Service) GetProductPrice(productID *big.Int) (*big.Int, error) {
-	price, err := s.contract.GetProductPrice(nil, productID)
+	product, err := s.GetProduct(productID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get product price: %v", err)
+		return nil, err
 	}
 
-	return price, nil
+	return product.Price, nil
 }
 
 // GetProductSeller retrieves a product's seller address
 func (s *Web3Service) GetProductSeller(productID *big.Int) (common.Address, error) {
-	seller, err := s.contract.GetProductSeller(nil, productID)
+	product, err := s.GetProduct(productID)
 	if err != nil {
-		return common.Address{}, fmt.Errorf("failed to get product seller: %v", err)
+		return common.Address{}, err
 	}
 
-	return seller, nil
+	return product.Seller, nil
 }
 
 // GetProductAuthentication retrieves a product's authentication status
 func (s *Web3Service) GetProductAuthentication(productID *big.Int) (bool, error) {
-	isAuthenticated, err := s.contract.GetProductAuthentication(nil, productID)
+	product, err := s.GetProduct(productID)
 	if err != nil {
-		return false, fmt.Errorf("failed to get product authentication: %v", err)
+		return false, err
 	}
 
-	return isAuthenticated, nil
-} 
\ No newline at end of file
+	return product.IsAuthenticated, nil
+}
@@ -2,108 +2,86 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // Event types
 const (
-	EventProductListed   = "ProductListed"
-	EventProductBought   = "ProductBought"
+	EventProductListed        = "ProductListed"
+	EventProductSold          = "ProductSold"
 	EventProductAuthenticated = "ProductAuthenticated"
-	EventPriceUpdated    = "PriceUpdated"
+	EventPriceUpdated         = "PriceUpdated"
+	EventTransfer             = "Transfer"
 )
 
-// StartEventListeners starts listening for contract events
-func (s *Web3Service) StartEventListeners(ctx context.Context) error {
-	// Create event query
-	query := ethereum.FilterQuery{
-		Addresses: []common.Address{s.contractAddr},
+// ErrUnknownEvent is returned for contract logs the backend does not
+// index, such as ERC-721 approvals
+var ErrUnknownEvent = errors.New("unknown contract event")
+
+// DecodeEvent decodes a ReVibe contract log. It returns the event's name,
+// the decoded event and its token ID.
+func (s *Web3Service) DecodeEvent(vLog types.Log) (string, interface{}, *big.Int, error) {
+	if vLog.Address != s.contractAddr {
+		return "", nil, nil, fmt.Errorf("log was not emitted by the ReVibe contract")
+	}
+	if event, err := s.contract.ParseProductListed(vLog); err == nil {
+		return EventProductListed, event, event.TokenId, nil
+	}
+	if event, err := s.contract.ParseProductSold(vLog); err == nil {
+		return EventProductSold, event, event.TokenId, nil
 	}
+	if event, err := s.contract.ParseProductAuthenticated(vLog); err == nil {
+		return EventProductAuthenticated, event, event.TokenId, nil
+	}
+	if event, err := s.contract.ParsePriceUpdated(vLog); err == nil {
+		return EventPriceUpdated, event, event.TokenId, nil
+	}
+	if event, err := s.contract.ParseTransfer(vLog); err == nil {
+		return EventTransfer, event, event.TokenId, nil
+	}
+	return "", nil, nil, ErrUnknownEvent
+}
 
-	// Subscribe to logs
-	logs := make(chan types.Log)
-	sub, err := s.client.SubscribeFilterLogs(ctx, query, logs)
+// DecodeEventArgs decodes any log of the ReVibe contract, including its
+// ERC-721 events, into the event's name and its arguments by name. Numbers
+// are returned as decimal strings.
+func (s *Web3Service) DecodeEventArgs(vLog types.Log) (string, map[string]interface{}, error) {
+	if vLog.Address != s.contractAddr || len(vLog.Topics) == 0 {
+		return "", nil, ErrUnknownEvent
+	}
+	event, err := s.abi.EventByID(vLog.Topics[0])
 	if err != nil {
-		return fmt.Errorf("failed to subscribe to logs: %v", err)
+		return "", nil, ErrUnknownEvent
 	}
 
-	// Start event processing
-	go func() {
-		for {
-			select {
-			case err := <-sub.Err():
-				log.Printf("Subscription error: %v", err)
-			case vLog := <-logs:
-				// Parse event
-				event, err := s.contract.ParseEvent(vLog)
-				if err != nil {
-					log.Printf("Failed to parse event: %v", err)
-					continue
-				}
-
-				// Handle event based on type
-				switch event.EventType {
-				case EventProductListed:
-					if e, ok := event.(*ReVibeContractProductListed); ok {
-						s.handleProductListed(e)
-					}
-				case EventProductBought:
-					if e, ok := event.(*ReVibeContractProductBought); ok {
-						s.handleProductBought(e)
-					}
-				case EventProductAuthenticated:
-					if e, ok := event.(*ReVibeContractProductAuthenticated); ok {
-						s.handleProductAuthenticated(e)
-					}
-				case EventPriceUpdated:
-					if e, ok := event.(*ReVibeContractPriceUpdated); ok {
-						s.handlePriceUpdated(e)
-					}
-				}
-			case <-ctx.Done():
-				return
-			}
+	args := make(map[string]interface{})
+	if err := event.Inputs.UnpackIntoMap(args, vLog.Data); err != nil {
+		return "", nil, fmt.Errorf("failed to decode %s: %v", event.Name, err)
+	}
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
 		}
-	}()
-
-	return nil
-}
-
-// handleProductListed handles the ProductListed event
-func (s *Web3Service) handleProductListed(event *ReVibeContractProductListed) {
-	log.Printf("Product listed: ID=%s, Name=%s, Price=%s, Seller=%s",
-		event.ProductId.String(),
-		event.Name,
-		event.Price.String(),
-		event.Seller.Hex())
-}
-
-// handleProductBought handles the ProductBought event
-func (s *Web3Service) handleProductBought(event *ReVibeContractProductBought) {
-	log.Printf("Product bought: ID=%s, Buyer=%s, Price=%s",
-		event.ProductId.String(),
-		event.Buyer.Hex(),
-		event.Price.String())
-}
-
-// handleProductAuthenticated handles the ProductAuthenticated event
-func (s *Web3Service) handleProductAuthenticated(event *ReVibeContractProductAuthenticated) {
-	log.Printf("Product authenticated: ID=%s, Result=%v",
-		event.ProductId.String(),
-		event.Result)
-}
+	}
+	if err := abi.ParseTopicsIntoMap(args, indexed, vLog.Topics[1:]); err != nil {
+		return "", nil, fmt.Errorf("failed to decode %s topics: %v", event.Name, err)
+	}
 
-// handlePriceUpdated handles the PriceUpdated event
-func (s *Web3Service) handlePriceUpdated(event *ReVibeContractPriceUpdated) {
-	log.Printf("Price updated: ID=%s, NewPrice=%s",
-		event.ProductId.String(),
-		event.NewPrice.String())
+	for name, value := range args {
+		if number, ok := value.(*big.Int); ok {
+			args[name] = number.String()
+		}
+	}
+	return event.Name, args, nil
 }
 
 // GetPastEvents retrieves past events
@@ -114,5 +92,23 @@ func (s *Web3Service) GetPastEvents(ctx context.Context, fromBlock, toBlock *big
 		Addresses: []common.Address{s.contractAddr},
 	}
 
-	return s.client.FilterLogs(ctx, query)
-} 
\ No newline at end of file
+	return s.backend.FilterLogs(ctx, query)
+}
+
+// BlockHash returns the hash of the canonical block at a height
+func (s *Web3Service) BlockHash(ctx context.Context, number uint64) (common.Hash, error) {
+	header, err := s.backend.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return header.Hash(), nil
+}
+
+// BlockTime returns the timestamp of the canonical block at a height
+func (s *Web3Service) BlockTime(ctx context.Context, number uint64) (time.Time, error) {
+	header, err := s.backend.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(int64(header.Time), 0).UTC(), nil
+}
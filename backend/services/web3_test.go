@@ -0,0 +1,429 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/revibe/backend/contracts"
+	"github.com/yourusername/revibe/backend/models"
+)
+
+// Where the compiled ReVibe contract is looked for: the bytecode written by
+// `npm run export-abi`, then hardhat's own artifact
+const (
+	reVibeBinPath      = "../contracts/ReVibe.bin"
+	reVibeArtifactPath = "../../contracts/artifacts/contracts/ReVibe.sol/ReVibe.json"
+)
+
+var oneEther = big.NewInt(1e18)
+
+// reVibeBytecode returns the compiled ReVibe contract, skipping the test
+// when it has not been built
+func reVibeBytecode(t *testing.T) []byte {
+	if data, err := os.ReadFile(reVibeBinPath); err == nil {
+		return common.FromHex(strings.TrimSpace(string(data)))
+	}
+
+	data, err := os.ReadFile(reVibeArtifactPath)
+	if err != nil {
+		t.Skip("compiled ReVibe contract not found; run `npm run export-abi` in contracts/")
+	}
+	var artifact struct {
+		Bytecode string `json:"bytecode"`
+	}
+	require.NoError(t, json.Unmarshal(data, &artifact))
+	return common.FromHex(artifact.Bytecode)
+}
+
+// simulatedChain is the ReVibe contract deployed on go-ethereum's in-memory
+// chain, with funded accounts for the contract owner, a seller and a buyer
+type simulatedChain struct {
+	t       *testing.T
+	backend *simulated.Backend
+	client  simulated.Client
+	web3    *Web3Service
+
+	owner  *bind.TransactOpts
+	seller *bind.TransactOpts
+	buyer  *bind.TransactOpts
+}
+
+// newSimulatedChain deploys the compiled ReVibe contract on a simulated
+// backend and returns a Web3Service using it
+func newSimulatedChain(t *testing.T) *simulatedChain {
+	bytecode := reVibeBytecode(t)
+
+	keys := make([]*ecdsa.PrivateKey, 3)
+	alloc := make(types.GenesisAlloc)
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys[i] = key
+		alloc[crypto.PubkeyToAddress(key.PublicKey)] = types.Account{
+			Balance: new(big.Int).Mul(big.NewInt(100), oneEther),
+		}
+	}
+
+	backend := simulated.NewBackend(alloc)
+	t.Cleanup(func() { backend.Close() })
+	client := backend.Client()
+
+	chainID, err := client.ChainID(context.Background())
+	require.NoError(t, err)
+
+	opts := make([]*bind.TransactOpts, len(keys))
+	for i, key := range keys {
+		opts[i], err = bind.NewKeyedTransactorWithChainID(key, chainID)
+		require.NoError(t, err)
+	}
+
+	contractABI, err := contracts.ReVibeContractMetaData.GetAbi()
+	require.NoError(t, err)
+	address, _, _, err := bind.DeployContract(opts[0], *contractABI, bytecode, client)
+	require.NoError(t, err)
+	backend.Commit()
+
+	web3, err := NewWeb3ServiceWithBackend(client, chainID, address)
+	require.NoError(t, err)
+
+	return &simulatedChain{
+		t:       t,
+		backend: backend,
+		client:  client,
+		web3:    web3,
+		owner:   opts[0],
+		seller:  opts[1],
+		buyer:   opts[2],
+	}
+}
+
+// mine commits a block and returns the receipt of a transaction, failing
+// the test if it reverted
+func (c *simulatedChain) mine(txHash string) *types.Receipt {
+	c.backend.Commit()
+	receipt, err := c.web3.GetTransactionReceipt(context.Background(), txHash)
+	require.NoError(c.t, err)
+	require.Equal(c.t, types.ReceiptStatusSuccessful, receipt.Status)
+	return receipt
+}
+
+// operatorCall sends calldata packed by Web3Service to the contract from
+// opts, the way the transaction manager sends operator calls
+func (c *simulatedChain) operatorCall(opts *bind.TransactOpts, data []byte) (string, error) {
+	contract := bind.NewBoundContract(c.web3.ContractAddress(), *c.web3.abi, c.client, c.client, c.client)
+	tx, err := contract.RawTransact(opts, data)
+	if err != nil {
+		return "", err
+	}
+	return tx.Hash().Hex(), nil
+}
+
+// list lists a product from the seller and returns its token ID
+func (c *simulatedChain) list(price *big.Int) *big.Int {
+	txHash, err := c.web3.ListProduct(c.seller, price, "ipfs://product")
+	require.NoError(c.t, err)
+	event := c.web3.FindProductListed(c.mine(txHash))
+	require.NotNil(c.t, event)
+	return event.TokenId
+}
+
+// authenticate marks a product authenticated from the owner
+func (c *simulatedChain) authenticate(tokenID *big.Int) {
+	data, err := c.web3.AuthenticateProductData(tokenID, true)
+	require.NoError(c.t, err)
+	txHash, err := c.operatorCall(c.owner, data)
+	require.NoError(c.t, err)
+	c.mine(txHash)
+}
+
+// buy buys a product from the buyer, paying value
+func (c *simulatedChain) buy(tokenID, value *big.Int) (string, error) {
+	opts := *c.buyer
+	opts.Value = value
+	return c.web3.BuyProduct(&opts, tokenID)
+}
+
+func (c *simulatedChain) balance(opts *bind.TransactOpts) *big.Int {
+	balance, err := c.client.BalanceAt(context.Background(), opts.From, nil)
+	require.NoError(c.t, err)
+	return balance
+}
+
+func TestWeb3ServiceListProduct(t *testing.T) {
+	chain := newSimulatedChain(t)
+	price := big.NewInt(5e16)
+
+	txHash, err := chain.web3.ListProduct(chain.seller, price, "ipfs://product")
+	require.NoError(t, err)
+	event := chain.web3.FindProductListed(chain.mine(txHash))
+	require.NotNil(t, event)
+	assert.Equal(t, int64(1), event.TokenId.Int64())
+	assert.Equal(t, chain.seller.From, event.Seller)
+	assert.Equal(t, price, event.Price)
+
+	product, err := chain.web3.GetProduct(event.TokenId)
+	require.NoError(t, err)
+	assert.Equal(t, chain.seller.From, product.Seller)
+	assert.Equal(t, price, product.Price)
+	assert.Equal(t, "ipfs://product", product.Metadata)
+	assert.False(t, product.IsAuthenticated)
+	assert.False(t, product.IsSold)
+
+	tokenIDs, err := chain.web3.GetUserProducts(chain.seller.From)
+	require.NoError(t, err)
+	assert.Equal(t, []*big.Int{big.NewInt(1)}, tokenIDs)
+
+	_, err = chain.web3.GetProduct(big.NewInt(2))
+	assert.ErrorIs(t, err, ErrProductNotMinted)
+
+	// The contract rejects a zero price before anything is sent
+	_, err = chain.web3.ListProduct(chain.seller, big.NewInt(0), "ipfs://free")
+	assert.Error(t, err)
+}
+
+func TestWeb3ServiceAuthenticateProduct(t *testing.T) {
+	chain := newSimulatedChain(t)
+	tokenID := chain.list(big.NewInt(5e16))
+
+	// Only the contract owner may authenticate
+	data, err := chain.web3.AuthenticateProductData(tokenID, true)
+	require.NoError(t, err)
+	_, err = chain.operatorCall(chain.seller, data)
+	assert.Error(t, err)
+
+	chain.authenticate(tokenID)
+	authenticated, err := chain.web3.GetProductAuthentication(tokenID)
+	require.NoError(t, err)
+	assert.True(t, authenticated)
+
+	// The other operator calls
+	data, err = chain.web3.UpdatePlatformFeeData(big.NewInt(50))
+	require.NoError(t, err)
+	txHash, err := chain.operatorCall(chain.owner, data)
+	require.NoError(t, err)
+	chain.mine(txHash)
+	fee, err := chain.web3.GetPlatformFee(nil)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(50), fee)
+
+	data, err = chain.web3.WithdrawData()
+	require.NoError(t, err)
+	txHash, err = chain.operatorCall(chain.owner, data)
+	require.NoError(t, err)
+	chain.mine(txHash)
+}
+
+func TestWeb3ServiceBuyProduct(t *testing.T) {
+	chain := newSimulatedChain(t)
+	price := big.NewInt(1e17)
+	tokenID := chain.list(price)
+
+	// Unauthenticated products cannot be bought
+	_, err := chain.buy(tokenID, price)
+	assert.Error(t, err)
+
+	chain.authenticate(tokenID)
+
+	// Nor can they be bought for less than the price
+	_, err = chain.buy(tokenID, big.NewInt(1e16))
+	assert.Error(t, err)
+
+	sellerBefore := chain.balance(chain.seller)
+	ownerBefore := chain.balance(chain.owner)
+
+	txHash, err := chain.buy(tokenID, price)
+	require.NoError(t, err)
+	event := chain.web3.FindProductSold(chain.mine(txHash))
+	require.NotNil(t, event)
+	assert.Equal(t, tokenID, event.TokenId)
+	assert.Equal(t, chain.seller.From, event.Seller)
+	assert.Equal(t, chain.buyer.From, event.Buyer)
+	assert.Equal(t, price, event.Price)
+
+	// The default platform fee is 2.5%
+	fee := new(big.Int).Div(new(big.Int).Mul(price, big.NewInt(25)), big.NewInt(1000))
+	assert.Equal(t, new(big.Int).Add(sellerBefore, new(big.Int).Sub(price, fee)), chain.balance(chain.seller))
+	assert.Equal(t, new(big.Int).Add(ownerBefore, fee), chain.balance(chain.owner))
+
+	product, err := chain.web3.GetProduct(tokenID)
+	require.NoError(t, err)
+	assert.True(t, product.IsSold)
+
+	purchases, err := chain.web3.GetUserPurchases(chain.buyer.From)
+	require.NoError(t, err)
+	assert.Equal(t, []*big.Int{tokenID}, purchases)
+
+	// A product sells once
+	_, err = chain.buy(tokenID, price)
+	assert.Error(t, err)
+}
+
+func TestWeb3ServiceRevertReason(t *testing.T) {
+	chain := newSimulatedChain(t)
+	price := big.NewInt(1e17)
+	tokenID := chain.list(price)
+	chain.authenticate(tokenID)
+
+	// A fixed gas limit skips estimation, so the failing purchase is mined
+	opts := *chain.buyer
+	opts.Value = big.NewInt(1e16)
+	opts.GasLimit = 300000
+	txHash, err := chain.web3.BuyProduct(&opts, tokenID)
+	require.NoError(t, err)
+	chain.backend.Commit()
+
+	ctx := context.Background()
+	receipt, err := chain.web3.GetTransactionReceipt(ctx, txHash)
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusFailed, receipt.Status)
+	tx, _, err := chain.web3.TransactionByHash(ctx, receipt.TxHash)
+	require.NoError(t, err)
+
+	reason, err := chain.web3.RevertReason(ctx, tx, chain.buyer.From, receipt.BlockNumber.Uint64())
+	require.NoError(t, err)
+	assert.Equal(t, "Insufficient payment", reason)
+}
+
+func TestWeb3ServiceUpdatePrice(t *testing.T) {
+	chain := newSimulatedChain(t)
+	tokenID := chain.list(big.NewInt(1e17))
+	newPrice := big.NewInt(8e16)
+
+	// Only the seller may change the price
+	_, err := chain.web3.UpdatePrice(chain.buyer, tokenID, newPrice)
+	assert.Error(t, err)
+
+	txHash, err := chain.web3.UpdatePrice(chain.seller, tokenID, newPrice)
+	require.NoError(t, err)
+	chain.mine(txHash)
+
+	price, err := chain.web3.GetProductPrice(tokenID)
+	require.NoError(t, err)
+	assert.Equal(t, newPrice, price)
+}
+
+func TestWeb3ServiceEvents(t *testing.T) {
+	chain := newSimulatedChain(t)
+	ctx := context.Background()
+	from, err := chain.web3.BlockNumber(ctx)
+	require.NoError(t, err)
+
+	tokenID := chain.list(big.NewInt(1e17))
+	chain.authenticate(tokenID)
+	txHash, err := chain.web3.UpdatePrice(chain.seller, tokenID, big.NewInt(9e16))
+	require.NoError(t, err)
+	chain.mine(txHash)
+	txHash, err = chain.buy(tokenID, big.NewInt(9e16))
+	require.NoError(t, err)
+	soldReceipt := chain.mine(txHash)
+
+	to, err := chain.web3.BlockNumber(ctx)
+	require.NoError(t, err)
+	logs, err := chain.web3.GetPastEvents(ctx, new(big.Int).SetUint64(from), new(big.Int).SetUint64(to))
+	require.NoError(t, err)
+
+	var names []string
+	for _, vLog := range logs {
+		name, _, eventTokenID, err := chain.web3.DecodeEvent(vLog)
+		require.NoError(t, err)
+		assert.Equal(t, tokenID, eventTokenID)
+		names = append(names, name)
+	}
+	assert.Equal(t, []string{
+		EventTransfer, EventProductListed, EventProductAuthenticated, EventPriceUpdated, EventTransfer, EventProductSold,
+	}, names)
+
+	// Every log, including ERC-721 transfers, decodes with its arguments
+	for _, vLog := range soldReceipt.Logs {
+		name, args, err := chain.web3.DecodeEventArgs(*vLog)
+		require.NoError(t, err)
+		if name == "Transfer" {
+			assert.Equal(t, chain.seller.From, args["from"])
+			assert.Equal(t, chain.buyer.From, args["to"])
+			assert.Equal(t, tokenID.String(), args["tokenId"])
+		}
+	}
+
+	sold, err := chain.web3.GetProductSoldEvents(ctx, new(big.Int).SetUint64(from), new(big.Int).SetUint64(to))
+	require.NoError(t, err)
+	require.Len(t, sold, 1)
+	assert.Equal(t, chain.buyer.From, sold[0].Buyer)
+	assert.Equal(t, soldReceipt.BlockNumber.Uint64(), sold[0].Raw.BlockNumber)
+
+	hash, err := chain.web3.BlockHash(ctx, soldReceipt.BlockNumber.Uint64())
+	require.NoError(t, err)
+	assert.Equal(t, soldReceipt.BlockHash, hash)
+
+	listedAt, err := chain.web3.BlockTime(ctx, logs[0].BlockNumber)
+	require.NoError(t, err)
+	soldAt, err := chain.web3.BlockTime(ctx, soldReceipt.BlockNumber.Uint64())
+	require.NoError(t, err)
+	assert.True(t, soldAt.After(listedAt))
+}
+
+func TestWeb3ServiceIndexedSale(t *testing.T) {
+	chain := newSimulatedChain(t)
+	market := newTestMarket(t, chain.web3)
+	seller := createUser(t, market.db, chain.seller.From.Hex())
+	buyer := createUser(t, market.db, chain.buyer.From.Hex())
+
+	product := createProduct(t, market.db, seller)
+	price := big.NewInt(1e17)
+	listTx, err := chain.web3.ListProduct(chain.seller, price, "ipfs://product")
+	require.NoError(t, err)
+	require.NoError(t, market.db.Create(&models.ListingTransaction{
+		ProductID:       product.ID,
+		TxHash:          listTx,
+		ChainID:         chain.web3.ChainID().Int64(),
+		ContractAddress: chain.web3.ContractAddress().Hex(),
+	}).Error)
+	tokenID := chain.web3.FindProductListed(chain.mine(listTx)).TokenId
+	chain.authenticate(tokenID)
+
+	saleTx, err := chain.buy(tokenID, price)
+	require.NoError(t, err)
+	order := models.Order{
+		ProductID: product.ID,
+		BuyerID:   buyer.ID,
+		SellerID:  seller.ID,
+		Price:     0.1,
+		TokenID:   tokenID.String(),
+		Status:    models.OrderStatusPending,
+		TxHash:    saleTx,
+	}
+	require.NoError(t, market.db.Create(&order).Error)
+	sold := chain.mine(saleTx)
+
+	market.sync()
+
+	market.reload(product, product.ID)
+	require.NotNil(t, product.TokenID)
+	assert.Equal(t, tokenID.String(), *product.TokenID)
+	assert.Equal(t, models.ProductStatusSold, product.Status)
+	assert.Equal(t, chain.buyer.From.Hex(), product.OwnerAddress)
+
+	market.reload(&order, order.ID)
+	assert.Equal(t, models.OrderStatusCompleted, order.Status)
+	assert.Equal(t, sold.BlockNumber.Uint64(), order.BlockNumber)
+
+	var journal models.LedgerJournal
+	require.NoError(t, market.db.First(&journal, "order_id = ?", order.ID).Error)
+	assert.Equal(t, int64(25), journal.FeeRatePerMille)
+	assert.Equal(t, "2500000000000000", journal.FeeWei)
+
+	// Mint and sale transfers, listing, authentication and sale
+	assert.Equal(t, int64(5), market.count(&models.ChainEvent{}, "contract_address = ?", chain.web3.ContractAddress().Hex()))
+	assert.Equal(t, int64(2), market.count(&models.TokenTransfer{}, "token_id = ?", tokenID.String()))
+}
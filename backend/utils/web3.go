@@ -63,17 +63,15 @@ func VerifySignature(address common.Address, message []byte, signature string) (
 	return recoveredAddr == address, nil
 }
 
-// CreateAuth creates a new auth transactor
+// CreateAuth creates a new auth transactor. Gas limit and fees are left
+// unset so they are estimated per transaction, with EIP-1559 fees where the
+// chain supports them.
 func CreateAuth(privateKey *ecdsa.PrivateKey, chainID *big.Int) (*bind.TransactOpts, error) {
 	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create auth: %v", err)
 	}
 
-	// Set gas price and limit
-	auth.GasPrice = big.NewInt(20000000000) // 20 Gwei
-	auth.GasLimit = uint64(3000000)
-
 	return auth, nil
 }
 
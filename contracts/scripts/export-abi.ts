@@ -0,0 +1,24 @@
+import { artifacts } from "hardhat";
+import * as fs from "fs";
+import * as path from "path";
+
+// Writes the compiled ReVibe ABI for the backend's Go bindings, and its
+// bytecode for the backend's simulated chain tests
+const OUTPUT_DIR = path.join(__dirname, "..", "..", "backend", "contracts");
+
+async function main() {
+  const artifact = await artifacts.readArtifact("ReVibe");
+
+  const abiPath = path.join(OUTPUT_DIR, "ReVibe.abi");
+  fs.writeFileSync(abiPath, JSON.stringify(artifact.abi, null, 2) + "\n");
+  console.log("ReVibe ABI written to:", abiPath);
+
+  const binPath = path.join(OUTPUT_DIR, "ReVibe.bin");
+  fs.writeFileSync(binPath, artifact.bytecode + "\n");
+  console.log("ReVibe bytecode written to:", binPath);
+}
+
+main().catch((error) => {
+  console.error(error);
+  process.exitCode = 1;
+});